@@ -19,6 +19,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/mohae/deepcopy"
 	"github.com/xuri/efp"
 )
 
@@ -416,6 +417,15 @@ func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative bool, tok
 // adjustFormulaRef returns adjusted formula by giving adjusting direction and
 // the base number of column or row, and offset.
 func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool, dir adjustDirection, num, offset int) (string, error) {
+	return f.adjustFormulaOperands(sheet, formula, func(token efp.Token) (string, error) {
+		return f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
+	})
+}
+
+// adjustFormulaOperands returns the formula with each range operand replaced by
+// the result of the given function. Operands which are defined names in the
+// scope of the given worksheet or structured references are kept as is.
+func (f *File) adjustFormulaOperands(sheet, formula string, fn func(token efp.Token) (string, error)) (string, error) {
 	var (
 		val          string
 		definedNames []string
@@ -440,7 +450,7 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 				val += token.TValue
 				continue
 			}
-			operand, err := f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
+			operand, err := fn(token)
 			if err != nil {
 				return val, err
 			}
@@ -668,6 +678,14 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 			idx--
 			continue
 		}
+		oldX1, oldColumns := coordinates[0], getTableColumnNames(&t)
+		// Remove the totals row when deleting the totals row of the table
+		if dir == rows && num == coordinates[3] && offset == -1 && t.TotalsRowCount > 0 {
+			t.TotalsRowCount = 0
+			for _, column := range getTableColumnList(&t) {
+				column.TotalsRowFunction, column.TotalsRowLabel, column.TotalsRowFormula = "", "", nil
+			}
+		}
 		coordinates = f.adjustAutoFilterHelper(dir, coordinates, num, offset)
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 		if y2-y1 < 1 || x2-x1 < 0 {
@@ -678,17 +696,158 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		}
 		t.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2})
 		if t.AutoFilter != nil {
-			t.AutoFilter.Ref = t.Ref
+			t.AutoFilter.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2 - t.TotalsRowCount})
 		}
 		_ = f.setTableColumns(sheet, true, x1, y1, x2, &t)
+		newColumns, renamed := getTableColumnNames(&t), map[string]string{}
+		for i, name := range oldColumns {
+			col := oldX1 + i
+			if dir == columns && col >= num {
+				if offset < 0 && col == num {
+					renamed[strings.ToLower(name)] = ""
+					continue
+				}
+				col += offset
+			}
+			if col-x1 >= 0 && col-x1 < len(newColumns) && newColumns[col-x1] != name {
+				renamed[strings.ToLower(name)] = newColumns[col-x1]
+			}
+		}
+		if err = f.renameStructuredRefs(sheet, &t, t.Name, coordinates, renamed); err != nil {
+			return err
+		}
+		if err = f.fillTableCalculatedColumns(sheet, &t, coordinates); err != nil {
+			return err
+		}
 		// Currently doesn't support query table
-		t.TableType, t.TotalsRowCount, t.ConnectionID = "", 0, 0
+		t.TableType, t.ConnectionID = "", 0
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
 	return nil
 }
 
+// getTableColumnList returns the columns of the table.
+func getTableColumnList(t *xlsxTable) []*xlsxTableColumn {
+	if t.TableColumns == nil {
+		return nil
+	}
+	return t.TableColumns.TableColumn
+}
+
+// getTableColumnNames returns the column names of the table.
+func getTableColumnNames(t *xlsxTable) []string {
+	var names []string
+	for _, column := range getTableColumnList(t) {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// renameStructuredRefs provides a function to update the structured
+// references of the table in the formulas of the workbook when the table or
+// the columns of the table have been renamed. The keys of the columns map are
+// the lowercase old column names, and the references to the column which
+// mapped to an empty name will be replaced with the #REF! error. The
+// unqualified structured references only be updated in the cells of the
+// table on the given worksheet and the column formulas of the table. The
+// given table definition will be updated but not be saved.
+func (f *File) renameStructuredRefs(sheet string, t *xlsxTable, oldName string, coordinates []int, columns map[string]string) error {
+	newName := t.Name
+	if oldName == newName && len(columns) == 0 {
+		return nil
+	}
+	rename := func(formula string, unqualified bool) (string, error) {
+		if !strings.Contains(formula, "[") {
+			return formula, nil
+		}
+		return replaceStructuredRefs(formula, func(ref *structuredRef, text string) (string, error) {
+			if (ref.table != "" || !unqualified) && !strings.EqualFold(ref.table, oldName) {
+				return text, nil
+			}
+			var changed bool
+			for i, column := range ref.columns {
+				if name, ok := columns[strings.ToLower(column)]; ok {
+					if name == "" {
+						return formulaErrorREF, nil
+					}
+					ref.columns[i], changed = name, true
+				}
+			}
+			if ref.table != "" && ref.table != newName {
+				ref.table, changed = newName, true
+			}
+			if !changed {
+				return text, nil
+			}
+			return ref.String(), nil
+		})
+	}
+	for _, sheetN := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		for r := range ws.SheetData.Row {
+			for c := range ws.SheetData.Row[r].C {
+				cell := &ws.SheetData.Row[r].C[c]
+				if cell.F == nil || cell.F.Content == "" {
+					continue
+				}
+				col, row, _ := CellNameToCoordinates(cell.R)
+				unqualified := sheetN == sheet && cellInRange([]int{col, row}, coordinates)
+				if cell.F.Content, err = rename(cell.F.Content, unqualified); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			if definedName.Data, err = rename(definedName.Data, false); err != nil {
+				return err
+			}
+		}
+	}
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	for _, info := range tables {
+		tbl, self := info.table, info.table.ID == t.ID
+		if self {
+			tbl = t
+		}
+		var changed bool
+		for _, column := range getTableColumnList(tbl) {
+			for _, formula := range []*xlsxTableFormula{column.CalculatedColumnFormula, column.TotalsRowFormula} {
+				if formula == nil {
+					continue
+				}
+				content, err := rename(formula.Content, self)
+				if err != nil {
+					return err
+				}
+				changed = changed || content != formula.Content
+				formula.Content = content
+			}
+		}
+		if changed && !self {
+			table, _ := xml.Marshal(tbl)
+			f.saveFileList(info.tableXML, table)
+		}
+	}
+	return nil
+}
+
 // adjustAutoFilter provides a function to update the auto filter when
 // inserting or deleting rows or columns.
 func (f *File) adjustAutoFilter(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
@@ -1148,3 +1307,1010 @@ func (f *File) adjustDefinedNames(ws *xlsxWorksheet, sheet string, dir adjustDir
 	}
 	return nil
 }
+
+// moveRange defines the source and destination range of cells on moving a
+// range of cells, the coordinates of the range are sorted as [x1, y1, x2, y2].
+type moveRange struct {
+	srcSheet, dstSheet string
+	src, dst           []int
+}
+
+// offset returns the columns and rows distance from the source range to the
+// destination range.
+func (mr *moveRange) offset() (int, int) {
+	return mr.dst[0] - mr.src[0], mr.dst[1] - mr.src[1]
+}
+
+// shift returns the coordinates moved by the distance from the source range to
+// the destination range.
+func (mr *moveRange) shift(coordinates []int) []int {
+	dCol, dRow := mr.offset()
+	return []int{coordinates[0] + dCol, coordinates[1] + dRow, coordinates[2] + dCol, coordinates[3] + dRow}
+}
+
+// inRect provides a function to determine if the first given range is within
+// the second range.
+func inRect(rect1, rect2 []int) bool {
+	return cellInRange([]int{rect1[0], rect1[1]}, rect2) && cellInRange([]int{rect1[2], rect1[3]}, rect2)
+}
+
+// intersectRect returns the intersection of the given two ranges, returns nil
+// if they don't overlap.
+func intersectRect(rect1, rect2 []int) []int {
+	if rect1[0] > rect2[2] || rect2[0] > rect1[2] || rect1[1] > rect2[3] || rect2[1] > rect1[3] {
+		return nil
+	}
+	rect := []int{rect1[0], rect1[1], rect1[2], rect1[3]}
+	if rect2[0] > rect[0] {
+		rect[0] = rect2[0]
+	}
+	if rect2[1] > rect[1] {
+		rect[1] = rect2[1]
+	}
+	if rect2[2] < rect[2] {
+		rect[2] = rect2[2]
+	}
+	if rect2[3] < rect[3] {
+		rect[3] = rect2[3]
+	}
+	return rect
+}
+
+// subtractRect returns the ranges of the first given range which are outside
+// of the second range.
+func subtractRect(rect1, rect2 []int) [][]int {
+	inter := intersectRect(rect1, rect2)
+	if inter == nil {
+		return [][]int{rect1}
+	}
+	var rects [][]int
+	if rect1[1] < inter[1] {
+		rects = append(rects, []int{rect1[0], rect1[1], rect1[2], inter[1] - 1})
+	}
+	if rect1[3] > inter[3] {
+		rects = append(rects, []int{rect1[0], inter[3] + 1, rect1[2], rect1[3]})
+	}
+	if rect1[0] < inter[0] {
+		rects = append(rects, []int{rect1[0], inter[1], inter[0] - 1, inter[3]})
+	}
+	if rect1[2] > inter[2] {
+		rects = append(rects, []int{inter[2] + 1, inter[1], rect1[2], inter[3]})
+	}
+	return rects
+}
+
+// rectsToSQRef returns the space-separated list of references by given ranges.
+func rectsToSQRef(rects [][]int) (string, error) {
+	var refs []string
+	for _, rect := range rects {
+		ref, err := coordinatesToRangeRef(rect)
+		if err != nil {
+			return "", err
+		}
+		if rect[0] == rect[2] && rect[1] == rect[3] {
+			ref = strings.Split(ref, ":")[0]
+		}
+		refs = append(refs, ref)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// sqrefToRects returns ranges of the given space-separated list of references.
+func sqrefToRects(sqref string) ([][]int, error) {
+	var rects [][]int
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return rects, err
+		}
+		_ = sortCoordinates(rect)
+		rects = append(rects, rect)
+	}
+	return rects, nil
+}
+
+// splitSQRef splits the given space-separated list of references into the
+// ranges outside of the given range, and the ranges inside of it.
+func splitSQRef(sqref string, rect []int) ([][]int, [][]int, error) {
+	var outside, inside [][]int
+	rects, err := sqrefToRects(sqref)
+	if err != nil {
+		return outside, inside, err
+	}
+	for _, r := range rects {
+		outside = append(outside, subtractRect(r, rect)...)
+		if inter := intersectRect(r, rect); inter != nil {
+			inside = append(inside, inter)
+		}
+	}
+	return outside, inside, err
+}
+
+// splitOperandSheet splits the range operand of formula into the unescaped
+// worksheet name and reference, and returns if the operand has an explicit
+// worksheet name.
+func splitOperandSheet(operand string) (string, string, bool) {
+	idx := strings.LastIndex(operand, "!")
+	if idx == -1 {
+		return "", operand, false
+	}
+	sheet := operand[:idx]
+	if len(sheet) > 1 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	return sheet, operand[idx+1:], true
+}
+
+// parseOperandCells parses a cell or range of cells reference in the formula
+// operand, returns the coordinates and the absolute flags of the column and
+// row for each corner cell.
+func parseOperandCells(ref string) ([]int, []bool, bool) {
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return nil, nil, false
+	}
+	var (
+		coordinates []int
+		abs         []bool
+	)
+	for _, part := range parts {
+		col, row, err := CellNameToCoordinates(part)
+		if err != nil {
+			return nil, nil, false
+		}
+		coordinates = append(coordinates, col, row)
+		abs = append(abs, strings.HasPrefix(part, "$"), strings.LastIndex(part, "$") > 0)
+	}
+	return coordinates, abs, true
+}
+
+// joinOperandCells returns the cell or range of cells reference by given
+// coordinates and absolute flags.
+func joinOperandCells(coordinates []int, abs []bool) string {
+	var parts []string
+	for i := 0; i < len(coordinates); i += 2 {
+		var colSign, rowSign string
+		if abs[i] {
+			colSign = "$"
+		}
+		if abs[i+1] {
+			rowSign = "$"
+		}
+		colName, _ := ColumnNumberToName(coordinates[i])
+		parts = append(parts, colSign+colName+rowSign+strconv.Itoa(coordinates[i+1]))
+	}
+	return strings.Join(parts, ":")
+}
+
+// adjustOperand returns the range operand after moving the range of cells. The
+// sheetN is the worksheet name used to resolve the reference without worksheet
+// name, and hostSheet is the worksheet name where the formula will be stored.
+// A reference entirely inside the source range follows the moved cells, and a
+// reference entirely inside the destination range becomes #REF! error, since
+// the cells were overwritten.
+func (mr *moveRange) adjustOperand(sheetN, hostSheet, operand string) (string, bool) {
+	sheet, ref, explicit := splitOperandSheet(operand)
+	if !explicit {
+		sheet = sheetN
+	}
+	if strings.Contains(sheet, ":") {
+		return operand, false
+	}
+	target, changed := sheet, false
+	if coordinates, abs, ok := parseOperandCells(ref); ok {
+		rect := coordinates
+		if len(rect) == 2 {
+			rect = append(rect, coordinates...)
+		}
+		rect = []int{rect[0], rect[1], rect[2], rect[3]}
+		_ = sortCoordinates(rect)
+		if sheet == mr.srcSheet && inRect(rect, mr.src) {
+			dCol, dRow := mr.offset()
+			for i := 0; i < len(coordinates); i += 2 {
+				coordinates[i], coordinates[i+1] = coordinates[i]+dCol, coordinates[i+1]+dRow
+			}
+			target, ref, changed = mr.dstSheet, joinOperandCells(coordinates, abs), true
+		} else if sheet == mr.dstSheet && inRect(rect, mr.dst) {
+			return formulaErrorREF, true
+		}
+	}
+	if explicit || target != hostSheet {
+		return escapeSheetName(target) + "!" + ref, changed || (!explicit && target != hostSheet)
+	}
+	return ref, changed
+}
+
+// adjustMoveFormula returns the formula with updated references after moving
+// the range of cells. The formula will be returned as is if there are no
+// references need to be updated.
+func (f *File) adjustMoveFormula(mr *moveRange, sheetN, hostSheet, formula string) (string, error) {
+	var changed bool
+	val, err := f.adjustFormulaOperands(sheetN, formula, func(token efp.Token) (string, error) {
+		operand, ok := mr.adjustOperand(sheetN, hostSheet, token.TValue)
+		changed = changed || ok
+		return operand, nil
+	})
+	if err != nil || !changed {
+		return formula, err
+	}
+	return val, err
+}
+
+// adjustMoveCellFormulas updates the formulas of cells in the worksheet after
+// moving the range of cells. The shared formulas which formula of any cells
+// should be updated will be converted to normal formulas.
+func (f *File) adjustMoveCellFormulas(mr *moveRange, ws *xlsxWorksheet, sheet string) error {
+	masters, formulas, unshared := map[int]*xlsxC{}, map[string]string{}, map[int]bool{}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F != nil && cell.F.T == STCellFormulaTypeShared && cell.F.Ref != "" && cell.F.Si != nil {
+				masters[*cell.F.Si] = cell
+			}
+		}
+	}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F == nil {
+				continue
+			}
+			if cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil {
+				master, ok := masters[*cell.F.Si]
+				if !ok {
+					continue
+				}
+				formula := getSharedFormulaByMaster(master, cell.R)
+				val, err := f.adjustMoveFormula(mr, sheet, sheet, formula)
+				if err != nil {
+					return err
+				}
+				if formulas[cell.R] = val; val != formula {
+					unshared[*cell.F.Si] = true
+				}
+				continue
+			}
+			if cell.F.Content != "" {
+				val, err := f.adjustMoveFormula(mr, sheet, sheet, cell.F.Content)
+				if err != nil {
+					return err
+				}
+				cell.F.Content = val
+			}
+		}
+	}
+	if len(unshared) == 0 {
+		return nil
+	}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			if cell := &ws.SheetData.Row[r].C[c]; cell.F != nil && cell.F.Si != nil && unshared[*cell.F.Si] {
+				cell.F = &xlsxF{Content: formulas[cell.R]}
+			}
+		}
+	}
+	return nil
+}
+
+// adjustMoveDataValidations updates the formulas of data validations in the
+// worksheet after moving the range of cells.
+func (f *File) adjustMoveDataValidations(mr *moveRange, dvs []*xlsxDataValidation, sheetN, hostSheet string) error {
+	for _, dv := range dvs {
+		if dv == nil {
+			continue
+		}
+		for _, formula := range []*xlsxInnerXML{dv.Formula1, dv.Formula2} {
+			if !formula.isFormula() {
+				continue
+			}
+			content := formulaUnescaper.Replace(formula.Content)
+			val, err := f.adjustMoveFormula(mr, sheetN, hostSheet, content)
+			if err != nil {
+				return err
+			}
+			if val != content {
+				formula.Content = formulaEscaper.Replace(val)
+			}
+		}
+	}
+	return nil
+}
+
+// adjustMoveConditionalFormats updates the formulas of conditional formats in
+// the worksheet after moving the range of cells.
+func (f *File) adjustMoveConditionalFormats(mr *moveRange, cfs []*xlsxConditionalFormatting, sheetN, hostSheet string) error {
+	for _, cf := range cfs {
+		if cf == nil {
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			for i, formula := range rule.Formula {
+				val, err := f.adjustMoveFormula(mr, sheetN, hostSheet, formula)
+				if err != nil {
+					return err
+				}
+				rule.Formula[i] = val
+			}
+		}
+	}
+	return nil
+}
+
+// adjustMoveRefs updates the references of cell formulas, data validations,
+// conditional formats and defined names in the workbook after moving the range
+// of cells.
+func (f *File) adjustMoveRefs(mr *moveRange) error {
+	for _, sheetN := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		if err = f.adjustMoveCellFormulas(mr, ws, sheetN); err != nil {
+			return err
+		}
+		if ws.DataValidations != nil {
+			if err = f.adjustMoveDataValidations(mr, ws.DataValidations.DataValidation, sheetN, sheetN); err != nil {
+				return err
+			}
+		}
+		if err = f.adjustMoveConditionalFormats(mr, ws.ConditionalFormatting, sheetN, sheetN); err != nil {
+			return err
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := 0; i < len(wb.DefinedNames.DefinedName); i++ {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			if definedName.Data, err = f.adjustMoveFormula(mr, "", "", definedName.Data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkPartialRange checks if any merged cells or array formulas in the
+// worksheet partially overlap the given range, the merged cells and array
+// formulas inside of the ignored range will be skipped.
+func (ws *xlsxWorksheet) checkPartialRange(rect, ignore []int) error {
+	partial := func(ref string) (bool, error) {
+		rects, err := sqrefToRects(ref)
+		if err != nil || len(rects) == 0 {
+			return false, err
+		}
+		return intersectRect(rects[0], rect) != nil && !inRect(rects[0], rect) &&
+			(ignore == nil || !inRect(rects[0], ignore)), err
+	}
+	if ws.MergeCells != nil {
+		for _, mc := range ws.MergeCells.Cells {
+			if mc == nil {
+				continue
+			}
+			if ok, err := partial(mc.Ref); ok || err != nil {
+				if err != nil {
+					return err
+				}
+				return ErrPartialMergedCells
+			}
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeArray || c.F.Ref == "" {
+				continue
+			}
+			if ok, err := partial(c.F.Ref); ok || err != nil {
+				if err != nil {
+					return err
+				}
+				return ErrPartialArrayFormula
+			}
+		}
+	}
+	return nil
+}
+
+// unshareFormulas converts the shared formulas which have any cells inside of
+// the given range to normal formulas.
+func (ws *xlsxWorksheet) unshareFormulas(rect []int) {
+	masters, unshared := map[int]*xlsxC{}, map[int]bool{}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if cell.F == nil || cell.F.T != STCellFormulaTypeShared || cell.F.Si == nil {
+				continue
+			}
+			if cell.F.Ref != "" {
+				masters[*cell.F.Si] = cell
+			}
+			if col, row, err := CellNameToCoordinates(cell.R); err == nil && cellInRange([]int{col, row}, rect) {
+				unshared[*cell.F.Si] = true
+			}
+		}
+	}
+	if len(unshared) == 0 {
+		return
+	}
+	formulas := map[string]string{}
+	for r := range ws.SheetData.Row {
+		for _, cell := range ws.SheetData.Row[r].C {
+			if cell.F != nil && cell.F.Si != nil && unshared[*cell.F.Si] && masters[*cell.F.Si] != nil {
+				formulas[cell.R] = getSharedFormulaByMaster(masters[*cell.F.Si], cell.R)
+			}
+		}
+	}
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			if cell := &ws.SheetData.Row[r].C[c]; cell.F != nil && cell.F.Si != nil && unshared[*cell.F.Si] {
+				cell.F = &xlsxF{Content: formulas[cell.R]}
+			}
+		}
+	}
+}
+
+// cutCells returns the copies of the cells in the source range with updated
+// formulas, and clears the cells in the source and destination range.
+func (f *File) cutCells(mr *moveRange, wsSrc, wsDst *xlsxWorksheet) ([]xlsxC, error) {
+	var cells []xlsxC
+	for r := mr.src[1]; r <= mr.src[3] && r <= len(wsSrc.SheetData.Row); r++ {
+		row := &wsSrc.SheetData.Row[r-1]
+		for c := mr.src[0]; c <= mr.src[2] && c <= len(row.C); c++ {
+			if !row.C[c-1].hasValue() && row.C[c-1].IS == nil {
+				continue
+			}
+			cell := deepcopy.Copy(row.C[c-1]).(xlsxC)
+			cell.R, _ = CoordinatesToCellName(mr.shift([]int{c, r, c, r})[0], mr.shift([]int{c, r, c, r})[1])
+			cell.f = ""
+			if cell.F != nil {
+				var err error
+				if cell.F.Content, err = f.adjustMoveFormula(mr, mr.srcSheet, mr.dstSheet, cell.F.Content); err != nil {
+					return cells, err
+				}
+				if cell.F.T == STCellFormulaTypeArray && cell.F.Ref != "" {
+					rects, err := sqrefToRects(cell.F.Ref)
+					if err != nil {
+						return cells, err
+					}
+					if cell.F.Ref, err = rectsToSQRef([][]int{mr.shift(rects[0])}); err != nil {
+						return cells, err
+					}
+				}
+			}
+			cells = append(cells, cell)
+		}
+	}
+	for _, item := range []struct {
+		ws    *xlsxWorksheet
+		sheet string
+		rect  []int
+	}{{wsSrc, mr.srcSheet, mr.src}, {wsDst, mr.dstSheet, mr.dst}} {
+		sheetID := f.getSheetID(item.sheet)
+		for r := item.rect[1]; r <= item.rect[3] && r <= len(item.ws.SheetData.Row); r++ {
+			row := &item.ws.SheetData.Row[r-1]
+			for c := item.rect[0]; c <= item.rect[2] && c <= len(row.C); c++ {
+				cell := &row.C[c-1]
+				if cell.F != nil {
+					if err := f.deleteCalcChain(sheetID, cell.R); err != nil {
+						return cells, err
+					}
+				}
+				*cell = xlsxC{R: cell.R}
+			}
+		}
+	}
+	return cells, nil
+}
+
+// pasteCells provides a function to put the given cells into the worksheet.
+func (ws *xlsxWorksheet) pasteCells(cells []xlsxC) {
+	for _, cell := range cells {
+		col, row, _ := CellNameToCoordinates(cell.R)
+		ws.prepareSheetXML(col, row)
+		ws.SheetData.Row[row-1].C[col-1] = cell
+	}
+}
+
+// copyDataValidations returns the copies of the data validations inside of
+// the given range in the worksheet, the ranges of the copies are mapped by the
+// given function.
+func (ws *xlsxWorksheet) copyDataValidations(rect []int, mapRect func([]int) []int, fn func(dv, dvCopy *xlsxDataValidation) error) ([]*xlsxDataValidation, error) {
+	var dvs []*xlsxDataValidation
+	if ws.DataValidations == nil {
+		return dvs, nil
+	}
+	for _, dv := range ws.DataValidations.DataValidation {
+		if dv == nil {
+			continue
+		}
+		_, inside, err := splitSQRef(dv.Sqref, rect)
+		if err != nil {
+			return dvs, err
+		}
+		if len(inside) == 0 {
+			continue
+		}
+		for i := range inside {
+			inside[i] = mapRect(inside[i])
+		}
+		dvCopy := deepcopy.Copy(*dv).(xlsxDataValidation)
+		if dvCopy.Sqref, err = rectsToSQRef(inside); err != nil {
+			return dvs, err
+		}
+		if err = fn(dv, &dvCopy); err != nil {
+			return dvs, err
+		}
+		dvs = append(dvs, &dvCopy)
+	}
+	return dvs, nil
+}
+
+// clearDataValidations removes the given range from the data validations of
+// the worksheet.
+func (ws *xlsxWorksheet) clearDataValidations(rect []int) error {
+	if ws.DataValidations == nil {
+		return nil
+	}
+	for i := 0; i < len(ws.DataValidations.DataValidation); i++ {
+		dv := ws.DataValidations.DataValidation[i]
+		if dv == nil {
+			continue
+		}
+		outside, _, err := splitSQRef(dv.Sqref, rect)
+		if err != nil {
+			return err
+		}
+		if len(outside) == 0 {
+			ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation[:i], ws.DataValidations.DataValidation[i+1:]...)
+			i--
+			continue
+		}
+		if dv.Sqref, err = rectsToSQRef(outside); err != nil {
+			return err
+		}
+	}
+	if ws.DataValidations.Count = len(ws.DataValidations.DataValidation); ws.DataValidations.Count == 0 {
+		ws.DataValidations = nil
+	}
+	return nil
+}
+
+// appendDataValidations appends the given data validations to the worksheet.
+func (ws *xlsxWorksheet) appendDataValidations(dvs []*xlsxDataValidation) {
+	if len(dvs) == 0 {
+		return
+	}
+	if ws.DataValidations == nil {
+		ws.DataValidations = new(xlsxDataValidations)
+	}
+	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, dvs...)
+	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
+}
+
+// cutDataValidations returns the copies of the data validations in the source
+// range, and removes the source and destination range from the data
+// validations of the worksheets.
+func (f *File) cutDataValidations(mr *moveRange, wsSrc, wsDst *xlsxWorksheet) ([]*xlsxDataValidation, error) {
+	moved, err := wsSrc.copyDataValidations(mr.src, mr.shift, func(_, dvCopy *xlsxDataValidation) error {
+		return f.adjustMoveDataValidations(mr, []*xlsxDataValidation{dvCopy}, mr.srcSheet, mr.dstSheet)
+	})
+	if err != nil {
+		return moved, err
+	}
+	if err = wsSrc.clearDataValidations(mr.src); err != nil {
+		return moved, err
+	}
+	return moved, wsDst.clearDataValidations(mr.dst)
+}
+
+// copyConditionalFormats returns the copies of the conditional formats inside
+// of the given range in the worksheet, the ranges of the copies are mapped by
+// the given function.
+func (ws *xlsxWorksheet) copyConditionalFormats(rect []int, mapRect func([]int) []int, fn func(cf, cfCopy *xlsxConditionalFormatting) error) ([]*xlsxConditionalFormatting, error) {
+	var cfs []*xlsxConditionalFormatting
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil {
+			continue
+		}
+		_, inside, err := splitSQRef(cf.SQRef, rect)
+		if err != nil {
+			return cfs, err
+		}
+		if len(inside) == 0 {
+			continue
+		}
+		for i := range inside {
+			inside[i] = mapRect(inside[i])
+		}
+		cfCopy := deepcopy.Copy(*cf).(xlsxConditionalFormatting)
+		if cfCopy.SQRef, err = rectsToSQRef(inside); err != nil {
+			return cfs, err
+		}
+		if err = fn(cf, &cfCopy); err != nil {
+			return cfs, err
+		}
+		cfs = append(cfs, &cfCopy)
+	}
+	return cfs, nil
+}
+
+// clearConditionalFormats removes the given range from the conditional
+// formats of the worksheet.
+func (ws *xlsxWorksheet) clearConditionalFormats(rect []int) error {
+	for i := 0; i < len(ws.ConditionalFormatting); i++ {
+		cf := ws.ConditionalFormatting[i]
+		if cf == nil {
+			continue
+		}
+		outside, _, err := splitSQRef(cf.SQRef, rect)
+		if err != nil {
+			return err
+		}
+		if len(outside) == 0 {
+			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
+			i--
+			continue
+		}
+		if cf.SQRef, err = rectsToSQRef(outside); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cutConditionalFormats returns the copies of the conditional formats in the
+// source range, and removes the source and destination range from the
+// conditional formats of the worksheets.
+func (f *File) cutConditionalFormats(mr *moveRange, wsSrc, wsDst *xlsxWorksheet) ([]*xlsxConditionalFormatting, error) {
+	moved, err := wsSrc.copyConditionalFormats(mr.src, mr.shift, func(_, cfCopy *xlsxConditionalFormatting) error {
+		return f.adjustMoveConditionalFormats(mr, []*xlsxConditionalFormatting{cfCopy}, mr.srcSheet, mr.dstSheet)
+	})
+	if err != nil {
+		return moved, err
+	}
+	if err = wsSrc.clearConditionalFormats(mr.src); err != nil {
+		return moved, err
+	}
+	return moved, wsDst.clearConditionalFormats(mr.dst)
+}
+
+// moveMergeCells moves the merged cells inside of the source range to the
+// destination range, and removes the merged cells inside of the destination
+// range.
+func (mr *moveRange) moveMergeCells(wsSrc, wsDst *xlsxWorksheet) error {
+	var moved []*xlsxMergeCell
+	for _, item := range []struct {
+		ws   *xlsxWorksheet
+		rect []int
+		cut  bool
+	}{{wsSrc, mr.src, true}, {wsDst, mr.dst, false}} {
+		if item.ws.MergeCells == nil {
+			continue
+		}
+		for i := 0; i < len(item.ws.MergeCells.Cells); i++ {
+			mc := item.ws.MergeCells.Cells[i]
+			if mc == nil {
+				continue
+			}
+			rects, err := sqrefToRects(mc.Ref)
+			if err != nil {
+				return err
+			}
+			if len(rects) == 0 || !inRect(rects[0], item.rect) {
+				continue
+			}
+			if item.cut {
+				rect := mr.shift(rects[0])
+				ref, err := coordinatesToRangeRef(rect)
+				if err != nil {
+					return err
+				}
+				moved = append(moved, &xlsxMergeCell{Ref: ref, rect: rect})
+			}
+			item.ws.MergeCells.Cells = append(item.ws.MergeCells.Cells[:i], item.ws.MergeCells.Cells[i+1:]...)
+			i--
+		}
+	}
+	if len(moved) > 0 {
+		if wsDst.MergeCells == nil {
+			wsDst.MergeCells = &xlsxMergeCells{}
+		}
+		wsDst.MergeCells.Cells = append(wsDst.MergeCells.Cells, moved...)
+	}
+	for _, ws := range []*xlsxWorksheet{wsSrc, wsDst} {
+		if ws.MergeCells == nil {
+			continue
+		}
+		if ws.MergeCells.Count = len(ws.MergeCells.Cells); ws.MergeCells.Count == 0 {
+			ws.MergeCells = nil
+		}
+	}
+	return nil
+}
+
+// moveHyperlinks moves the hyperlinks inside of the source range to the
+// destination range, and removes the hyperlinks inside of the destination
+// range.
+func (f *File) moveHyperlinks(mr *moveRange, wsSrc, wsDst *xlsxWorksheet) error {
+	var moved []xlsxHyperlink
+	for _, item := range []struct {
+		ws    *xlsxWorksheet
+		sheet string
+		rect  []int
+		cut   bool
+	}{{wsSrc, mr.srcSheet, mr.src, true}, {wsDst, mr.dstSheet, mr.dst, false}} {
+		if item.ws.Hyperlinks == nil {
+			continue
+		}
+		for i := 0; i < len(item.ws.Hyperlinks.Hyperlink); i++ {
+			link := item.ws.Hyperlinks.Hyperlink[i]
+			rects, err := sqrefToRects(link.Ref)
+			if err != nil {
+				return err
+			}
+			if len(rects) == 0 || !inRect(rects[0], item.rect) {
+				continue
+			}
+			if item.cut {
+				if link.Ref, err = rectsToSQRef([][]int{mr.shift(rects[0])}); err != nil {
+					return err
+				}
+				if link.RID != "" && mr.srcSheet != mr.dstSheet {
+					target := f.getSheetRelationshipsTargetByID(mr.srcSheet, link.RID)
+					f.deleteSheetRelationships(mr.srcSheet, link.RID)
+					sheetPath, _ := f.getSheetXMLPath(mr.dstSheet)
+					sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+					link.RID = "rId" + strconv.Itoa(f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External"))
+					f.addSheetNameSpace(mr.dstSheet, SourceRelationship)
+				}
+				moved = append(moved, link)
+			} else {
+				f.deleteSheetRelationships(item.sheet, link.RID)
+			}
+			item.ws.Hyperlinks.Hyperlink = append(item.ws.Hyperlinks.Hyperlink[:i], item.ws.Hyperlinks.Hyperlink[i+1:]...)
+			i--
+		}
+	}
+	if len(moved) > 0 {
+		if wsDst.Hyperlinks == nil {
+			wsDst.Hyperlinks = new(xlsxHyperlinks)
+		}
+		wsDst.Hyperlinks.Hyperlink = append(wsDst.Hyperlinks.Hyperlink, moved...)
+	}
+	for _, ws := range []*xlsxWorksheet{wsSrc, wsDst} {
+		if ws.Hyperlinks != nil && len(ws.Hyperlinks.Hyperlink) == 0 {
+			ws.Hyperlinks = nil
+		}
+	}
+	return nil
+}
+
+// moveComments moves the comments inside of the source range to the
+// destination range, and removes the comments inside of the destination
+// range. The style, size and visibility of the comment box will be kept.
+func (f *File) moveComments(mr *moveRange) error {
+	srcComments, err := f.getVMLComments(mr.srcSheet, mr.src)
+	if err != nil {
+		return err
+	}
+	dstComments, err := f.getVMLComments(mr.dstSheet, mr.dst)
+	if err != nil {
+		return err
+	}
+	for _, comment := range srcComments {
+		if err = f.DeleteComment(mr.srcSheet, comment.cell); err != nil {
+			return err
+		}
+	}
+	for _, comment := range dstComments {
+		col, row, _ := CellNameToCoordinates(comment.cell)
+		if mr.srcSheet == mr.dstSheet && cellInRange([]int{col, row}, mr.src) {
+			continue
+		}
+		if err = f.DeleteComment(mr.dstSheet, comment.cell); err != nil {
+			return err
+		}
+	}
+	for _, comment := range srcComments {
+		col, row, _ := CellNameToCoordinates(comment.cell)
+		rect := mr.shift([]int{col, row, col, row})
+		cell, _ := CoordinatesToCellName(rect[0], rect[1])
+		if err = f.addVMLComment(mr.dstSheet, cell, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveCellRange moves the cells, merged cells, comments, hyperlinks, data
+// validations and conditional formats from the source range to the
+// destination range, and updates the references in the workbook which refer to
+// the source range.
+func (f *File) moveCellRange(mr *moveRange) error {
+	wsSrc, err := f.workSheetReader(mr.srcSheet)
+	if err != nil {
+		return err
+	}
+	wsDst, err := f.workSheetReader(mr.dstSheet)
+	if err != nil {
+		return err
+	}
+	var ignore []int
+	if mr.srcSheet == mr.dstSheet {
+		ignore = mr.src
+	}
+	if err = wsSrc.checkPartialRange(mr.src, nil); err != nil {
+		return err
+	}
+	if err = wsDst.checkPartialRange(mr.dst, ignore); err != nil {
+		return err
+	}
+	wsSrc.unshareFormulas(mr.src)
+	wsDst.unshareFormulas(mr.dst)
+	cells, err := f.cutCells(mr, wsSrc, wsDst)
+	if err != nil {
+		return err
+	}
+	dvs, err := f.cutDataValidations(mr, wsSrc, wsDst)
+	if err != nil {
+		return err
+	}
+	cfs, err := f.cutConditionalFormats(mr, wsSrc, wsDst)
+	if err != nil {
+		return err
+	}
+	if err = f.adjustMoveRefs(mr); err != nil {
+		return err
+	}
+	wsDst.pasteCells(cells)
+	wsDst.appendDataValidations(dvs)
+	wsDst.ConditionalFormatting = append(wsDst.ConditionalFormatting, cfs...)
+	if err = mr.moveMergeCells(wsSrc, wsDst); err != nil {
+		return err
+	}
+	if err = f.moveHyperlinks(mr, wsSrc, wsDst); err != nil {
+		return err
+	}
+	return f.moveComments(mr)
+}
+
+// shiftOperand returns the range operand with relative references shifted by
+// given columns and rows distance, like copy and paste a formula cell in Excel.
+// The #REF! error will be returned if the shifted reference is out of the
+// worksheet.
+func shiftOperand(operand string, dCol, dRow int) string {
+	var prefix string
+	ref := operand
+	if idx := strings.LastIndex(operand, "!"); idx != -1 {
+		prefix, ref = operand[:idx+1], operand[idx+1:]
+	}
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return operand
+	}
+	for i, part := range parts {
+		if col, row, err := CellNameToCoordinates(part); err == nil {
+			if !strings.HasPrefix(part, "$") {
+				col += dCol
+			}
+			if strings.LastIndex(part, "$") <= 0 {
+				row += dRow
+			}
+			if col < MinColumns || col > MaxColumns || row < 1 || row > TotalRows {
+				return formulaErrorREF
+			}
+			parts[i] = shiftCell(part, dCol, dRow)
+			continue
+		}
+		if len(parts) != 2 {
+			return operand
+		}
+		name := strings.TrimPrefix(part, "$")
+		abs := name != part
+		if col, err := ColumnNameToNumber(name); err == nil {
+			if !abs {
+				col += dCol
+			}
+			if col < MinColumns || col > MaxColumns {
+				return formulaErrorREF
+			}
+			name, _ = ColumnNumberToName(col)
+		} else if row, err := strconv.Atoi(name); err == nil {
+			if !abs {
+				row += dRow
+			}
+			if row < 1 || row > TotalRows {
+				return formulaErrorREF
+			}
+			name = strconv.Itoa(row)
+		} else {
+			return operand
+		}
+		if abs {
+			name = "$" + name
+		}
+		parts[i] = name
+	}
+	return prefix + strings.Join(parts, ":")
+}
+
+// shiftFormula returns the formula with relative references shifted by given
+// columns and rows distance, like copy and paste a formula cell in Excel.
+func (f *File) shiftFormula(sheet, formula string, dCol, dRow int) (string, error) {
+	if dCol == 0 && dRow == 0 {
+		return formula, nil
+	}
+	return f.adjustFormulaOperands(sheet, formula, func(token efp.Token) (string, error) {
+		return shiftOperand(token.TValue, dCol, dRow), nil
+	})
+}
+
+// transposeOperand returns the range operand with relative references
+// transposed by given coordinates of the source and destination cell, like
+// copy and paste a formula cell with transpose in Excel. The column and row
+// distance of the relative references to the source cell will be swapped. The
+// #REF! error will be returned if the transposed reference is out of the
+// worksheet.
+func transposeOperand(operand string, srcCol, srcRow, dstCol, dstRow int) string {
+	var prefix string
+	ref := operand
+	if idx := strings.LastIndex(operand, "!"); idx != -1 {
+		prefix, ref = operand[:idx+1], operand[idx+1:]
+	}
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return operand
+	}
+	for i, part := range parts {
+		if col, row, err := CellNameToCoordinates(part); err == nil {
+			toCol, toRow := col, row
+			if !strings.HasPrefix(part, "$") {
+				toCol = dstCol + row - srcRow
+			}
+			if strings.LastIndex(part, "$") <= 0 {
+				toRow = dstRow + col - srcCol
+			}
+			if toCol < MinColumns || toCol > MaxColumns || toRow < 1 || toRow > TotalRows {
+				return formulaErrorREF
+			}
+			parts[i] = shiftCell(part, toCol-col, toRow-row)
+			continue
+		}
+		// The absolute entire column or row references will be kept
+		if len(parts) != 2 || strings.Contains(ref, "$") {
+			return operand
+		}
+		if col, err := ColumnNameToNumber(part); err == nil {
+			row := dstRow + col - srcCol
+			if row < 1 || row > TotalRows {
+				return formulaErrorREF
+			}
+			parts[i] = strconv.Itoa(row)
+		} else if row, err := strconv.Atoi(part); err == nil {
+			col := dstCol + row - srcRow
+			if col < MinColumns || col > MaxColumns {
+				return formulaErrorREF
+			}
+			parts[i], _ = ColumnNumberToName(col)
+		} else {
+			return operand
+		}
+	}
+	return prefix + strings.Join(parts, ":")
+}
+
+// transposeFormula returns the formula with relative references transposed by
+// given coordinates of the source and destination cell.
+func (f *File) transposeFormula(sheet, formula string, srcCol, srcRow, dstCol, dstRow int) (string, error) {
+	return f.adjustFormulaOperands(sheet, formula, func(token efp.Token) (string, error) {
+		return transposeOperand(token.TValue, srcCol, srcRow, dstCol, dstRow), nil
+	})
+}
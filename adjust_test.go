@@ -1262,3 +1262,80 @@ func TestAdjustDefinedNames(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.adjustDefinedNames(nil, "Sheet1", columns, 0, 0, 1), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAdjustMoveRange(t *testing.T) {
+	assert.Nil(t, intersectRect([]int{1, 1, 2, 2}, []int{3, 3, 4, 4}))
+	assert.Equal(t, []int{2, 2, 2, 2}, intersectRect([]int{1, 1, 2, 2}, []int{2, 2, 4, 4}))
+	assert.Equal(t, [][]int{{1, 1, 3, 1}, {1, 3, 3, 3}, {1, 2, 1, 2}, {3, 2, 3, 2}}, subtractRect([]int{1, 1, 3, 3}, []int{2, 2, 2, 2}))
+	assert.Equal(t, [][]int{{1, 1, 2, 2}}, subtractRect([]int{1, 1, 2, 2}, []int{3, 3, 4, 4}))
+	outside, inside, err := splitSQRef("A1:C3 E5", []int{2, 2, 5, 5})
+	assert.NoError(t, err)
+	ref, err := rectsToSQRef(outside)
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C1 A2:A3", ref)
+	ref, err = rectsToSQRef(inside)
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:C3 E5", ref)
+	_, _, err = splitSQRef("A", []int{1, 1, 1, 1})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	_, err = rectsToSQRef([][]int{{0, 0, 0, 0}})
+	assert.Equal(t, newCoordinatesToCellNameError(0, 0), err)
+
+	mr := &moveRange{srcSheet: "Sheet1", dstSheet: "Sheet2", src: []int{1, 1, 2, 2}, dst: []int{3, 3, 4, 4}}
+	for _, c := range []struct {
+		sheetN, hostSheet, operand, expected string
+		changed                              bool
+	}{
+		{"Sheet1", "Sheet1", "A1", "Sheet2!C3", true},
+		{"Sheet1", "Sheet1", "$A1:B$2", "Sheet2!$C3:D$4", true},
+		{"Sheet1", "Sheet1", "A1:C3", "A1:C3", false},
+		{"Sheet1", "Sheet1", "A:B", "A:B", false},
+		{"Sheet1", "Sheet2", "A:B", "Sheet1!A:B", true},
+		{"Sheet2", "Sheet2", "'Sheet1'!B2", "Sheet2!D4", true},
+		{"Sheet2", "Sheet2", "D4", "#REF!", true},
+		{"Sheet3", "Sheet3", "Sheet1:Sheet2!A1", "Sheet1:Sheet2!A1", false},
+		{"", "", "'Sheet 1'!A1", "'Sheet 1'!A1", false},
+	} {
+		operand, changed := mr.adjustOperand(c.sheetN, c.hostSheet, c.operand)
+		assert.Equal(t, c.expected, operand, c.operand)
+		assert.Equal(t, c.changed, changed, c.operand)
+	}
+
+	// Test check partial range with invalid merged cells and array formulas
+	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A"}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), ws.checkPartialRange([]int{1, 1, 1, 1}, nil))
+	ws = &xlsxWorksheet{SheetData: xlsxSheetData{Row: []xlsxRow{{C: []xlsxC{{F: &xlsxF{T: STCellFormulaTypeArray, Ref: "A"}}}}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), ws.checkPartialRange([]int{1, 1, 1, 1}, nil))
+}
+
+func TestShiftOperand(t *testing.T) {
+	for _, c := range []struct {
+		operand    string
+		dCol, dRow int
+		expected   string
+	}{
+		{"A1", 1, 2, "B3"},
+		{"$A$1", 1, 2, "$A$1"},
+		{"$A1:B$2", 1, 2, "$A3:C$2"},
+		{"'Sheet 2'!A1", 2, 0, "'Sheet 2'!C1"},
+		{"A:B", 1, 1, "B:C"},
+		{"$A:B", 1, 1, "$A:C"},
+		{"1:$2", 1, 1, "2:$2"},
+		{"A1", -1, 0, "#REF!"},
+		{"A1", 0, -1, "#REF!"},
+		{"A:A", -1, 0, "#REF!"},
+		{"1:1", 0, -1, "#REF!"},
+		{"A", 1, 1, "A"},
+		{"A1:B1:C1", 1, 1, "A1:B1:C1"},
+		{"A:-", 1, 1, "A:-"},
+	} {
+		assert.Equal(t, c.expected, shiftOperand(c.operand, c.dCol, c.dRow), c.operand)
+	}
+	f := NewFile()
+	formula, err := f.shiftFormula("Sheet1", "SUM(A1:B2)+$C$1", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1:B2)+$C$1", formula)
+	formula, err = f.shiftFormula("Sheet1", "SUM(A1:B2)+$C$1", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:C3)+$C$1", formula)
+}
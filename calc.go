@@ -220,6 +220,7 @@ type calcContext struct {
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	tables            map[string]*tableRefInfo
 }
 
 // cellRef defines the structure of a cell reference.
@@ -853,6 +854,9 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	if formula, err = f.getCellFormula(sheet, cell, true); err != nil {
 		return
 	}
+	if formula, err = f.resolveStructuredRefs(ctx, sheet, cell, formula); err != nil {
+		return
+	}
 	ps := efp.ExcelParser()
 	tokens := ps.Parse(formula)
 	if tokens == nil {
@@ -1300,6 +1304,9 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 			return ErrInvalidFormula
 		}
 		opd := opdStack.Pop().(formulaArg)
+		if opd.Type == ArgError {
+			return errors.New(opd.Value())
+		}
 		opdStack.Push(newNumberFormulaArg(0 - opd.ToNumber().Number))
 	}
 	if opt.TValue == "-" && opt.TType == efp.TokenTypeOperatorInfix {
@@ -1412,7 +1419,7 @@ func isOperatorPrefixToken(token efp.Token) bool {
 
 // isOperand determine if the token is parse operand.
 func isOperand(token efp.Token) bool {
-	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical)
+	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical || token.TSubType == efp.TokenSubTypeError)
 }
 
 // tokenToFormulaArg create a formula argument by given token.
@@ -1423,6 +1430,8 @@ func tokenToFormulaArg(token efp.Token) formulaArg {
 	case efp.TokenSubTypeNumber:
 		num, _ := strconv.ParseFloat(token.TValue, 64)
 		return newNumberFormulaArg(num)
+	case efp.TokenSubTypeError:
+		return newErrorFormulaArg(token.TValue, token.TValue)
 	default:
 		return newStringFormulaArg(token.TValue)
 	}
@@ -1580,6 +1589,363 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 	return f.rangeResolver(ctx, cellRefs, cellRanges)
 }
 
+// structuredRef directly maps the table name, special items and columns of a
+// structured reference, such as Sales[[#Totals],[Amount]] or Sales[@Qty].
+type structuredRef struct {
+	table   string
+	items   []string
+	columns []string
+	thisRow bool
+}
+
+// structuredRefItems defined the special items of structured references in
+// lowercase and their canonical names.
+var structuredRefItems = map[string]string{
+	"#all":      "#All",
+	"#data":     "#Data",
+	"#headers":  "#Headers",
+	"#totals":   "#Totals",
+	"#this row": "#This Row",
+}
+
+// isStructuredRefNameChar returns true if the given character can be used in
+// the name of a table.
+func isStructuredRefNameChar(ch byte) bool {
+	return ch == '_' || ch == '.' || ch == '\\' || ch >= 0x80 ||
+		('0' <= ch && ch <= '9') || ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
+
+// skipQuoted returns the index after the closing quote of the string literal
+// or quoted worksheet name which starts at the given index.
+func skipQuoted(formula string, start int) int {
+	quote := formula[start]
+	for i := start + 1; i < len(formula); i++ {
+		if formula[i] == quote {
+			if i+1 < len(formula) && formula[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(formula)
+}
+
+// matchBracket returns the index of the close bracket matching the open
+// bracket at the given index, the characters escaped by the apostrophe will
+// be skipped. It returns -1 if the brackets are unbalanced.
+func matchBracket(formula string, start int) int {
+	var depth int
+	for i := start; i < len(formula); i++ {
+		switch formula[i] {
+		case '\'':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitStructuredRef splits the content of the structured reference by given
+// separator outside of the brackets.
+func splitStructuredRef(content string, sep byte) []string {
+	var (
+		parts        []string
+		depth, start int
+	)
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\'':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts, start = append(parts, content[start:i]), i+1
+			}
+		}
+	}
+	return append(parts, content[start:])
+}
+
+// unescapeStructuredRef removes the apostrophes which escape the special
+// characters in the column name of the structured reference.
+func unescapeStructuredRef(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\'' && i+1 < len(name) {
+			i++
+		}
+		b.WriteByte(name[i])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// escapeStructuredRef escapes the special characters in the column name of
+// the structured reference by apostrophes.
+func escapeStructuredRef(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if strings.IndexByte("[]#'", name[i]) != -1 {
+			b.WriteByte('\'')
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// parseStructuredRef parses the structured reference by given table name and
+// the content in the outermost brackets. It returns false if the content is
+// not a structured reference, such as the index of external workbook.
+func parseStructuredRef(table, content string) (*structuredRef, bool) {
+	ref := &structuredRef{table: table}
+	if content = strings.TrimSpace(content); table == "" {
+		if _, err := strconv.Atoi(content); err == nil {
+			return ref, false
+		}
+	}
+	if strings.HasPrefix(content, "@") {
+		ref.thisRow, content = true, strings.TrimSpace(content[1:])
+	}
+	if !strings.HasPrefix(content, "[") {
+		if item, ok := structuredRefItems[strings.ToLower(content)]; ok && !ref.thisRow {
+			ref.items = []string{item}
+		} else if content != "" {
+			ref.columns = []string{unescapeStructuredRef(content)}
+		}
+		return ref, true
+	}
+	for _, elem := range splitStructuredRef(content, ',') {
+		var names []string
+		for _, part := range splitStructuredRef(strings.TrimSpace(elem), ':') {
+			if part = strings.TrimSpace(part); len(part) < 2 || part[0] != '[' || part[len(part)-1] != ']' {
+				return ref, false
+			}
+			names = append(names, part[1:len(part)-1])
+		}
+		if item, ok := structuredRefItems[strings.ToLower(strings.TrimSpace(names[0]))]; ok && len(names) == 1 {
+			if item == "#This Row" {
+				ref.thisRow = true
+				continue
+			}
+			ref.items = append(ref.items, item)
+			continue
+		}
+		if len(names) > 2 || len(ref.columns) > 0 {
+			return ref, false
+		}
+		for _, name := range names {
+			ref.columns = append(ref.columns, unescapeStructuredRef(name))
+		}
+	}
+	return ref, true
+}
+
+// String returns the structured reference text in the canonical form.
+func (ref *structuredRef) String() string {
+	simple := func(name string) bool {
+		for i := 0; i < len(name); i++ {
+			if !isStructuredRefNameChar(name[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(ref.items) == 0 && len(ref.columns) < 2 {
+		if len(ref.columns) == 0 {
+			if ref.thisRow {
+				return ref.table + "[@]"
+			}
+			return ref.table + "[]"
+		}
+		name := escapeStructuredRef(ref.columns[0])
+		if ref.thisRow && !simple(name) {
+			return ref.table + "[@[" + name + "]]"
+		}
+		if ref.thisRow {
+			return ref.table + "[@" + name + "]"
+		}
+		return ref.table + "[" + name + "]"
+	}
+	var elems []string
+	for _, item := range ref.items {
+		elems = append(elems, "["+item+"]")
+	}
+	if len(ref.columns) > 0 {
+		var columns []string
+		for _, column := range ref.columns {
+			columns = append(columns, "["+escapeStructuredRef(column)+"]")
+		}
+		elems = append(elems, strings.Join(columns, ":"))
+	}
+	if ref.thisRow {
+		return ref.table + "[@" + strings.Join(elems, ",") + "]"
+	}
+	return ref.table + "[" + strings.Join(elems, ",") + "]"
+}
+
+// replaceStructuredRefs walks the structured references in the formula, and
+// replaces each of them with the result of the given function.
+func replaceStructuredRefs(formula string, fn func(ref *structuredRef, text string) (string, error)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(formula); {
+		ch := formula[i]
+		if ch == '"' || ch == '\'' {
+			end := skipQuoted(formula, i)
+			b.WriteString(formula[i:end])
+			i = end
+			continue
+		}
+		if !isStructuredRefNameChar(ch) && ch != '[' {
+			b.WriteByte(ch)
+			i++
+			continue
+		}
+		j := i
+		for j < len(formula) && isStructuredRefNameChar(formula[j]) {
+			j++
+		}
+		if j == len(formula) || formula[j] != '[' {
+			b.WriteString(formula[i:j])
+			i = j
+			continue
+		}
+		end := matchBracket(formula, j)
+		if end == -1 {
+			b.WriteString(formula[i:])
+			break
+		}
+		text := formula[i : end+1]
+		if ref, ok := parseStructuredRef(formula[i:j], formula[j+1:end]); ok {
+			replaced, err := fn(ref, text)
+			if err != nil {
+				return formula, err
+			}
+			text = replaced
+		}
+		b.WriteString(text)
+		i = end + 1
+	}
+	return b.String(), nil
+}
+
+// resolveStructuredRef returns the range reference of the structured
+// reference by given table and the cell where the formula in. It returns
+// error formula result if the structured reference is invalid.
+func resolveStructuredRef(ref *structuredRef, info *tableRefInfo, sheet string, col, row int) string {
+	coordinates := append([]int{}, info.coordinates...)
+	header := 1
+	if info.table.HeaderRowCount != nil {
+		header = *info.table.HeaderRowCount
+	}
+	dataStart, dataEnd := coordinates[1]+header, coordinates[3]-info.table.TotalsRowCount
+	items := map[string]bool{}
+	for _, item := range ref.items {
+		items[item] = true
+	}
+	switch {
+	case ref.thisRow:
+		if len(items) > 0 || sheet != info.sheet || row < dataStart || row > dataEnd {
+			return formulaErrorVALUE
+		}
+		coordinates[1], coordinates[3] = row, row
+	case items["#All"]:
+	case items["#Headers"] && items["#Data"] && len(items) == 2:
+		coordinates[3] = dataEnd
+	case items["#Data"] && items["#Totals"] && len(items) == 2:
+		coordinates[1] = dataStart
+	case items["#Headers"] && len(items) == 1:
+		if header == 0 {
+			return formulaErrorREF
+		}
+		coordinates[3] = coordinates[1]
+	case items["#Totals"] && len(items) == 1:
+		if info.table.TotalsRowCount == 0 {
+			return formulaErrorREF
+		}
+		coordinates[1] = coordinates[3]
+	case len(items) == 0 || items["#Data"] && len(items) == 1:
+		coordinates[1], coordinates[3] = dataStart, dataEnd
+	default:
+		return formulaErrorREF
+	}
+	if coordinates[1] > coordinates[3] {
+		return formulaErrorREF
+	}
+	var columns []int
+	for _, name := range ref.columns {
+		idx := -1
+		if info.table.TableColumns != nil {
+			for i, column := range info.table.TableColumns.TableColumn {
+				if column != nil && strings.EqualFold(strings.TrimSpace(column.Name), name) {
+					idx = i
+					break
+				}
+			}
+		}
+		if idx == -1 || coordinates[0]+idx > coordinates[2] {
+			return formulaErrorREF
+		}
+		columns = append(columns, coordinates[0]+idx)
+	}
+	if len(columns) > 0 {
+		sort.Ints(columns)
+		coordinates[0], coordinates[2] = columns[0], columns[len(columns)-1]
+	}
+	ref1, _ := CoordinatesToCellName(coordinates[0], coordinates[1], true)
+	result := fmt.Sprintf("'%s'!%s", strings.ReplaceAll(info.sheet, "'", "''"), ref1)
+	if coordinates[0] != coordinates[2] || coordinates[1] != coordinates[3] {
+		ref2, _ := CoordinatesToCellName(coordinates[2], coordinates[3], true)
+		result += ":" + ref2
+	}
+	return result
+}
+
+// resolveStructuredRefs replaces the structured references in the formula of
+// the cell with the range references of the tables in the workbook. The tables
+// of the workbook will be read once and cached in the calculation context.
+func (f *File) resolveStructuredRefs(ctx *calcContext, sheet, cell, formula string) (string, error) {
+	if !strings.Contains(formula, "[") {
+		return formula, nil
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return formula, err
+	}
+	ctx.mu.Lock()
+	tables := ctx.tables
+	if tables == nil {
+		if tables, err = f.getWorkbookTables(); err != nil {
+			ctx.mu.Unlock()
+			return formula, err
+		}
+		ctx.tables = tables
+	}
+	ctx.mu.Unlock()
+	return replaceStructuredRefs(formula, func(ref *structuredRef, text string) (string, error) {
+		info, ok := tables[strings.ToLower(ref.table)]
+		if ref.table == "" {
+			for _, t := range tables {
+				if t.sheet == sheet && cellInRange([]int{col, row}, t.coordinates) {
+					info, ok = t, true
+					break
+				}
+			}
+		}
+		if !ok {
+			return text, nil
+		}
+		return resolveStructuredRef(ref, info, sheet, col, row), nil
+	})
+}
+
 // prepareValueRange prepare value range.
 func prepareValueRange(cr cellRange, valueRange []int) {
 	if cr.From.Row < valueRange[0] || valueRange[0] == 0 {
@@ -1643,6 +2009,11 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 	switch cellType {
 	case CellTypeBool:
 		return arg.ToBool(), err
+	case CellTypeCheckbox:
+		if value == "" {
+			return newBoolFormulaArg(false), err
+		}
+		return arg.ToBool(), err
 	case CellTypeNumber, CellTypeUnset:
 		if arg.Value() == "" {
 			return newEmptyFormulaArg(), err
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"container/list"
+	"encoding/xml"
 	"math"
 	"path/filepath"
 	"strings"
@@ -6446,3 +6447,158 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestCalcStructuredReferences(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Region", "Qty", "Amount", "Line"},
+		{"East", 2, 10},
+		{"West", 3, 20},
+		{"North", 4, 30},
+		{"Total", nil, 60},
+	} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:D5", Name: "Sales"}))
+	// Mark the last row of the table as totals row
+	tables, err := f.getWorkbookTables()
+	assert.NoError(t, err)
+	tables["sales"].table.TotalsRowCount = 1
+	table, err := xml.Marshal(tables["sales"].table)
+	assert.NoError(t, err)
+	f.Pkg.Store(tables["sales"].tableXML, table)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, tbl := range [][]string{
+		{"Sheet1", "F1", "SUM(Sales[Amount])", "60"},
+		{"Sheet1", "F2", "SUM(sales[amount])", "60"},
+		{"Sheet1", "F3", "SUM(Sales[[#Totals],[Amount]])", "60"},
+		{"Sheet1", "F4", "SUM(Sales[[#All],[Amount]])", "120"},
+		{"Sheet1", "F5", "COUNTA(Sales[#Headers])", "4"},
+		{"Sheet1", "F6", "SUM(Sales[[#Data],[Qty]])", "9"},
+		{"Sheet1", "F7", "SUM(Sales[[#Data],[#Totals],[Qty]:[Amount]])", "129"},
+		{"Sheet1", "F8", "SUM(Sales[[Qty]:[Amount]])", "69"},
+		{"Sheet1", "F9", "ROWS(Sales[])", "3"},
+		{"Sheet1", "F10", "Sales[Missing]", "#REF!"},
+		{"Sheet1", "F11", "Sales[@Qty]", "#VALUE!"},
+		{"Sheet1", "F12", "\"Sales[Qty]\"", "Sales[Qty]"},
+		{"Sheet2", "A1", "SUM(Sales[Qty])*2", "18"},
+		{"Sheet1", "D2", "Sales[@Qty]*Sales[@[Amount]]", "20"},
+		{"Sheet1", "D3", "[@Qty]*[@Amount]", "60"},
+		{"Sheet1", "D4", "Sales[[#This Row],[Amount]]", "30"},
+		{"Sheet1", "D5", "Sales[@Qty]", "#VALUE!"},
+	} {
+		assert.NoError(t, f.SetCellFormula(tbl[0], tbl[1], tbl[2]))
+		result, _ := f.CalcCellValue(tbl[0], tbl[1])
+		assert.Equal(t, tbl[3], result, tbl[2])
+	}
+	// Test structured references after resizing the table
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 100))
+	result, err := f.CalcCellValue("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "160", result)
+	// Test rewrite structured references after deleting columns of the table
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	for cell, expected := range map[string]string{
+		"E1":  "SUM(Sales[Amount])",
+		"E7":  "SUM(#REF!)",
+		"E9":  "SUM(#REF!)",
+		"E10": "ROWS(Sales[])",
+		"C2":  "#REF!*Sales[@[Amount]]",
+		"C4":  "#REF!*[@Amount]",
+		"C5":  "Sales[[#This Row],[Amount]]",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(#REF!)*2", formula)
+	// Test rewrite structured references after renaming the table and columns
+	tables, err = f.getWorkbookTables()
+	assert.NoError(t, err)
+	tables["sales"].table.Name = "Orders"
+	assert.NoError(t, f.renameStructuredRefs("Sheet1", tables["sales"].table, "Sales", []int{1, 1, 3, 6},
+		map[string]string{"amount": "Total Amount"}))
+	for cell, expected := range map[string]string{
+		"E1":  "SUM(Orders[Total Amount])",
+		"E10": "ROWS(Orders[])",
+		"C4":  "#REF!*[@[Total Amount]]",
+		"C5":  "Orders[@[Total Amount]]",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test resolve structured references with invalid cell reference
+	_, err = f.resolveStructuredRefs(&calcContext{}, "Sheet1", "A", "Sales[Qty]")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test the tables of the workbook have been cached in the calculation context
+	ctx := &calcContext{}
+	formula, err = f.resolveStructuredRefs(ctx, "Sheet1", "A1", "ROWS(Sales[])")
+	assert.NoError(t, err)
+	assert.Equal(t, "ROWS('Sheet1'!$A$2:$C$5)", formula)
+	assert.Contains(t, ctx.tables, "sales")
+	ctx.tables = map[string]*tableRefInfo{}
+	formula, err = f.resolveStructuredRefs(ctx, "Sheet1", "A1", "ROWS(Sales[])")
+	assert.NoError(t, err)
+	assert.Equal(t, "ROWS(Sales[])", formula)
+}
+
+func TestCalcErrorLiterals(t *testing.T) {
+	f := NewFile()
+	for _, tbl := range [][]string{
+		{"IFERROR(#N/A,1)", "1", ""},
+		{"IFNA(#N/A,2)", "2", ""},
+		{"ISERROR(#REF!)", "TRUE", ""},
+		{"ISNA(#N/A)", "TRUE", ""},
+		{"IF(ISERROR(#VALUE!),\"y\",\"n\")", "y", ""},
+		{"ERROR.TYPE(#DIV/0!)", "2", ""},
+		{"#DIV/0!+1", "", formulaErrorDIV},
+		{"-#N/A", "", formulaErrorNA},
+		{"1&#NULL!", "", formulaErrorNULL},
+		{"SUM(1,#NUM!)", formulaErrorNUM, formulaErrorNUM},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", tbl[0]))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		if tbl[2] == "" {
+			assert.NoError(t, err, tbl[0])
+		} else {
+			assert.EqualError(t, err, tbl[2], tbl[0])
+		}
+		assert.Equal(t, tbl[1], result, tbl[0])
+	}
+}
+
+func TestStructuredRefString(t *testing.T) {
+	for _, tbl := range [][]string{
+		{"Sales[Amount]", "Sales[Amount]"},
+		{"Sales[@Qty]", "Sales[@Qty]"},
+		{"Sales[@[Unit Price]]", "Sales[@[Unit Price]]"},
+		{"Sales[[#totals],[Amount]]", "Sales[[#Totals],[Amount]]"},
+		{"Sales[[#This Row],[Amount]]", "Sales[@Amount]"},
+		{"Sales[#data]", "Sales[[#Data]]"},
+		{"Sales[[Qty]:[Amount]]", "Sales[[Qty]:[Amount]]"},
+		{"Sales[@]", "Sales[@]"},
+		{"Sales[]", "Sales[]"},
+		{"Sales['#Items]", "Sales['#Items]"},
+		{"[1]Sheet1!A1", "[1]Sheet1!A1"},
+		{"Sales[[A]:[B]:[C]]", "Sales[[A]:[B]:[C]]"},
+		{"Sales[[A],[B]]", "Sales[[A],[B]]"},
+		{"Sales[[A]", "Sales[[A]"},
+	} {
+		result, err := replaceStructuredRefs(tbl[0], func(ref *structuredRef, text string) (string, error) {
+			return ref.String(), nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tbl[1], result, tbl[0])
+	}
+	_, err := replaceStructuredRefs("Sales[A]", func(ref *structuredRef, text string) (string, error) {
+		return text, ErrParameterInvalid
+	})
+	assert.Equal(t, ErrParameterInvalid, err)
+}
@@ -22,6 +22,8 @@ import (
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/mohae/deepcopy"
 )
 
 // CellType is the type of cell value type.
@@ -37,6 +39,7 @@ const (
 	CellTypeInlineString
 	CellTypeNumber
 	CellTypeSharedString
+	CellTypeCheckbox
 )
 
 const (
@@ -66,31 +69,42 @@ var cellTypes = map[string]CellType{
 // converted to the 'string' data type. This function is concurrency safe. If
 // the cell format can be applied to the value of a cell, the applied value
 // will be returned, otherwise the original value will be returned. All cells'
-// values will be the same in a merged range.
+// values will be the same in a merged range. The empty in-cell checkbox will
+// be returned as "FALSE".
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		sst, err := f.sharedStringsReader()
 		if err != nil {
 			return "", true, err
 		}
-		val, err := c.getValueFrom(f, sst, f.getOptions(opts...).RawCellValue)
+		raw := f.getOptions(opts...).RawCellValue
+		val, err := c.getValueFrom(f, sst, raw)
+		if err == nil && !raw && c.T == "" && c.V == "" && f.isCheckboxStyle(c.S) {
+			val = "FALSE"
+		}
 		return val, true, err
 	})
 }
 
 // GetCellType provides a function to get the cell's data type by given
-// worksheet name and cell reference in spreadsheet file.
+// worksheet name and cell reference in spreadsheet file. The CellTypeCheckbox
+// will be returned for the boolean or empty cell with in-cell checkbox.
 func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	var (
 		err         error
 		cellTypeStr string
 		cellType    CellType
+		checkbox    bool
 	)
 	if cellTypeStr, err = f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		checkbox = (c.T == "b" || c.T == "" && c.V == "") && f.isCheckboxStyle(c.S)
 		return c.T, true, nil
 	}); err != nil {
 		return CellTypeUnset, err
 	}
+	if checkbox {
+		return CellTypeCheckbox, err
+	}
 	cellType = cellTypes[cellTypeStr]
 	return cellType, err
 }
@@ -377,6 +391,257 @@ func setCellBool(value bool) (t string, v string) {
 	return
 }
 
+// SetCellCheckbox provides a function to insert in-cell checkboxes into the
+// cells by given worksheet name and range reference. The in-cell checkbox is a
+// cell format of the boolean value, so the checkboxes could be sorted and
+// filtered as the boolean values, use the SetCellBool function to check or
+// uncheck it. The empty cells in the range will be set as FALSE, and the
+// values of other cells will be kept. Note that the in-cell checkbox is only
+// supported in the newer version of Excel. For example, insert checkboxes into
+// Sheet1!A2:A10:
+//
+//	err := f.SetCellCheckbox("Sheet1", "A2:A10")
+func (f *File) SetCellCheckbox(sheet, rangeRef string) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	bags, err := f.featurePropertyBagsReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	idx := bags.getCheckboxComplementIndex()
+	if idx == -1 {
+		idx = bags.addCheckbox()
+		if err = f.addFeaturePropertyBagPart(bags); err != nil {
+			f.mu.Unlock()
+			return err
+		}
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	styles := map[int]int{}
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			c, col, row, err := ws.prepareCell(cell)
+			if err != nil {
+				return err
+			}
+			styleID := ws.prepareCellStyle(col, row, c.S)
+			if _, ok := styles[styleID]; !ok {
+				styles[styleID] = s.getCheckboxStyleID(styleID, idx)
+			}
+			c.S = styles[styleID]
+			if c.T == "" && c.V == "" && c.F == nil {
+				c.T, c.V = setCellBool(false)
+			}
+		}
+	}
+	return err
+}
+
+// isCheckboxStyle provides a function to check if the cell format of the
+// given style index has the in-cell checkbox feature.
+func (f *File) isCheckboxStyle(styleID int) bool {
+	s, err := f.stylesReader()
+	if err != nil || s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return false
+	}
+	idx := getXfComplementIndex(s.CellXfs.Xf[styleID])
+	if idx == -1 {
+		return false
+	}
+	bags, err := f.featurePropertyBagsReader()
+	if err != nil {
+		return false
+	}
+	return bags.isCheckboxComplement(idx)
+}
+
+// getXfComplementIndex provides a function to get the index of the feature
+// property bag complement in the extension list by given cell format, returns
+// -1 if not found.
+func getXfComplementIndex(xf xlsxXf) int {
+	if xf.ExtLst == nil {
+		return -1
+	}
+	var extLst decodeExtLst
+	if err := xml.Unmarshal([]byte("<extLst>"+xf.ExtLst.Ext+"</extLst>"), &extLst); err != nil {
+		return -1
+	}
+	for _, ext := range extLst.Ext {
+		if ext.URI != ExtURIFeaturePropertyBag {
+			continue
+		}
+		var xfComplement decodeXfComplement
+		if err := xml.Unmarshal([]byte(ext.Content), &xfComplement); err == nil {
+			return xfComplement.I
+		}
+	}
+	return -1
+}
+
+// getCheckboxStyleID provides a function to get the index of the cell format
+// with in-cell checkbox feature based on the given cell format, the new cell
+// format will be created if not exist.
+func (ss *xlsxStyleSheet) getCheckboxStyleID(styleID, idx int) int {
+	if ss.CellXfs == nil {
+		ss.CellXfs = &xlsxCellXfs{}
+	}
+	var xf xlsxXf
+	if styleID < len(ss.CellXfs.Xf) {
+		xf = deepcopy.Copy(ss.CellXfs.Xf[styleID]).(xlsxXf)
+	}
+	xf.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:xfpb="%s"><xfpb:xfComplement i="%d"/></ext>`,
+		ExtURIFeaturePropertyBag, NameSpaceFeaturePropertyBag, idx)}
+	for i, v := range ss.CellXfs.Xf {
+		if reflect.DeepEqual(v, xf) {
+			return i
+		}
+	}
+	ss.CellXfs.Xf = append(ss.CellXfs.Xf, xf)
+	ss.CellXfs.Count = len(ss.CellXfs.Xf)
+	return ss.CellXfs.Count - 1
+}
+
+// getCheckboxComplementIndex provides a function to get the index of the
+// checkbox complement in the mapped feature property bags, returns -1 if not
+// found.
+func (bags *xlsxFeaturePropertyBags) getCheckboxComplementIndex() int {
+	for _, bag := range bags.Bag {
+		if bag.Type != "XFComplements" {
+			continue
+		}
+		for _, a := range bag.A {
+			if a.K != "MappedFeaturePropertyBags" {
+				continue
+			}
+			for i, bagID := range a.BagID {
+				if bags.isCheckbox(bagID.Val) {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// isCheckboxComplement provides a function to check if the complement in the
+// mapped feature property bags of given index is a checkbox.
+func (bags *xlsxFeaturePropertyBags) isCheckboxComplement(idx int) bool {
+	for _, bag := range bags.Bag {
+		if bag.Type != "XFComplements" {
+			continue
+		}
+		for _, a := range bag.A {
+			if a.K == "MappedFeaturePropertyBags" && idx < len(a.BagID) {
+				return bags.isCheckbox(a.BagID[idx].Val)
+			}
+		}
+	}
+	return false
+}
+
+// isCheckbox provides a function to check if the feature property bag of the
+// given index references to the checkbox by the cell control.
+func (bags *xlsxFeaturePropertyBags) isCheckbox(ID int) bool {
+	for _, step := range [][]string{{"XFComplement", "XFControls"}, {"XFControls", "CellControl"}} {
+		if ID < 0 || ID >= len(bags.Bag) || bags.Bag[ID].Type != step[0] {
+			return false
+		}
+		next := -1
+		for _, bagID := range bags.Bag[ID].BagID {
+			if bagID.K == step[1] {
+				next = bagID.Val
+			}
+		}
+		ID = next
+	}
+	return ID >= 0 && ID < len(bags.Bag) && bags.Bag[ID].Type == "Checkbox"
+}
+
+// addCheckbox provides a function to add the feature property bags of the
+// checkbox, returns the index of the checkbox complement in the mapped
+// feature property bags.
+func (bags *xlsxFeaturePropertyBags) addCheckbox() int {
+	ID := len(bags.Bag)
+	bags.Bag = append(bags.Bag,
+		xlsxFeaturePropertyBag{Type: "Checkbox"},
+		xlsxFeaturePropertyBag{Type: "XFControls", BagID: []xlsxBagID{{K: "CellControl", Val: ID}}},
+		xlsxFeaturePropertyBag{Type: "XFComplement", BagID: []xlsxBagID{{K: "XFControls", Val: ID + 1}}},
+	)
+	for i, bag := range bags.Bag {
+		if bag.Type != "XFComplements" {
+			continue
+		}
+		for j, a := range bag.A {
+			if a.K == "MappedFeaturePropertyBags" {
+				bags.Bag[i].A[j].BagID = append(bags.Bag[i].A[j].BagID, xlsxBagID{Val: ID + 2})
+				return len(bags.Bag[i].A[j].BagID) - 1
+			}
+		}
+		bags.Bag[i].A = append(bags.Bag[i].A, xlsxBagArray{K: "MappedFeaturePropertyBags", BagID: []xlsxBagID{{Val: ID + 2}}})
+		return 0
+	}
+	bags.Bag = append(bags.Bag, xlsxFeaturePropertyBag{
+		Type: "XFComplements", ExtRef: "XFComplementsMapperExtRef",
+		A: []xlsxBagArray{{K: "MappedFeaturePropertyBags", BagID: []xlsxBagID{{Val: ID + 2}}}},
+	})
+	return 0
+}
+
+// addFeaturePropertyBagPart provides a function to save the feature property
+// bag part, and add the workbook relationships and content types of it if not
+// exist.
+func (f *File) addFeaturePropertyBagPart(bags *xlsxFeaturePropertyBags) error {
+	bags.XMLNS = NameSpaceFeaturePropertyBag
+	output, err := xml.Marshal(bags)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(defaultXMLFeaturePropertyBag, output)
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return err
+	}
+	var exist bool
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipFeaturePropertyBag {
+				exist = true
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if !exist {
+		f.addRels(relPath, SourceRelationshipFeaturePropertyBag, strings.TrimPrefix(defaultXMLFeaturePropertyBag, "xl/"), "")
+	}
+	return f.addContentTypePart(0, "featurePropertyBag")
+}
+
 // SetCellFloat sets a floating point value into a cell. The precision
 // parameter specifies how many places after the decimal will be shown
 // while -1 is a special value that will use as many decimal places as
@@ -461,6 +726,7 @@ func (f *File) setCellString(value string) (t, v string, err error) {
 func (f *File) sharedStringsLoader() (err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.loadZipFile(defaultXMLPathSharedStrings)
 	if path, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
 		f.Pkg.Store(defaultXMLPathSharedStrings, f.readBytes(defaultXMLPathSharedStrings))
 		f.tempFiles.Delete(defaultXMLPathSharedStrings)
@@ -1421,6 +1687,624 @@ func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDi
 	return err
 }
 
+// MoveRange provides a function to move a range of cells like cut and paste
+// in Excel, by given source worksheet name, source range reference,
+// destination worksheet name and the top-left cell reference of the
+// destination. The values, formulas, styles, merged cells, comments,
+// hyperlinks, data validations and conditional formats in the source range
+// will be moved to the destination, and the cells in the destination range
+// will be overwritten. The formulas, data validations, conditional formats and
+// defined names in the workbook which refer to the cells in the source range
+// will be updated to refer to the new location, and the references to the
+// overwritten cells will be changed to the #REF! error. For example, move the
+// cells A1:C5 on Sheet1 to the range starts with cell E3 on Sheet2:
+//
+//	err := f.MoveRange("Sheet1", "A1:C5", "Sheet2", "E3")
+//
+// Note that moving part of merged cells or array formulas is not allowed, and
+// the tables, pictures, charts and shapes in the source range will not be
+// moved.
+func (f *File) MoveRange(srcSheet, srcRange, dstSheet, dstCell string) error {
+	src, dst, err := prepareRangeDestination(srcRange, dstCell, false)
+	if err != nil {
+		return err
+	}
+	if srcSheet == dstSheet && src[0] == dst[0] && src[1] == dst[1] {
+		_, err = f.workSheetReader(srcSheet)
+		return err
+	}
+	return f.moveCellRange(&moveRange{srcSheet: srcSheet, dstSheet: dstSheet, src: src, dst: dst})
+}
+
+// prepareRangeDestination returns the coordinates of the source range and the
+// destination range by given source range reference and the top-left cell
+// reference of the destination.
+func prepareRangeDestination(srcRange, dstCell string, transpose bool) ([]int, []int, error) {
+	src, err := sqrefToRects(srcRange)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(src) != 1 {
+		return nil, nil, ErrParameterInvalid
+	}
+	col, row, err := CellNameToCoordinates(dstCell)
+	if err != nil {
+		return nil, nil, err
+	}
+	width, height := src[0][2]-src[0][0], src[0][3]-src[0][1]
+	if transpose {
+		width, height = height, width
+	}
+	dst := []int{col, row, col + width, row + height}
+	if dst[2] > MaxColumns {
+		return nil, nil, ErrColumnNumber
+	}
+	if dst[3] > TotalRows {
+		return nil, nil, ErrMaxRows
+	}
+	return src[0], dst, err
+}
+
+// PasteType is the type of paste special for copying a range of cells.
+type PasteType byte
+
+// This section defines the currently supported paste types enumeration.
+const (
+	PasteAll PasteType = iota
+	PasteValues
+	PasteFormats
+	PasteFormulas
+)
+
+// PasteOperation is the type of arithmetic operation which combines the copied
+// values with the values in the destination cells.
+type PasteOperation byte
+
+// This section defines the currently supported paste operations enumeration.
+const (
+	PasteOperationNone PasteOperation = iota
+	PasteOperationAdd
+	PasteOperationSubtract
+	PasteOperationMultiply
+	PasteOperationDivide
+)
+
+// CopyRangeOptions directly maps the paste special settings for copying a
+// range of cells.
+type CopyRangeOptions struct {
+	Type       PasteType
+	Operation  PasteOperation
+	SkipBlanks bool
+	Transpose  bool
+}
+
+// copyRange defines the source and destination range of cells and the paste
+// special settings on copying a range of cells.
+type copyRange struct {
+	moveRange
+	opts *CopyRangeOptions
+}
+
+// position returns the coordinates of the destination cell by given
+// coordinates of the source cell.
+func (cr *copyRange) position(col, row int) (int, int) {
+	if cr.opts.Transpose {
+		return cr.dst[0] + row - cr.src[1], cr.dst[1] + col - cr.src[0]
+	}
+	return cr.dst[0] + col - cr.src[0], cr.dst[1] + row - cr.src[1]
+}
+
+// mapRect returns the destination range by given range inside of the source
+// range.
+func (cr *copyRange) mapRect(rect []int) []int {
+	x1, y1 := cr.position(rect[0], rect[1])
+	x2, y2 := cr.position(rect[2], rect[3])
+	return []int{x1, y1, x2, y2}
+}
+
+// CopyRange provides a function to copy a range of cells like copy and paste
+// in Excel, by given source worksheet name, source range reference,
+// destination worksheet name, the top-left cell reference of the destination
+// and paste special options. The relative references in the copied formulas
+// will be shifted, and the shared formulas which entirely inside of the source
+// range will be kept as shared formulas. The options can be nil, which means
+// paste all of the values, formulas, styles, merged cells, comments,
+// hyperlinks, data validations and conditional formats. The optional paste
+// types are:
+//
+//	 Type          | Description
+//	---------------+------------------------------------------------
+//	 PasteAll      | Values, formulas, formats, comments and so on
+//	 PasteValues   | Values and the results of formulas
+//	 PasteFormats  | Styles, merged cells and conditional formats
+//	 PasteFormulas | Values and formulas without formats
+//
+// The Operation specifies the arithmetic operation (add, subtract, multiply
+// and divide) which combines the copied numbers with the destination cells,
+// the SkipBlanks specifies to avoid replacing values in the destination when
+// blank cells occur in the copied range, and the Transpose specifies to
+// change columns of copied cells to rows, or vice versa, the relative
+// references in the copied formulas will be transposed too. For example, copy
+// the values of cells A1:C5 on Sheet1 and add them to the cells of the range
+// starts with cell E3 on Sheet2:
+//
+//	err := f.CopyRange("Sheet1", "A1:C5", "Sheet2", "E3", &excelize.CopyRangeOptions{
+//	    Type:      excelize.PasteValues,
+//	    Operation: excelize.PasteOperationAdd,
+//	})
+func (f *File) CopyRange(srcSheet, srcRange, dstSheet, dstCell string, opts *CopyRangeOptions) error {
+	if opts == nil {
+		opts = &CopyRangeOptions{}
+	}
+	src, dst, err := prepareRangeDestination(srcRange, dstCell, opts.Transpose)
+	if err != nil {
+		return err
+	}
+	if opts.Type > PasteFormulas || opts.Operation > PasteOperationDivide {
+		return ErrParameterInvalid
+	}
+	return f.copyCellRange(&copyRange{
+		moveRange: moveRange{srcSheet: srcSheet, dstSheet: dstSheet, src: src, dst: dst},
+		opts:      opts,
+	})
+}
+
+// copySharedFormulas returns the master cells of the shared formulas in the
+// worksheet, and the new index of shared formulas which entirely inside of the
+// source range when copying the range of cells without transposing.
+func (cr *copyRange) copySharedFormulas(wsSrc, wsDst *xlsxWorksheet) (map[int]*xlsxC, map[int]int) {
+	masters, partial, indexes := map[int]*xlsxC{}, map[int]bool{}, map[int]int{}
+	for r := range wsSrc.SheetData.Row {
+		for c := range wsSrc.SheetData.Row[r].C {
+			cell := &wsSrc.SheetData.Row[r].C[c]
+			if cell.F == nil || cell.F.T != STCellFormulaTypeShared || cell.F.Si == nil {
+				continue
+			}
+			if cell.F.Ref != "" {
+				masters[*cell.F.Si] = deepcopy.Copy(cell).(*xlsxC)
+			}
+			if col, row, err := CellNameToCoordinates(cell.R); err != nil || !cellInRange([]int{col, row}, cr.src) {
+				partial[*cell.F.Si] = true
+			}
+		}
+	}
+	if cr.opts.Transpose {
+		return masters, indexes
+	}
+	cnt := wsDst.countSharedFormula()
+	for si := range masters {
+		if !partial[si] {
+			indexes[si] = cnt
+			cnt++
+		}
+	}
+	return masters, indexes
+}
+
+// copyFormula returns the formula of the destination cell by given source
+// cell, the master cells and new indexes of shared formulas.
+func (f *File) copyFormula(cr *copyRange, sc *xlsxC, col, row int, masters map[int]*xlsxC, indexes map[int]int) (*xlsxF, error) {
+	srcCol, srcRow, err := CellNameToCoordinates(sc.R)
+	if err != nil {
+		return nil, err
+	}
+	if sc.F.T == STCellFormulaTypeShared && sc.F.Si != nil {
+		master := masters[*sc.F.Si]
+		if master == nil {
+			return nil, err
+		}
+		if si, ok := indexes[*sc.F.Si]; ok {
+			formula := &xlsxF{T: STCellFormulaTypeShared, Si: intPtr(si)}
+			if sc.F.Ref != "" {
+				rects, err := sqrefToRects(sc.F.Ref)
+				if err != nil {
+					return nil, err
+				}
+				if formula.Ref, err = rectsToSQRef([][]int{cr.mapRect(rects[0])}); err != nil {
+					return nil, err
+				}
+				formula.Content, err = f.pasteFormula(cr, sc.F.Content, srcCol, srcRow, col, row)
+			}
+			return formula, err
+		}
+		formula := &xlsxF{}
+		formula.Content, err = f.pasteFormula(cr, getSharedFormulaByMaster(master, sc.R), srcCol, srcRow, col, row)
+		return formula, err
+	}
+	formula := deepcopy.Copy(sc.F).(*xlsxF)
+	if formula.T == STCellFormulaTypeArray && formula.Ref != "" {
+		rects, err := sqrefToRects(formula.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if formula.Ref = ""; inRect(rects[0], cr.src) {
+			if formula.Ref, err = rectsToSQRef([][]int{cr.mapRect(rects[0])}); err != nil {
+				return nil, err
+			}
+		} else {
+			formula.T = ""
+		}
+	}
+	formula.Content, err = f.pasteFormula(cr, formula.Content, srcCol, srcRow, col, row)
+	return formula, err
+}
+
+// pasteFormula returns the formula of the destination cell by given formula
+// and coordinates of the source cell and coordinates of the destination cell,
+// the relative references will be transposed when pasting with transpose.
+func (f *File) pasteFormula(cr *copyRange, formula string, srcCol, srcRow, dstCol, dstRow int) (string, error) {
+	if cr.opts.Transpose {
+		return f.transposeFormula(cr.srcSheet, formula, srcCol, srcRow, dstCol, dstRow)
+	}
+	return f.shiftFormula(cr.srcSheet, formula, dstCol-srcCol, dstRow-srcRow)
+}
+
+// pasteValue sets the value of the destination cell by given source cell, the
+// formula of the source cell will be replaced by its cached result.
+func (f *File) pasteValue(dc, sc *xlsxC) error {
+	dc.F, dc.f, dc.T, dc.V, dc.IS = nil, "", "", "", nil
+	if sc == nil {
+		return nil
+	}
+	if dc.T, dc.V = sc.T, sc.V; sc.IS != nil {
+		dc.IS = deepcopy.Copy(sc.IS).(*xlsxSI)
+	}
+	if sc.T == "str" {
+		var err error
+		dc.T, dc.V, err = f.setCellString(sc.V)
+		return err
+	}
+	return nil
+}
+
+// pasteOperation combines the number of the source cell into the destination
+// cell by given arithmetic operation. It returns false if the source cell is
+// not a number, the blank cell will be treated as number zero.
+func pasteOperation(dc, sc *xlsxC, op PasteOperation) bool {
+	var num float64
+	if sc != nil && (sc.V != "" || sc.IS != nil) {
+		if sc.T != "" && sc.T != "n" {
+			return false
+		}
+		var err error
+		if num, err = strconv.ParseFloat(sc.V, 64); err != nil {
+			return false
+		}
+	}
+	operator := map[PasteOperation]string{
+		PasteOperationAdd: "+", PasteOperationSubtract: "-", PasteOperationMultiply: "*", PasteOperationDivide: "/",
+	}[op]
+	if dc.F != nil {
+		dc.F = &xlsxF{Content: "(" + dc.F.Content + ")" + operator + strconv.FormatFloat(num, 'f', -1, 64)}
+		dc.f, dc.T, dc.V = "", "", ""
+		return true
+	}
+	if dc.T != "" && dc.T != "n" {
+		return true
+	}
+	val, _ := strconv.ParseFloat(dc.V, 64)
+	switch op {
+	case PasteOperationAdd:
+		val += num
+	case PasteOperationSubtract:
+		val -= num
+	case PasteOperationMultiply:
+		val *= num
+	default:
+		if num == 0 {
+			dc.T, dc.V = "e", formulaErrorDIV
+			return true
+		}
+		val /= num
+	}
+	dc.setCellFloat(val, -1, 64)
+	return true
+}
+
+// copyCells copies the cells in the source range to the destination range by
+// given paste special settings.
+func (f *File) copyCells(cr *copyRange, wsSrc, wsDst *xlsxWorksheet) error {
+	masters, indexes := cr.copySharedFormulas(wsSrc, wsDst)
+	var cells []*xlsxC
+	for r := cr.src[1]; r <= cr.src[3]; r++ {
+		for c := cr.src[0]; c <= cr.src[2]; c++ {
+			if r <= len(wsSrc.SheetData.Row) && c <= len(wsSrc.SheetData.Row[r-1].C) {
+				cells = append(cells, deepcopy.Copy(&wsSrc.SheetData.Row[r-1].C[c-1]).(*xlsxC))
+				continue
+			}
+			cells = append(cells, nil)
+		}
+	}
+	sheetID, idx := f.getSheetID(cr.dstSheet), 0
+	for r := cr.src[1]; r <= cr.src[3]; r++ {
+		for c := cr.src[0]; c <= cr.src[2]; c, idx = c+1, idx+1 {
+			sc := cells[idx]
+			if cr.opts.SkipBlanks && (sc == nil || (sc.F == nil && sc.V == "" && sc.IS == nil)) {
+				continue
+			}
+			col, row := cr.position(c, r)
+			wsDst.prepareSheetXML(col, row)
+			dc := &wsDst.SheetData.Row[row-1].C[col-1]
+			if dc.F != nil {
+				if err := f.deleteCalcChain(sheetID, dc.R); err != nil {
+					return err
+				}
+			}
+			if sc != nil && (cr.opts.Type == PasteAll || cr.opts.Type == PasteFormats) {
+				dc.S = sc.S
+			}
+			if cr.opts.Operation != PasteOperationNone && pasteOperation(dc, sc, cr.opts.Operation) {
+				continue
+			}
+			switch cr.opts.Type {
+			case PasteAll:
+				if sc == nil {
+					*dc = xlsxC{R: dc.R, S: dc.S}
+					continue
+				}
+				cell := *sc
+				cell.R, cell.f = dc.R, ""
+				if cell.F != nil {
+					formula, err := f.copyFormula(cr, sc, col, row, masters, indexes)
+					if err != nil {
+						return err
+					}
+					if cell.F = formula; formula == nil || formula.Content != sc.F.Content {
+						cell.T, cell.V = "", ""
+					}
+				}
+				*dc = cell
+			case PasteValues:
+				if err := f.pasteValue(dc, sc); err != nil {
+					return err
+				}
+			case PasteFormulas:
+				if err := f.pasteValue(dc, sc); err != nil || sc == nil || sc.F == nil {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				formula, err := f.copyFormula(cr, sc, col, row, masters, indexes)
+				if err != nil {
+					return err
+				}
+				if dc.F = formula; formula == nil || formula.Content != sc.F.Content {
+					dc.T, dc.V, dc.IS = "", "", nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// copyMergeCells copies the merged cells inside of the source range to the
+// destination range, and removes the merged cells inside of the destination
+// range.
+func (cr *copyRange) copyMergeCells(wsSrc, wsDst *xlsxWorksheet) error {
+	var copied []*xlsxMergeCell
+	if wsSrc.MergeCells != nil {
+		for _, mc := range wsSrc.MergeCells.Cells {
+			if mc == nil {
+				continue
+			}
+			rects, err := sqrefToRects(mc.Ref)
+			if err != nil {
+				return err
+			}
+			if len(rects) == 0 || !inRect(rects[0], cr.src) {
+				continue
+			}
+			rect := cr.mapRect(rects[0])
+			ref, err := coordinatesToRangeRef(rect)
+			if err != nil {
+				return err
+			}
+			copied = append(copied, &xlsxMergeCell{Ref: ref, rect: rect})
+		}
+	}
+	if wsDst.MergeCells != nil {
+		for i := 0; i < len(wsDst.MergeCells.Cells); i++ {
+			mc := wsDst.MergeCells.Cells[i]
+			if mc == nil {
+				continue
+			}
+			if rects, err := sqrefToRects(mc.Ref); err == nil && len(rects) > 0 && inRect(rects[0], cr.dst) {
+				wsDst.MergeCells.Cells = append(wsDst.MergeCells.Cells[:i], wsDst.MergeCells.Cells[i+1:]...)
+				i--
+			}
+		}
+	}
+	if len(copied) > 0 {
+		if wsDst.MergeCells == nil {
+			wsDst.MergeCells = &xlsxMergeCells{}
+		}
+		wsDst.MergeCells.Cells = append(wsDst.MergeCells.Cells, copied...)
+	}
+	if wsDst.MergeCells != nil {
+		if wsDst.MergeCells.Count = len(wsDst.MergeCells.Cells); wsDst.MergeCells.Count == 0 {
+			wsDst.MergeCells = nil
+		}
+	}
+	return nil
+}
+
+// copyHyperlinks copies the hyperlinks inside of the source range to the
+// destination range, and removes the hyperlinks inside of the destination
+// range.
+func (f *File) copyHyperlinks(cr *copyRange, wsSrc, wsDst *xlsxWorksheet) error {
+	var copied []xlsxHyperlink
+	if wsSrc.Hyperlinks != nil {
+		for _, link := range wsSrc.Hyperlinks.Hyperlink {
+			rects, err := sqrefToRects(link.Ref)
+			if err != nil {
+				return err
+			}
+			if len(rects) == 0 || !inRect(rects[0], cr.src) {
+				continue
+			}
+			if link.Ref, err = rectsToSQRef([][]int{cr.mapRect(rects[0])}); err != nil {
+				return err
+			}
+			if link.RID != "" {
+				link.RID = f.getSheetRelationshipsTargetByID(cr.srcSheet, link.RID)
+			}
+			copied = append(copied, link)
+		}
+	}
+	if wsDst.Hyperlinks != nil {
+		for i := 0; i < len(wsDst.Hyperlinks.Hyperlink); i++ {
+			link := wsDst.Hyperlinks.Hyperlink[i]
+			if rects, err := sqrefToRects(link.Ref); err == nil && len(rects) > 0 && inRect(rects[0], cr.dst) {
+				f.deleteSheetRelationships(cr.dstSheet, link.RID)
+				wsDst.Hyperlinks.Hyperlink = append(wsDst.Hyperlinks.Hyperlink[:i], wsDst.Hyperlinks.Hyperlink[i+1:]...)
+				i--
+			}
+		}
+	}
+	if len(copied) > 0 {
+		if wsDst.Hyperlinks == nil {
+			wsDst.Hyperlinks = new(xlsxHyperlinks)
+		}
+		sheetPath, _ := f.getSheetXMLPath(cr.dstSheet)
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+		for _, link := range copied {
+			if link.RID != "" {
+				link.RID = "rId" + strconv.Itoa(f.addRels(sheetRels, SourceRelationshipHyperLink, link.RID, "External"))
+				f.addSheetNameSpace(cr.dstSheet, SourceRelationship)
+			}
+			wsDst.Hyperlinks.Hyperlink = append(wsDst.Hyperlinks.Hyperlink, link)
+		}
+	}
+	if wsDst.Hyperlinks != nil && len(wsDst.Hyperlinks.Hyperlink) == 0 {
+		wsDst.Hyperlinks = nil
+	}
+	return nil
+}
+
+// copyComments copies the comments inside of the source range to the
+// destination range, and removes the comments inside of the destination
+// range. The style, size and visibility of the comment box will be kept.
+func (f *File) copyComments(cr *copyRange) error {
+	srcComments, err := f.getVMLComments(cr.srcSheet, cr.src)
+	if err != nil {
+		return err
+	}
+	dstComments, err := f.getVMLComments(cr.dstSheet, cr.dst)
+	if err != nil {
+		return err
+	}
+	for _, comment := range dstComments {
+		if err = f.DeleteComment(cr.dstSheet, comment.cell); err != nil {
+			return err
+		}
+	}
+	for _, comment := range srcComments {
+		col, row, _ := CellNameToCoordinates(comment.cell)
+		col, row = cr.position(col, row)
+		cell, _ := CoordinatesToCellName(col, row)
+		if err = renewThreadedCommentIDs(&comment); err != nil {
+			return err
+		}
+		if err = f.addVMLComment(cr.dstSheet, cell, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyCellRange copies the cells, merged cells, comments, hyperlinks, data
+// validations and conditional formats from the source range to the
+// destination range by given paste special settings.
+func (f *File) copyCellRange(cr *copyRange) error {
+	wsSrc, err := f.workSheetReader(cr.srcSheet)
+	if err != nil {
+		return err
+	}
+	wsDst, err := f.workSheetReader(cr.dstSheet)
+	if err != nil {
+		return err
+	}
+	if err = wsDst.checkPartialRange(cr.dst, nil); err != nil {
+		return err
+	}
+	wsDst.unshareFormulas(cr.dst)
+	pasteRules := func(orig, cp string, fn func(paste func(formula string) (string, error)) error) error {
+		origRects, err := sqrefToRects(orig)
+		if err != nil {
+			return err
+		}
+		cpRects, err := sqrefToRects(cp)
+		if err != nil {
+			return err
+		}
+		return fn(func(formula string) (string, error) {
+			return f.pasteFormula(cr, formula, origRects[0][0], origRects[0][1], cpRects[0][0], cpRects[0][1])
+		})
+	}
+	var (
+		dvs []*xlsxDataValidation
+		cfs []*xlsxConditionalFormatting
+	)
+	if cr.opts.Type == PasteAll {
+		if dvs, err = wsSrc.copyDataValidations(cr.src, cr.mapRect, func(dv, dvCopy *xlsxDataValidation) error {
+			return pasteRules(dv.Sqref, dvCopy.Sqref, func(paste func(formula string) (string, error)) error {
+				for _, formula := range []*xlsxInnerXML{dvCopy.Formula1, dvCopy.Formula2} {
+					if formula.isFormula() {
+						content, err := paste(formulaUnescaper.Replace(formula.Content))
+						if err != nil {
+							return err
+						}
+						formula.Content = formulaEscaper.Replace(content)
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	if cr.opts.Type == PasteAll || cr.opts.Type == PasteFormats {
+		if cfs, err = wsSrc.copyConditionalFormats(cr.src, cr.mapRect, func(cf, cfCopy *xlsxConditionalFormatting) error {
+			return pasteRules(cf.SQRef, cfCopy.SQRef, func(paste func(formula string) (string, error)) error {
+				for _, rule := range cfCopy.CfRule {
+					for i, formula := range rule.Formula {
+						if rule.Formula[i], err = paste(formula); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	if err = f.copyCells(cr, wsSrc, wsDst); err != nil {
+		return err
+	}
+	if cr.opts.Type == PasteAll || cr.opts.Type == PasteFormats {
+		if err = cr.copyMergeCells(wsSrc, wsDst); err != nil {
+			return err
+		}
+		if err = wsDst.clearConditionalFormats(cr.dst); err != nil {
+			return err
+		}
+		wsDst.ConditionalFormatting = append(wsDst.ConditionalFormatting, cfs...)
+	}
+	if cr.opts.Type != PasteAll {
+		return err
+	}
+	if err = wsDst.clearDataValidations(cr.dst); err != nil {
+		return err
+	}
+	wsDst.appendDataValidations(dvs)
+	if err = f.copyHyperlinks(cr, wsSrc, wsDst); err != nil {
+		return err
+	}
+	return f.copyComments(cr)
+}
+
 // getCellInfo does common preparation for all set cell value functions.
 func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 	var err error
@@ -1693,22 +2577,28 @@ func getSharedFormula(ws *xlsxWorksheet, si int, cell string) string {
 		for column := 0; column < len(r.C); column++ {
 			c := &r.C[column]
 			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && *c.F.Si == si {
-				col, row, _ := CellNameToCoordinates(cell)
-				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
-				dCol := col - sharedCol
-				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return getSharedFormulaByMaster(c, cell)
 			}
 		}
 	}
 	return ""
 }
 
+// getSharedFormulaByMaster returns the formula of the cell in a shared formula
+// by given master cell of the shared formula and cell reference.
+func getSharedFormulaByMaster(master *xlsxC, cell string) string {
+	col, row, _ := CellNameToCoordinates(cell)
+	sharedCol, sharedRow, _ := CellNameToCoordinates(master.R)
+	dCol := col - sharedCol
+	dRow := row - sharedRow
+	orig := []byte(master.F.Content)
+	res, start := parseSharedFormula(dCol, dRow, orig)
+	if start < len(orig) {
+		res += string(orig[start:])
+	}
+	return res
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
 // consideration absolute references with dollar sign ($)
 func shiftCell(cellID string, dCol, dRow int) string {
@@ -332,6 +332,121 @@ func TestSetCellBool(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, f.SetCellBool("Sheet:1", "A1", true))
 }
 
+func TestSetCellCheckbox(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	assert.NoError(t, f.SetCellBool("Sheet1", "A2", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Task"))
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A1:A3"))
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "C1"))
+	// Test set checkbox again on the same cells
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A3:A1"))
+	for cell, expected := range map[string]string{"A1": "FALSE", "A2": "TRUE", "A3": "FALSE", "C1": "FALSE"} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeCheckbox, cellType, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	cellType, err := f.GetCellType("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	// Test the existing cell format was kept
+	styleID, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	s, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, s.Font.Bold)
+	// Test new style doesn't reuse the cell format with checkbox
+	newStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, style, newStyle)
+	// Test uncheck the checkbox and calculate with the checkbox
+	assert.NoError(t, f.SetCellBool("Sheet1", "A2", false))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "NOT(A1)"))
+	result, err := f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", result)
+	assert.NoError(t, f.SetCellBool("Sheet1", "A1", true))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "FALSE", result)
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A5"))
+	// Test get cell value with raw value
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", nil))
+	val, err := f.GetCellValue("Sheet1", "A5", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	val, err = f.GetCellValue("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, "FALSE", val)
+	cellType, err = f.GetCellType("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeCheckbox, cellType)
+	bags, err := f.featurePropertyBagsReader()
+	assert.NoError(t, err)
+	assert.Len(t, bags.Bag, 4)
+
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellCheckbox.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetCellCheckbox.xlsx"))
+	assert.NoError(t, err)
+	cellType, err = f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeCheckbox, cellType)
+	// Test add checkbox into the workbook with existing feature property bags
+	bags, err = f.featurePropertyBagsReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, bags.getCheckboxComplementIndex())
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellCheckbox("Sheet2", "A1"))
+	bags, err = f.featurePropertyBagsReader()
+	assert.NoError(t, err)
+	assert.Len(t, bags.Bag, 4)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test set checkbox with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellCheckbox("Sheet1", "A"))
+	// Test set checkbox on not exists worksheet
+	assert.EqualError(t, f.SetCellCheckbox("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test set checkbox with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test set checkbox with unsupported charset feature property bags
+	f.Pkg.Store(defaultXMLFeaturePropertyBag, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.False(t, f.isCheckboxStyle(0))
+	// Test set checkbox with unsupported charset workbook relationships
+	f.Pkg.Delete(defaultXMLFeaturePropertyBag)
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestFeaturePropertyBags(t *testing.T) {
+	bags := &xlsxFeaturePropertyBags{Bag: []xlsxFeaturePropertyBag{{Type: "XFComplements"}}}
+	assert.Equal(t, -1, bags.getCheckboxComplementIndex())
+	assert.False(t, bags.isCheckboxComplement(0))
+	assert.Equal(t, 0, bags.addCheckbox())
+	assert.Equal(t, 0, bags.getCheckboxComplementIndex())
+	assert.True(t, bags.isCheckboxComplement(0))
+	assert.False(t, bags.isCheckbox(0))
+	assert.False(t, bags.isCheckbox(len(bags.Bag)))
+	assert.Equal(t, -1, getXfComplementIndex(xlsxXf{ExtLst: &xlsxExtLst{Ext: "<ext"}}))
+	assert.Equal(t, -1, getXfComplementIndex(xlsxXf{ExtLst: &xlsxExtLst{Ext: `<ext uri="{00000000-0000-0000-0000-000000000000}"/>`}}))
+}
+
 func TestSetCellTime(t *testing.T) {
 	date, err := time.Parse(time.RFC3339Nano, "2009-11-10T23:00:00Z")
 	assert.NoError(t, err)
@@ -1126,3 +1241,364 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestMoveRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r * 10}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A1:B3)+$A$2"))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sheet1!A2*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "F2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", "overwritten"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B3"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell: "B2", Author: "Excelize", Text: "Comment", Visible: true, Width: 200, Height: 100,
+		Fill: Fill{Color: []string{"DDEBF7"}},
+	}))
+	expectedComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	vml.Shape[0].Style = strings.ReplaceAll(vml.Shape[0].Style, "z-index:1", "z-index:5")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://github.com/xuri/excelize", "External"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A5"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$1:$B$3"}))
+
+	assert.NoError(t, f.MoveRange("Sheet1", "A1:B3", "Sheet1", "E2"))
+	// Test the values and styles have been moved
+	for cell, expected := range map[string]string{"A1": "", "B3": "", "E2": "1", "F3": "20", "E4": "3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test the formulas refer to the moved cells have been updated
+	for _, c := range []struct{ sheet, cell, formula string }{
+		{"Sheet1", "C1", "E2+F2"},
+		{"Sheet1", "D1", "SUM(E2:F4)+$E$3"},
+		{"Sheet 2", "A1", "Sheet1!E3*2"},
+		{"Sheet1", "E1", "#REF!"},
+	} {
+		formula, err := f.GetCellFormula(c.sheet, c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.formula, formula, c.cell)
+	}
+	assert.Equal(t, "Sheet1!$F$2:$F$4", f.GetDefinedName()[0].RefersTo)
+	// Test the merged cells, comments, hyperlinks and data validations have been moved
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "E4:F4", mergeCells[0][0])
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	expectedComments[0].Cell = "F3"
+	assert.Equal(t, expectedComments, comments)
+	assert.Contains(t, vml.Shape[0].Style, "z-index:5")
+	ok, link, err := f.GetCellHyperLink("Sheet1", "E3")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/xuri/excelize", link)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "A4:A5", dvs[0].Sqref)
+	assert.Equal(t, "E2:E4", dvs[1].Sqref)
+
+	// Test move range to another worksheet
+	assert.NoError(t, f.MoveRange("Sheet1", "E2:F4", "Sheet 2", "B2"))
+	for _, c := range []struct{ sheet, cell, formula string }{
+		{"Sheet1", "C1", "'Sheet 2'!B2+'Sheet 2'!C2"},
+		{"Sheet 2", "A1", "'Sheet 2'!B3*2"},
+	} {
+		formula, err := f.GetCellFormula(c.sheet, c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.formula, formula, c.cell)
+	}
+	ok, link, err = f.GetCellHyperLink("Sheet 2", "B3")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/xuri/excelize", link)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	comments, err = f.GetComments("Sheet 2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	// The height of the comment box depends on the default row height of the
+	// worksheet
+	expectedComments[0].Cell, expectedComments[0].Height = "C3", comments[0].Height
+	assert.Equal(t, expectedComments, comments)
+	assert.Contains(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].Shape[0].Style, "z-index:5")
+	// Test move formula cells refer to the cells out of the range
+	assert.NoError(t, f.MoveRange("Sheet1", "C1", "Sheet 2", "D1"))
+	formula, err := f.GetCellFormula("Sheet 2", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet 2'!B2+'Sheet 2'!C2", formula)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "B1+Sheet1!B2"))
+	assert.NoError(t, f.MoveRange("Sheet1", "A1", "Sheet 2", "A10"))
+	formula, err = f.GetCellFormula("Sheet 2", "A10")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!B1+Sheet1!B2", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveRange.xlsx")))
+
+	// Test move shared formula cells
+	f = NewFile()
+	for r := 1; r <= 5; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r + 1}))
+	}
+	formulaType, ref := STCellFormulaTypeShared, "C1:C5"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.MoveRange("Sheet1", "A3:B3", "Sheet1", "A10"))
+	for cell, expected := range map[string]string{"C1": "A1+B1", "C3": "A10+B10", "C5": "A5+B5"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.NoError(t, f.MoveRange("Sheet1", "C4:C5", "Sheet1", "D4"))
+	for cell, expected := range map[string]string{"C1": "A1+B1", "D4": "A4+B4", "D5": "A5+B5"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+
+	// Test move range with partial merged cells and array formulas
+	assert.NoError(t, f.MergeCell("Sheet1", "F1", "G2"))
+	assert.Equal(t, ErrPartialMergedCells, f.MoveRange("Sheet1", "F1:F2", "Sheet1", "H1"))
+	assert.Equal(t, ErrPartialMergedCells, f.MoveRange("Sheet1", "A1", "Sheet1", "G2"))
+	formulaType, ref = STCellFormulaTypeArray, "H1:H2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "A1:A2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.Equal(t, ErrPartialArrayFormula, f.MoveRange("Sheet1", "H1", "Sheet1", "J1"))
+	assert.NoError(t, f.MoveRange("Sheet1", "H1:H2", "Sheet1", "J1"))
+	formula, err = f.GetCellFormula("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:A2", formula)
+	// Test move range with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.MoveRange("Sheet1", "A1:A2 B1:B2", "Sheet1", "C1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.MoveRange("Sheet1", "A:B", "Sheet1", "C1"))
+	assert.Equal(t, newCellNameToCoordinatesError("C", newInvalidCellNameError("C")), f.MoveRange("Sheet1", "A1", "Sheet1", "C"))
+	assert.Equal(t, ErrColumnNumber, f.MoveRange("Sheet1", "A1:B1", "Sheet1", "XFD1"))
+	assert.Equal(t, ErrMaxRows, f.MoveRange("Sheet1", "A1:A2", "Sheet1", "A1048576"))
+	assert.EqualError(t, f.MoveRange("SheetN", "A1", "Sheet1", "B1"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.MoveRange("Sheet1", "A1", "SheetN", "B1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.MoveRange("Sheet1", "A1", "Sheet1", "A1"))
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r * 10}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1+$A$1"))
+	formulaType, ref := STCellFormulaTypeShared, "C2:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "A2*B2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B3"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell: "B2", Author: "Excelize", Text: "Comment", Visible: true, Width: 200,
+		Fill: Fill{Color: []string{"DDEBF7"}},
+	}))
+	expectedComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	vml.Shape[0].Style = strings.ReplaceAll(vml.Shape[0].Style, "z-index:1", "z-index:5")
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://github.com/xuri/excelize", "External"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A3"
+	dv.SetSqrefDropList("$B$1:$B$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B3", []ConditionalFormatOptions{
+		{Type: "formula", Format: &style, Criteria: "=B1>A1"},
+	}))
+
+	// Test copy all of the range to another worksheet
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C3", "Sheet 2", "B2", nil))
+	for cell, expected := range map[string]string{"B2": "1", "C3": "20", "B4": "3", "A1": ""} {
+		val, err := f.GetCellValue("Sheet 2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{"D2": "B2+C2+$A$1", "D3": "B3*C3", "D4": "B4*C4"} {
+		formula, err := f.GetCellFormula("Sheet 2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test the source range has not been changed
+	formula, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "A3*B3", formula)
+	styleID, err := f.GetCellStyle("Sheet 2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	mergeCells, err := f.GetMergeCells("Sheet 2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B4:C4", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	comments, err := f.GetComments("Sheet 2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	// The height of the comment box depends on the default row height of the
+	// worksheet
+	copied := expectedComments[0]
+	copied.Cell, copied.Height = "C3", comments[0].Height
+	assert.Equal(t, copied, comments[0])
+	assert.Contains(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].Shape[0].Style, "z-index:5")
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedComments, comments)
+	link, target, err := f.GetCellHyperLink("Sheet 2", "B3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	dvs, err := f.GetDataValidations("Sheet 2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "B2:B4", dvs[0].Sqref)
+	assert.Equal(t, "$B$1:$B$3", dvs[0].Formula1)
+	cfs, err := f.GetConditionalFormats("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "C2>B2", cfs["C2:C4"][0].Criteria)
+	// Test the shared formula has been kept as shared formula
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, STCellFormulaTypeShared, ws.(*xlsxWorksheet).SheetData.Row[2].C[3].F.T)
+	assert.Equal(t, "D3:D4", ws.(*xlsxWorksheet).SheetData.Row[2].C[3].F.Ref)
+
+	// Test copy with transpose, the shared formulas will be expanded
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C3", "Sheet1", "E1", &CopyRangeOptions{Transpose: true}))
+	for cell, expected := range map[string]string{"E1": "1", "F1": "2", "G1": "3", "E2": "10", "F2": "20"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{"E3": "E1+E2+$A$1", "F3": "F1*F2", "G3": "G1*G2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "G1:G2", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	cfs, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E2>E1", cfs["E2:G2"][0].Criteria)
+
+	// Test copy formulas with transpose, the relative references will be transposed
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+$B$1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "SUM(A1:B3)+$A2+C$1+SUM(A:B)+SUM($1:$2)"))
+	assert.NoError(t, f.CopyRange("Sheet1", "C1", "Sheet1", "E3", &CopyRangeOptions{Transpose: true}))
+	assert.NoError(t, f.CopyRange("Sheet1", "D2", "Sheet1", "F5", &CopyRangeOptions{Transpose: true}))
+	for cell, expected := range map[string]string{"E3": "E1+$B$1", "F5": "SUM(E2:G3)+$A2+E$1+SUM(2:3)+SUM($1:$2)"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test copy formulas with transpose, the references out of the worksheet
+	assert.NoError(t, f.CopyRange("Sheet1", "C1", "Sheet1", "A1", &CopyRangeOptions{Transpose: true}))
+	formula, err = f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!+$B$1", formula)
+
+	// Test paste values, formats and formulas only
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, "text"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1+B1"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[3].V = "3"
+	style, err = f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:D1", "Sheet1", "A2", &CopyRangeOptions{Type: PasteValues}))
+	formula, err = f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	for cell, expected := range map[string]string{"A2": "1", "C2": "text", "D2": "3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:D1", "Sheet1", "A3", &CopyRangeOptions{Type: PasteFormats}))
+	styleID, err = f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	val, err := f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:D1", "Sheet1", "A4", &CopyRangeOptions{Type: PasteFormulas}))
+	formula, err = f.GetCellFormula("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "A4+B4", formula)
+	styleID, err = f.GetCellStyle("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+
+	// Test paste with skip blanks and arithmetic operations
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{2, nil, "text", 0}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{10, 20, 30, 40}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "A2+B2"))
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C1", "Sheet1", "A2", &CopyRangeOptions{Type: PasteValues, SkipBlanks: true}))
+	for cell, expected := range map[string]string{"A2": "2", "B2": "20", "C2": "text"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{10, 20, "text", 40}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E3", "A3+B3"))
+	for op, expected := range map[PasteOperation][]string{
+		PasteOperationAdd:      {"12", "20", "text", "40", "(A4+B4)+2"},
+		PasteOperationSubtract: {"8", "20", "text", "40", "(A4+B4)-2"},
+		PasteOperationMultiply: {"20", "0", "text", "0", "(A4+B4)*2"},
+		PasteOperationDivide:   {"5", "#DIV/0!", "text", "#DIV/0!", "(A4+B4)/2"},
+	} {
+		assert.NoError(t, f.CopyRange("Sheet1", "A3:E3", "Sheet1", "A4", nil))
+		assert.NoError(t, f.CopyRange("Sheet1", "A1:D1", "Sheet1", "A4", &CopyRangeOptions{Type: PasteValues, Operation: op}))
+		assert.NoError(t, f.CopyRange("Sheet1", "A1", "Sheet1", "E4", &CopyRangeOptions{Type: PasteValues, Operation: op}))
+		for i, cell := range []string{"A4", "B4", "C4", "D4"} {
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[i], val, cell)
+		}
+		formula, err := f.GetCellFormula("Sheet1", "E4")
+		assert.NoError(t, err)
+		assert.Equal(t, expected[4], formula)
+	}
+
+	// Test copy range with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "C1", &CopyRangeOptions{Type: PasteFormulas + 1}))
+	assert.Equal(t, ErrParameterInvalid, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "C1", &CopyRangeOptions{Operation: PasteOperationDivide + 1}))
+	assert.Equal(t, ErrParameterInvalid, f.CopyRange("Sheet1", "A1:B2 C3", "Sheet1", "C1", nil))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "A1048576", nil))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.CopyRange("SheetN", "A1:B2", "Sheet1", "C1", nil))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.CopyRange("Sheet1", "A1:B2", "SheetN", "C1", nil))
+	assert.EqualError(t, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "C", nil), newCellNameToCoordinatesError("C", newInvalidCellNameError("C")).Error())
+	// Test copy range into part of merged cells and array formula
+	assert.NoError(t, f.MergeCell("Sheet1", "F1", "G1"))
+	assert.Equal(t, ErrPartialMergedCells, f.CopyRange("Sheet1", "A1", "Sheet1", "G1", nil))
+	formulaType, ref = STCellFormulaTypeArray, "F5:G5"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F5", "A5:B5", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.Equal(t, ErrPartialArrayFormula, f.CopyRange("Sheet1", "A1", "Sheet1", "G5", nil))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyRange.xlsx")))
+}
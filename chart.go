@@ -1171,14 +1171,7 @@ func (f *File) DeleteChart(sheet, cell string) error {
 // countCharts provides a function to get chart files count storage in the
 // folder xl/charts.
 func (f *File) countCharts() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
-			count++
-		}
-		return true
-	})
-	return count
+	return len(f.getPartNames("xl/charts/chart"))
 }
 
 // ptToEMUs provides a function to convert pt to EMUs, 1 pt = 12700 EMUs. The
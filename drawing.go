@@ -1279,7 +1279,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 			Xdr: NameSpaceDrawingMLSpreadSheet.Value,
 			A:   NameSpaceDrawingML.Value,
 		}
-		if _, ok = f.Pkg.Load(path); ok { // Append Model
+		if _, ok = f.loadPkg(path); ok { // Append Model
 			decodeWsDr := decodeWsDr{}
 			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
 				Decode(&decodeWsDr); err != nil && err != io.EOF {
@@ -1304,6 +1304,10 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 					GraphicFrame: v.Content,
 				})
 			}
+			if offset := getDrawingMaxObjectID(&content) - len(content.OneCellAnchor) - len(content.TwoCellAnchor) - 1; offset > 0 {
+				// Skip the object IDs used by the objects within the group shapes.
+				content.objectIDOffset = offset
+			}
 		}
 		f.Drawings.Store(path, &content)
 	}
@@ -1313,7 +1317,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 	}
 	wsDr.mu.Lock()
 	defer wsDr.mu.Unlock()
-	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2, nil
+	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2 + wsDr.objectIDOffset, nil
 }
 
 // addDrawingChart provides a function to add chart graphic frame by given
@@ -51,10 +51,19 @@ var (
 	// ErrDefinedNameScope defined the error message on not found defined name
 	// in the given scope.
 	ErrDefinedNameScope = errors.New("no defined name on the scope")
+	// ErrExistsComment defined the error message on given cell already has a
+	// comment.
+	ErrExistsComment = errors.New("the comment already exists in the cell")
 	// ErrExistsSheet defined the error message on given sheet already exists.
 	ErrExistsSheet = errors.New("the same name sheet already exists")
 	// ErrExistsTableName defined the error message on given table already exists.
 	ErrExistsTableName = errors.New("the same name table already exists")
+	// ErrExistsTableStyleName defined the error message on given table style
+	// already exists.
+	ErrExistsTableStyleName = errors.New("the same name table style already exists")
+	// ErrExistsThreadedComment defined the error message on given cell
+	// already has a threaded comment.
+	ErrExistsThreadedComment = errors.New("the threaded comment already exists in the cell")
 	// ErrFontLength defined the error message on the length of the font
 	// family name overflow.
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
@@ -82,6 +91,9 @@ var (
 	// ErrNameLength defined the error message on receiving the defined name or
 	// table name length exceeds the limit.
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
+	// ErrOptionsCompressionLevel defined the error message for receiving
+	// invalid CompressionLevel.
+	ErrOptionsCompressionLevel = errors.New("the value of CompressionLevel should be between -2 and 9")
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
@@ -94,6 +106,12 @@ var (
 	// ErrParameterRequired defined the error message on receive the empty
 	// parameter.
 	ErrParameterRequired = errors.New("parameter is required")
+	// ErrPartialArrayFormula defined the error message on changing part of an
+	// array formula.
+	ErrPartialArrayFormula = errors.New("cannot change part of an array formula")
+	// ErrPartialMergedCells defined the error message on changing part of
+	// merged cells.
+	ErrPartialMergedCells = errors.New("cannot change part of merged cells")
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
@@ -114,6 +132,9 @@ var (
 	// ErrSheetNameSingleQuote defined the error message on the first or last
 	// character of the sheet name was a single quote.
 	ErrSheetNameSingleQuote = errors.New("the first or last character of the sheet name can not be a single quote")
+	// ErrSortRange defined the error message on sorting a range which contains
+	// merged cells or array formulas across multiple rows.
+	ErrSortRange = errors.New("cannot sort a range which contains merged cells or array formulas across multiple rows")
 	// ErrSparkline defined the error message on receive the invalid sparkline
 	// parameters.
 	ErrSparkline = errors.New("must have the same number of 'Location' and 'Range' parameters")
@@ -135,6 +156,15 @@ var (
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrStreamSheetData defined the error message on the worksheet has no
+	// sheet data element in stream appending mode.
+	ErrStreamSheetData = errors.New("the worksheet has no sheetData element")
+	// ErrTableRange defined the error message on receiving the table range
+	// which not contains any data row.
+	ErrTableRange = errors.New("the table range should contain at least one data row")
+	// ErrTableRangeOverlap defined the error message on receiving the table
+	// range which overlaps with another table or auto filter.
+	ErrTableRangeOverlap = errors.New("the table range overlaps with another table or auto filter")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
@@ -258,18 +288,66 @@ func newInvalidSlicerNameError(name string) error {
 	return fmt.Errorf("invalid slicer name %q", name)
 }
 
+// newInvalidSortKeyColumnError defined the error message on receiving the
+// sort key column which is not in the sort range.
+func newInvalidSortKeyColumnError(col string) error {
+	return fmt.Errorf("the sort key column %q is out of the sort range", col)
+}
+
 // newInvalidStyleID defined the error message on receiving the invalid style
 // ID.
 func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newInvalidTimelineFieldError defined the error message on receiving the
+// timeline field name which not contains date values.
+func newInvalidTimelineFieldError(name string) error {
+	return fmt.Errorf("the field %q of the timeline is not a date field", name)
+}
+
+// newNoExistCommentError defined the error message on receiving the cell
+// reference without comment.
+func newNoExistCommentError(cell string) error {
+	return fmt.Errorf("comment in cell %s does not exist", cell)
+}
+
+// newNoExistShapeError defined the error message on receiving the non existing
+// shape name or cell reference.
+func newNoExistShapeError(shape string) error {
+	return fmt.Errorf("shape %s does not exist", shape)
+}
+
+// newNoExistSlicerError defined the error message on receiving the non existing
+// slicer name.
+func newNoExistSlicerError(name string) error {
+	return fmt.Errorf("slicer %s does not exist", name)
+}
+
+// newNoExistSlicerItemError defined the error message on receiving the non
+// existing slicer item.
+func newNoExistSlicerItemError(item string) error {
+	return fmt.Errorf("slicer item %q does not exist", item)
+}
+
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
 	return fmt.Errorf("table %s does not exist", name)
 }
 
+// newNoExistTableStyleError defined the error message on receiving the non
+// existing table style name.
+func newNoExistTableStyleError(name string) error {
+	return fmt.Errorf("table style %s does not exist", name)
+}
+
+// newNoExistThreadedCommentError defined the error message on receiving the
+// cell reference which doesn't contain any threaded comment.
+func newNoExistThreadedCommentError(cell string) error {
+	return fmt.Errorf("threaded comment in cell %s does not exist", cell)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
@@ -294,6 +372,12 @@ func newStreamSetRowError(row int) error {
 	return fmt.Errorf("row %d has already been written", row)
 }
 
+// newTableColumnReferencedError defined the error message on removing the
+// table column which is referenced by formulas.
+func newTableColumnReferencedError(table, column string) error {
+	return fmt.Errorf("the column %q of the table %q is referenced by formulas", column, table)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
@@ -39,6 +39,9 @@ type File struct {
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
+	zipFiles         sync.Map
+	zipFilesMu       sync.Mutex
+	zipSource        *os.File
 	CalcChain        *xlsxCalcChain
 	CharsetReader    charsetTranscoderFn
 	Comments         map[string]*xlsxComments
@@ -97,16 +100,60 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// CompressionLevel specifies the deflate compression level of the parts on
+// save the spreadsheet, the values are the same as the compression levels in
+// the compress/flate package: flate.NoCompression (0), flate.BestSpeed (1)
+// through flate.BestCompression (9), flate.DefaultCompression (-1) and
+// flate.HuffmanOnly (-2). The default compressor of the archive/zip package
+// will be used if the value is nil.
+//
+// StreamingSave specifies if save the spreadsheet in streaming mode, which
+// writes the worksheets and shared string table to the zip writer directly
+// without marshaling them into memory buffers, and copies the temporary files
+// of the stream writer and unzipped worksheets from the disk. The ZIP64 format
+// will be used when the output size is over 4GB. Note that the spreadsheet
+// with password protection will still be saved with memory buffers.
+//
+// LoadSheets specifies the names of the worksheets to be loaded on open the
+// spreadsheet, the other worksheets will be kept in compressed form and be
+// loaded when accessing them, all worksheets will be loaded by default.
+//
+// DeferSharedStrings specifies if defer loading the shared string table until
+// it is referenced.
+//
+// SkipDrawings specifies if skip loading the drawings, charts and media parts
+// on open the spreadsheet, these parts will be loaded when requested.
+//
+// SkipPivotTables specifies if skip loading the pivot table and pivot cache
+// parts on open the spreadsheet, these parts will be loaded when requested.
+//
+// The parts which not be loaded will be copied to the saved spreadsheet
+// without decompressing. Note that the LoadSheets, DeferSharedStrings,
+// SkipDrawings and SkipPivotTables options are best-effort, the deferred parts
+// will be unzipped into memory once they are referenced by any function, for
+// example, getting the cell values of a worksheet references the shared
+// string table, and adding a picture references the existing media for
+// reusing the same image. The spreadsheet file opened by the OpenFile function
+// will be kept open until closing the workbook if any part is deferred, and
+// the reader given to the OpenReader function which implements the
+// io.ReaderAt interface should be kept available until closing the workbook.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations  uint
+	Password           string
+	RawCellValue       bool
+	UnzipSizeLimit     int64
+	UnzipXMLSizeLimit  int64
+	ShortDatePattern   string
+	LongDatePattern    string
+	LongTimePattern    string
+	CultureInfo        CultureName
+	CompressionLevel   *int
+	StreamingSave      bool
+	LoadSheets         []string
+	DeferSharedStrings bool
+	SkipDrawings       bool
+	SkipPivotTables    bool
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -129,6 +176,11 @@ func OpenFile(filename string, opts ...Options) (*File, error) {
 		return f, err
 	}
 	f.Path = filename
+	if f.hasZipFiles() {
+		// keep the file open for reading the deferred parts
+		f.zipSource = file
+		return f, err
+	}
 	return f, file.Close()
 }
 
@@ -173,9 +225,12 @@ func (f *File) checkOpenReaderOptions() error {
 }
 
 // OpenReader read data stream from io.Reader and return a populated
-// spreadsheet file.
+// spreadsheet file. The archive will be read through the reader directly
+// without reading the whole data stream into memory if the reader implements
+// the io.ReaderAt interface with a known size, such as *os.File and
+// *bytes.Reader.
 func OpenReader(r io.Reader, opts ...Options) (*File, error) {
-	b, err := io.ReadAll(r)
+	ra, size, err := getReaderAt(r)
 	if err != nil {
 		return nil, err
 	}
@@ -184,12 +239,18 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if err = f.checkOpenReaderOptions(); err != nil {
 		return nil, err
 	}
-	if bytes.Contains(b, oleIdentifier) {
+	header := make([]byte, len(oleIdentifier))
+	if n, _ := ra.ReadAt(header, 0); n == len(header) && bytes.Equal(header, oleIdentifier) {
+		b, err := io.ReadAll(io.NewSectionReader(ra, 0, size))
+		if err != nil {
+			return nil, err
+		}
 		if b, err = Decrypt(b, f.options); err != nil {
 			return nil, ErrWorkbookFileFormat
 		}
+		ra, size = bytes.NewReader(b), int64(len(b))
 	}
-	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	zr, err := zip.NewReader(ra, size)
 	if err != nil {
 		if len(f.options.Password) > 0 {
 			return nil, ErrWorkbookPassword
@@ -210,6 +271,13 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if f.sheetMap, err = f.getSheetMap(); err != nil {
 		return f, err
 	}
+	for _, sheet := range f.options.LoadSheets {
+		sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+		if !ok {
+			return f, ErrSheetNotExist{sheet}
+		}
+		f.loadZipFile(sheetXMLPath)
+	}
 	if f.Styles, err = f.stylesReader(); err != nil {
 		return f, err
 	}
@@ -217,6 +285,25 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	return f, err
 }
 
+// getReaderAt provides a function to get the io.ReaderAt and size of the data
+// stream by given reader, the data stream will be read into memory if the
+// reader doesn't implement the io.ReaderAt interface with a known size.
+func getReaderAt(r io.Reader) (io.ReaderAt, int64, error) {
+	switch reader := r.(type) {
+	case interface {
+		io.ReaderAt
+		Size() int64
+	}:
+		return reader, reader.Size(), nil
+	case *os.File:
+		if fi, err := reader.Stat(); err == nil && fi.Mode().IsRegular() {
+			return reader, fi.Size(), nil
+		}
+	}
+	b, err := io.ReadAll(r)
+	return bytes.NewReader(b), int64(len(b)), err
+}
+
 // getOptions provides a function to parse the optional settings for open
 // and reading spreadsheet.
 func (f *File) getOptions(opts ...Options) *Options {
@@ -612,6 +699,17 @@ func (f *File) richValueReader() (*xlsxRichValueData, error) {
 	return &richValue, nil
 }
 
+// richValueStructureReader provides a function to get the pointer to the
+// structure after deserialization of xl/richData/rdrichvaluestructure.xml.
+func (f *File) richValueStructureReader() (*xlsxRichValueStructures, error) {
+	var richValueStructures xlsxRichValueStructures
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLRdRichValueStructurePart)))).
+		Decode(&richValueStructures); err != nil && err != io.EOF {
+		return &richValueStructures, err
+	}
+	return &richValueStructures, nil
+}
+
 // richValueRelReader provides a function to get the pointer to the structure
 // after deserialization of xl/richData/richValueRel.xml.
 func (f *File) richValueRelReader() (*xlsxRichValueRels, error) {
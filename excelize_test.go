@@ -353,6 +353,164 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenReaderSelectiveLoading(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Month", "Region", "Sales"}))
+	for row := 2; row < 6; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"Jan", "East", row * 100}))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Sheet2"))
+	assert.NoError(t, f.AddPicture("Sheet1", "E1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "E10", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$C$2:$C$5"}},
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C5",
+		PivotTableRange: "Sheet2!C2:E10",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Columns:         []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderSelectiveLoading.xlsx")))
+	assert.NoError(t, f.Close())
+
+	opts := Options{
+		LoadSheets:         []string{"Sheet2"},
+		DeferSharedStrings: true,
+		SkipDrawings:       true,
+		SkipPivotTables:    true,
+	}
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading.xlsx"), opts)
+	assert.NoError(t, err)
+	for _, name := range []string{
+		"xl/worksheets/sheet1.xml",
+		defaultXMLPathSharedStrings,
+		"xl/drawings/drawing1.xml",
+		"xl/charts/chart1.xml",
+		"xl/media/image1.png",
+		"xl/pivotTables/pivotTable1.xml",
+		"xl/pivotCache/pivotCacheDefinition1.xml",
+	} {
+		_, ok := f.Pkg.Load(name)
+		assert.False(t, ok, name)
+		_, ok = f.zipFiles.Load(name)
+		assert.True(t, ok, name)
+	}
+	_, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	// Test get cell value, pictures and pivot tables from the deferred parts
+	val, err := f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "East", val)
+	pics, err := f.GetPictures("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	pivotTables, err := f.GetPivotTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderSelectiveLoading2.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add chart on the workbook opened without loading drawings
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading2.xlsx"), opts)
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChart("Sheet2", "G1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$C$2:$C$5"}},
+	}))
+	// Test the deferred parts are counted without decompressing
+	for _, name := range []string{"xl/drawings/drawing1.xml", "xl/charts/chart1.xml"} {
+		_, ok = f.zipFiles.Load(name)
+		assert.True(t, ok, name)
+	}
+	assert.NotNil(t, f.zipSource)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderSelectiveLoading3.xlsx")))
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.zipSource)
+
+	zr, err := zip.OpenReader(filepath.Join("test", "TestOpenReaderSelectiveLoading3.xlsx"))
+	assert.NoError(t, err)
+	parts := map[string]int{}
+	for _, item := range zr.File {
+		parts[item.Name]++
+	}
+	assert.NoError(t, zr.Close())
+	for _, name := range []string{
+		"xl/charts/chart1.xml",
+		"xl/charts/chart2.xml",
+		"xl/drawings/drawing1.xml",
+		"xl/drawings/drawing2.xml",
+		"xl/media/image1.png",
+		"xl/pivotTables/pivotTable1.xml",
+	} {
+		assert.Equal(t, 1, parts[name], name)
+	}
+
+	// Test reopen the workbook and check the data was preserved
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading3.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"Sheet1!A1": "Month", "Sheet1!C5": "500", "Sheet2!A1": "Sheet2"} {
+		ref := strings.Split(cell, "!")
+		val, err = f.GetCellValue(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	pics, err = f.GetPictures("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.NoError(t, f.Close())
+
+	// Test load deferred worksheet and shared string table over the XML size limit
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading.xlsx"), Options{
+		LoadSheets: []string{"Sheet2"}, DeferSharedStrings: true, UnzipXMLSizeLimit: 10,
+	})
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Jan", val)
+	_, ok = f.tempFiles.Load(defaultXMLPathSharedStrings)
+	assert.True(t, ok)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 5)
+	assert.NoError(t, f.Close())
+
+	// Test open workbook with not exist sheet to be loaded
+	_, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading.xlsx"), Options{LoadSheets: []string{"SheetN"}})
+	assert.EqualError(t, err, ErrSheetNotExist{"SheetN"}.Error())
+
+	// Test save the workbook with deferred parts to the opened file
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading3.xlsx"), opts)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A2", "Updated"))
+	assert.NoError(t, f.Save())
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderSelectiveLoading3.xlsx"))
+	assert.NoError(t, err)
+	assert.Nil(t, f.zipSource)
+	for cell, expected := range map[string]string{"Sheet1!C5": "500", "Sheet2!A2": "Updated"} {
+		ref := strings.Split(cell, "!")
+		val, err = f.GetCellValue(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test open workbook from the reader without io.ReaderAt implementation
+	file, err := os.ReadFile(filepath.Join("test", "TestOpenReaderSelectiveLoading.xlsx"))
+	assert.NoError(t, err)
+	f, err = OpenReader(struct{ io.Reader }{bytes.NewReader(file)}, opts)
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "East", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
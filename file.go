@@ -14,6 +14,7 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
 	"io"
 	"os"
@@ -75,6 +76,7 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
 		return ErrWorkbookFileFormat
 	}
+	f.loadZipFilesBeforeOverwrite(name)
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
 	if err != nil {
 		return err
@@ -100,6 +102,12 @@ func (f *File) Close() error {
 	for _, stream := range f.streams {
 		_ = stream.rawData.Close()
 	}
+	if f.zipSource != nil {
+		if closeErr := f.zipSource.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		f.zipSource = nil
+	}
 	return err
 }
 
@@ -141,6 +149,9 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
+	if err := f.setZipCompressor(zw); err != nil {
+		return buf, err
+	}
 
 	if err := f.writeToZip(zw); err != nil {
 		return buf, zw.Close()
@@ -164,6 +175,9 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
 	zw := zip.NewWriter(w)
+	if err := f.setZipCompressor(zw); err != nil {
+		return err
+	}
 	if err := f.writeToZip(zw); err != nil {
 		_ = zw.Close()
 		return err
@@ -171,8 +185,25 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 	return zw.Close()
 }
 
+// setZipCompressor provides a function to register the deflate compressor
+// with the compression level specified in options for the zip writer.
+func (f *File) setZipCompressor(zw *zip.Writer) error {
+	if f.options == nil || f.options.CompressionLevel == nil {
+		return nil
+	}
+	level := *f.options.CompressionLevel
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return ErrOptionsCompressionLevel
+	}
+	zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(w, level)
+	})
+	return nil
+}
+
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
+	streaming := f.options != nil && f.options.StreamingSave && f.options.Password == ""
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -180,18 +211,42 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.volatileDepsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
+	if !streaming {
+		f.workSheetWriter()
+	}
 	f.relsWriter()
-	_ = f.sharedStringsLoader()
-	f.sharedStringsWriter()
+	if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); !ok || !streaming {
+		_ = f.sharedStringsLoader()
+	}
+	if !streaming {
+		f.sharedStringsWriter()
+	}
 	f.styleSheetWriter()
 	f.themeWriter()
 
-	for path, stream := range f.streams {
+	written := make(map[string]struct{})
+	if streaming {
+		if err := f.writeWorkSheetsToZip(zw, written); err != nil {
+			return err
+		}
+		if err := f.writeSharedStringsToZip(zw, written); err != nil {
+			return err
+		}
+	}
+	var (
+		err                       error
+		streams, files, tempFiles []string
+	)
+	for path := range f.streams {
+		streams = append(streams, path)
+	}
+	sort.Strings(streams)
+	for _, path := range streams {
 		fi, err := zw.Create(path)
 		if err != nil {
 			return err
 		}
+		stream := f.streams[path]
 		var from io.Reader
 		if from, err = stream.rawData.Reader(); err != nil {
 			_ = stream.rawData.Close()
@@ -200,13 +255,10 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		if _, err = io.Copy(fi, from); err != nil {
 			return err
 		}
+		written[path] = struct{}{}
 	}
-	var (
-		err              error
-		files, tempFiles []string
-	)
 	f.Pkg.Range(func(path, content interface{}) bool {
-		if _, ok := f.streams[path.(string)]; ok {
+		if _, ok := written[path.(string)]; ok {
 			return true
 		}
 		files = append(files, path.(string))
@@ -225,6 +277,9 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		if _, ok := f.Pkg.Load(path); ok {
 			return true
 		}
+		if _, ok := written[path.(string)]; ok || path.(string) == defaultTempFileSST {
+			return true
+		}
 		tempFiles = append(tempFiles, path.(string))
 		return true
 	})
@@ -234,7 +289,139 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		if fi, err = zw.Create(path); err != nil {
 			break
 		}
+		if streaming {
+			if err = f.copyTempFile(fi, path); err != nil {
+				break
+			}
+			continue
+		}
 		_, err = fi.Write(f.readBytes(path))
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return f.writeZipFilesToZip(zw, written)
+}
+
+// writeZipFilesToZip provides a function to copy the compressed data of the
+// parts which kept in compressed form on open the spreadsheet to the zip
+// writer.
+func (f *File) writeZipFilesToZip(zw *zip.Writer, written map[string]struct{}) error {
+	var zipFiles []string
+	f.zipFiles.Range(func(path, zipFile interface{}) bool {
+		if _, ok := written[path.(string)]; ok {
+			return true
+		}
+		if _, ok := f.Pkg.Load(path); ok {
+			return true
+		}
+		if _, ok := f.tempFiles.Load(path); ok {
+			return true
+		}
+		zipFiles = append(zipFiles, path.(string))
+		return true
+	})
+	sort.Sort(sort.Reverse(sort.StringSlice(zipFiles)))
+	for _, path := range zipFiles {
+		zipFile, ok := f.zipFiles.Load(path)
+		if !ok {
+			continue
+		}
+		header := zipFile.(*zip.File).FileHeader
+		header.Name = path
+		fi, err := zw.CreateRaw(&header)
+		if err != nil {
+			return err
+		}
+		r, err := zipFile.(*zip.File).OpenRaw()
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(fi, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeWorkSheetsToZip provides a function to write the worksheets in memory
+// to the zip writer directly in streaming save mode.
+func (f *File) writeWorkSheetsToZip(zw *zip.Writer, written map[string]struct{}) error {
+	var paths []string
+	f.Sheet.Range(func(p, ws interface{}) bool {
+		if ws != nil {
+			paths = append(paths, p.(string))
+		}
+		return true
+	})
+	sort.Strings(paths)
+	for _, path := range paths {
+		ws, _ := f.Sheet.Load(path)
+		sheet := ws.(*xlsxWorksheet)
+		f.prepareWorkSheet(path, sheet)
+		// stream the worksheet into the zip entry directly, the worksheet
+		// keeps loaded in memory and will be serialized again on demand
+		if err := f.writePartToZip(zw, path, sheet, [2][]byte{
+			[]byte(`xmlns:relationships="http://schemas.openxmlformats.org/officeDocument/2006/relationships" relationships`),
+			[]byte("r"),
+		}); err != nil {
+			return err
+		}
+		written[path] = struct{}{}
+	}
+	return nil
+}
+
+// writeSharedStringsToZip provides a function to write the shared string
+// table in memory to the zip writer directly in streaming save mode.
+func (f *File) writeSharedStringsToZip(zw *zip.Writer, written map[string]struct{}) error {
+	if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok || f.SharedStrings == nil {
+		return nil
+	}
+	written[defaultXMLPathSharedStrings] = struct{}{}
+	return f.writePartToZip(zw, defaultXMLPathSharedStrings, f.SharedStrings)
+}
+
+// writePartToZip provides a function to encode the given part to the zip
+// writer, the XML root element attribute and given pairs of the source and
+// target sequences of bytes will be replaced in the encoded data.
+func (f *File) writePartToZip(zw *zip.Writer, path string, v interface{}, replaces ...[2][]byte) error {
+	fi, err := zw.Create(path)
+	if err != nil {
+		return err
+	}
+	return f.writePart(fi, path, v, replaces...)
+}
+
+// writePart provides a function to encode the given part by given part path
+// to the writer, the XML root element attribute and given pairs of the source
+// and target sequences of bytes will be replaced in the encoded data.
+func (f *File) writePart(w io.Writer, path string, v interface{}, replaces ...[2][]byte) error {
+	var err error
+	sourceXmlns, targetXmlns := f.getNameSpaceBytes(path)
+	rw := &bytesReplaceWriter{w: w, source: [][]byte{sourceXmlns}, target: [][]byte{targetXmlns}}
+	for _, replace := range replaces {
+		rw.source, rw.target = append(rw.source, replace[0]), append(rw.target, replace[1])
+	}
+	if _, err = rw.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	if err = xml.NewEncoder(rw).Encode(v); err != nil {
+		return err
+	}
+	return rw.Flush()
+}
+
+// copyTempFile provides a function to copy the system temporary file by given
+// path in the zip to the writer.
+func (f *File) copyTempFile(w io.Writer, name string) error {
+	file, err := f.readTemp(name)
+	if err != nil || file == nil {
+		return err
+	}
+	if _, err = io.Copy(w, file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
 }
@@ -3,6 +3,9 @@ package excelize
 import (
 	"bufio"
 	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -95,3 +98,115 @@ func TestClose(t *testing.T) {
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestStreamingSave(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Hello", 1, true}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	for row := 1; row <= 100; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row, strings.Repeat("s", row)}))
+	}
+	assert.NoError(t, sw.Flush())
+	expected := map[string][][]string{}
+	for _, sheet := range f.GetSheetList() {
+		expected[sheet], err = f.GetRows(sheet)
+		assert.NoError(t, err)
+	}
+	checkRows := func(path string) {
+		f, err := OpenFile(path)
+		assert.NoError(t, err)
+		for sheet, rows := range expected {
+			result, err := f.GetRows(sheet)
+			assert.NoError(t, err)
+			assert.Equal(t, rows, result)
+		}
+		link, target, err := f.GetCellHyperLink("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/xuri/excelize", target)
+		assert.NoError(t, f.Close())
+	}
+	sizes := map[int]int64{}
+	for _, level := range []int{flate.NoCompression, flate.DefaultCompression, flate.HuffmanOnly, flate.BestSpeed, flate.BestCompression} {
+		path := filepath.Join("test", fmt.Sprintf("TestStreamingSave%d.xlsx", level))
+		assert.NoError(t, f.SaveAs(path, Options{StreamingSave: true, CompressionLevel: intPtr(level)}))
+		checkRows(path)
+		fi, err := os.Stat(path)
+		assert.NoError(t, err)
+		sizes[level] = fi.Size()
+	}
+	assert.Greater(t, sizes[flate.NoCompression], sizes[flate.BestCompression])
+	assert.Greater(t, sizes[flate.NoCompression], sizes[flate.DefaultCompression])
+	// Test streaming save after modified the worksheet again
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 2))
+	expected["Sheet1"][0][1] = "2"
+	path := filepath.Join("test", "TestStreamingSave.xlsx")
+	content, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(path, Options{StreamingSave: true}))
+	checkRows(path)
+	// Test the worksheet not be buffered into the package by streaming save,
+	// and keeps loaded in memory
+	data, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, content, data)
+	_, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	cellValue, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", cellValue)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected["Sheet1"], rows)
+	// Test save the worksheet which has been saved in streaming mode
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamingSaveAgain.xlsx")))
+	checkRows(filepath.Join("test", "TestStreamingSaveAgain.xlsx"))
+	// Test streaming save with invalid compression level
+	for _, level := range []int{-3, 10} {
+		assert.Equal(t, ErrOptionsCompressionLevel, f.SaveAs(filepath.Join("test", "TestStreamingSave3.xlsx"), Options{StreamingSave: true, CompressionLevel: intPtr(level)}))
+		_, err = f.WriteToBuffer()
+		assert.Equal(t, ErrOptionsCompressionLevel, err)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test streaming save with the worksheet and shared string table in
+	// temporary files
+	f, err = OpenFile(path, Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	_, ok = f.tempFiles.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	_, ok = f.tempFiles.Load(defaultXMLPathSharedStrings)
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamingSave2.xlsx"), Options{StreamingSave: true}))
+	_, ok = f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+	checkRows(filepath.Join("test", "TestStreamingSave2.xlsx"))
+
+	// Test streaming save with invalid worksheet path
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	const maxUint16 = 1<<16 - 1
+	f.Sheet.Store(strings.Repeat("s", maxUint16+1), ws)
+	assert.EqualError(t, f.Write(io.Discard, Options{StreamingSave: true}), "zip: FileHeader.Name too long")
+	f.Sheet.Delete(strings.Repeat("s", maxUint16+1))
+	// Test streaming save with invalid shared string table path
+	f.SharedStrings = &xlsxSST{}
+	f.Pkg.Store(strings.Repeat("s", maxUint16+1), nil)
+	f.tempFiles.Store(strings.Repeat("t", maxUint16+1), "")
+	assert.EqualError(t, f.Write(io.Discard, Options{StreamingSave: true}), "zip: FileHeader.Name too long")
+	f.Pkg.Delete(strings.Repeat("s", maxUint16+1))
+	f.tempFiles.Delete(strings.Repeat("t", maxUint16+1))
+	// Test streaming save with not exists temporary file
+	f.tempFiles.Store("s", filepath.Join("test", "NotExists"))
+	assert.Error(t, f.Write(io.Discard, Options{StreamingSave: true}))
+	f.tempFiles.Delete("s")
+	assert.NoError(t, f.Close())
+}
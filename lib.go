@@ -21,9 +21,11 @@ import (
 	"math"
 	"math/big"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // ReadZipReader extract spreadsheet with given options.
@@ -48,6 +50,13 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
+		if f.isDeferredPart(fileName) && !v.FileInfo().IsDir() {
+			if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
+				worksheets++
+			}
+			f.zipFiles.Store(fileName, v)
+			continue
+		}
 		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && fileSize > f.options.UnzipXMLSizeLimit {
 			tempFile, err := f.unzipToTemp(v)
 			if tempFile != "" {
@@ -96,9 +105,139 @@ func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
 	return tmp.Name(), tmp.Close()
 }
 
+// isDeferredPart provides a function to check if the part should be kept in
+// compressed form on open the spreadsheet by given path in the zip.
+func (f *File) isDeferredPart(name string) bool {
+	if strings.Contains(name, "/_rels/") {
+		return false
+	}
+	lowerName := strings.ToLower(name)
+	if len(f.options.LoadSheets) > 0 && strings.HasPrefix(lowerName, "xl/worksheets/sheet") {
+		return true
+	}
+	if f.options.DeferSharedStrings && name == defaultXMLPathSharedStrings {
+		return true
+	}
+	if f.options.SkipDrawings {
+		for _, prefix := range []string{"xl/drawings/drawing", "xl/charts/", "xl/media/"} {
+			if strings.HasPrefix(lowerName, prefix) {
+				return true
+			}
+		}
+	}
+	if f.options.SkipPivotTables {
+		for _, prefix := range []string{"xl/pivottables/", "xl/pivotcache/"} {
+			if strings.HasPrefix(lowerName, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadZipFile provides a function to unzip the part which kept in compressed
+// form on open the spreadsheet by given path in the zip. The worksheet and
+// shared string table will be extracted to system temporary directory when
+// the file size is over the UnzipXMLSizeLimit.
+func (f *File) loadZipFile(name string) {
+	if _, ok := f.zipFiles.Load(name); !ok {
+		return
+	}
+	f.zipFilesMu.Lock()
+	defer f.zipFilesMu.Unlock()
+	zipFile, ok := f.zipFiles.Load(name)
+	if !ok {
+		return
+	}
+	defer f.zipFiles.Delete(name)
+	if _, ok = f.Pkg.Load(name); ok {
+		return
+	}
+	if (name == defaultXMLPathSharedStrings || strings.HasPrefix(strings.ToLower(name), "xl/worksheets/sheet")) &&
+		zipFile.(*zip.File).FileInfo().Size() > f.options.UnzipXMLSizeLimit {
+		tempFile, err := f.unzipToTemp(zipFile.(*zip.File))
+		if tempFile != "" {
+			f.tempFiles.Store(name, tempFile)
+		}
+		if err == nil {
+			return
+		}
+	}
+	if content, err := readFile(zipFile.(*zip.File)); err == nil {
+		f.Pkg.Store(name, content)
+	}
+}
+
+// loadZipFiles provides a function to unzip all parts which kept in
+// compressed form on open the spreadsheet by given path prefix in the zip.
+func (f *File) loadZipFiles(prefix string) {
+	var names []string
+	f.zipFiles.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), prefix) {
+			names = append(names, k.(string))
+		}
+		return true
+	})
+	for _, name := range names {
+		f.loadZipFile(name)
+	}
+}
+
+// hasZipFiles provides a function to check if any part is kept in compressed
+// form on open the spreadsheet.
+func (f *File) hasZipFiles() bool {
+	var ok bool
+	f.zipFiles.Range(func(k, v interface{}) bool {
+		ok = true
+		return false
+	})
+	return ok
+}
+
+// loadZipFilesBeforeOverwrite provides a function to unzip all parts which
+// kept in compressed form into memory if the given file path is the opened
+// spreadsheet file, to avoid reading the deferred parts from the truncated
+// file on save.
+func (f *File) loadZipFilesBeforeOverwrite(name string) {
+	if f.zipSource == nil {
+		return
+	}
+	src, err := f.zipSource.Stat()
+	if err != nil {
+		return
+	}
+	if dst, err := os.Stat(filepath.Clean(name)); err == nil && os.SameFile(src, dst) {
+		f.loadZipFiles("")
+	}
+}
+
+// getPartNames provides a function to get the names of the parts which
+// contain the given path in the zip, including the parts which kept in
+// compressed form on open the spreadsheet without decompressing them.
+func (f *File) getPartNames(path string) map[string]struct{} {
+	names := map[string]struct{}{}
+	for _, parts := range []*sync.Map{&f.Pkg, &f.zipFiles} {
+		parts.Range(func(k, v interface{}) bool {
+			if strings.Contains(k.(string), path) {
+				names[k.(string)] = struct{}{}
+			}
+			return true
+		})
+	}
+	return names
+}
+
+// loadPkg provides a function to get the part content by given path in the
+// zip, the part which kept in compressed form on open the spreadsheet will be
+// unzipped at first.
+func (f *File) loadPkg(name string) (interface{}, bool) {
+	f.loadZipFile(name)
+	return f.Pkg.Load(name)
+}
+
 // readXML provides a function to read XML content as bytes.
 func (f *File) readXML(name string) []byte {
-	if content, _ := f.Pkg.Load(name); content != nil {
+	if content, _ := f.loadPkg(name); content != nil {
 		return content.([]byte)
 	}
 	if content, ok := f.streams[name]; ok {
@@ -694,12 +833,76 @@ func getXMLNamespace(space string, attr []xml.Attr) string {
 // replaceNameSpaceBytes provides a function to replace the XML root element
 // attribute by the given component part path and XML content.
 func (f *File) replaceNameSpaceBytes(path string, contentMarshal []byte) []byte {
+	sourceXmlns, targetXmlns := f.getNameSpaceBytes(path)
+	return bytesReplace(contentMarshal, sourceXmlns, targetXmlns, -1)
+}
+
+// getNameSpaceBytes provides a function to get the source and target XML root
+// element attribute by the given component part path.
+func (f *File) getNameSpaceBytes(path string) ([]byte, []byte) {
 	sourceXmlns := []byte(`xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
 	targetXmlns := []byte(templateNamespaceIDMap)
 	if attrs, ok := f.xmlAttr.Load(path); ok {
 		targetXmlns = []byte(genXMLNamespace(attrs.([]xml.Attr)))
 	}
-	return bytesReplace(contentMarshal, sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{}), -1)
+	return sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{})
+}
+
+// bytesReplaceWriter directly maps the writer which replaces all
+// non-overlapping instances of the source sequences of bytes with the target
+// sequences of bytes in the written data.
+type bytesReplaceWriter struct {
+	w              io.Writer
+	source, target [][]byte
+	buf            []byte
+	err            error
+}
+
+// Write writes the data to the underlying writer with replacement, the bytes
+// which could be a prefix of the source sequences will be kept in the buffer
+// until more data has been written or the writer has been flushed.
+func (rw *bytesReplaceWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	rw.buf = append(rw.buf, p...)
+	var keep int
+	for {
+		idx, n := -1, 0
+		for i, source := range rw.source {
+			if keep < len(source)-1 {
+				keep = len(source) - 1
+			}
+			if j := bytes.Index(rw.buf, source); j != -1 && (idx == -1 || j < idx) {
+				idx, n = j, i
+			}
+		}
+		if idx == -1 {
+			break
+		}
+		rw.write(rw.buf[:idx])
+		rw.write(rw.target[n])
+		rw.buf = rw.buf[idx+len(rw.source[n]):]
+	}
+	if len(rw.buf) > keep {
+		rw.write(rw.buf[:len(rw.buf)-keep])
+		rw.buf = append(rw.buf[:0], rw.buf[len(rw.buf)-keep:]...)
+	}
+	return len(p), rw.err
+}
+
+// write writes the data to the underlying writer and keeps the first error.
+func (rw *bytesReplaceWriter) write(p []byte) {
+	if rw.err == nil && len(p) > 0 {
+		_, rw.err = rw.w.Write(p)
+	}
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (rw *bytesReplaceWriter) Flush() error {
+	rw.write(rw.buf)
+	rw.buf = rw.buf[:0]
+	return rw.err
 }
 
 // addNameSpaces provides a function to add an XML attribute by the given
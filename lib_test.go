@@ -312,6 +312,33 @@ func TestGenXMLNamespace(t *testing.T) {
 	}), `xml:space="preserve">`)
 }
 
+func TestBytesReplaceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rw := &bytesReplaceWriter{
+		w:      &buf,
+		source: [][]byte{[]byte("abc"), []byte("cd")},
+		target: [][]byte{[]byte("x"), []byte("abc")},
+	}
+	for _, p := range []string{"1a", "bc2c", "d3ab", "", "c4a"} {
+		n, err := rw.Write([]byte(p))
+		assert.NoError(t, err)
+		assert.Equal(t, len(p), n)
+	}
+	assert.NoError(t, rw.Flush())
+	assert.Equal(t, "1x2abc3x4a", buf.String())
+	// Test write with the underlying writer error
+	f, err := os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	defer os.Remove(f.Name())
+	rw = &bytesReplaceWriter{w: f, source: [][]byte{[]byte("a")}, target: [][]byte{[]byte("b")}}
+	_, err = rw.Write([]byte("aaa"))
+	assert.Error(t, err)
+	_, err = rw.Write([]byte("aaa"))
+	assert.Error(t, err)
+	assert.Error(t, rw.Flush())
+}
+
 func TestBstrUnmarshal(t *testing.T) {
 	bstrs := map[string]string{
 		"*":                           "*",
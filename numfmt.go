@@ -4754,6 +4754,25 @@ func (f *File) langNumFmtFuncZhCN(numFmtID int) string {
 	return langNumFmt["zh-cn"][numFmtID]
 }
 
+// isDateTimeNumFmt provides a function to check if the given number format
+// code contains date and time tokens in the first section.
+func isDateTimeNumFmt(fmtCode string) bool {
+	if fmtCode == "" {
+		return false
+	}
+	p := nfp.NumberFormatParser()
+	sections := p.Parse(fmtCode)
+	if len(sections) == 0 {
+		return false
+	}
+	for _, token := range sections[0].Items {
+		if token.TType == nfp.TokenTypeDateTimes {
+			return true
+		}
+	}
+	return false
+}
+
 // getBuiltInNumFmtCode convert number format index to number format code with
 // specified locale and language.
 func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"image"
 	"io"
 	"os"
@@ -65,9 +66,9 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and file path, supported image types: BMP, EMF, EMZ, GIF, JPEG, JPG, PNG,
 // SVG, TIF, TIFF, WMF, and WMZ. This function is concurrency-safe. Note that
-// this function only supports adding pictures placed over the cells currently,
-// and doesn't support adding pictures placed in cells or creating the Kingsoft
-// WPS Office embedded image cells. For example:
+// this function only supports adding pictures placed over the cells, please
+// use the AddPictureFromBytes function to add pictures placed in cells. For
+// example:
 //
 //	package main
 //
@@ -169,6 +170,37 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
 // size with cells). If you don't set this parameter, the default positioning
 // is to move and size with cells.
+//
+// The optional parameter "Crop" specifies the percentages of the picture
+// trimmed from the left, top, right and bottom edges, each value should be
+// greater than or equal to 0 and less than 100.
+//
+// The optional parameter "Rotation" specifies the clockwise rotation angle of
+// the picture in degrees.
+//
+// The optional parameter "FlipHorizontal" and "FlipVertical" specifies if flip
+// the picture horizontally or vertically.
+//
+// The optional parameter "Border" specifies the border line of the picture,
+// the "Color" of the border is specified by hex color code, the default value
+// of that is black. The "Width" of the border is specified in points, and the
+// "Dash" specifies the dash type of the border line, the supported dash types
+// are: solid, dot, dash, lgDash, dashDot, lgDashDot, lgDashDotDot, sysDash,
+// sysDot, sysDashDot and sysDashDotDot.
+//
+// The optional parameter "Transparency" specifies the percentage of the
+// transparency of the picture, the value range is 0 to 100.
+//
+// The optional parameter "Brightness" and "Contrast" specifies the percentage
+// of brightness and contrast adjustment of the picture, the value range is -100
+// to 100.
+//
+// The optional parameter "Shadow" specifies the outer shadow of the picture,
+// the "Color" of the shadow is specified by hex color code, the default value
+// of that is black. The "Transparency" specifies the transparency percentage
+// of the shadow, the "Blur" and "Distance" specifies the blur radius and the
+// distance of the shadow in points, and the "Angle" specifies the direction of
+// the shadow in degrees.
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
 	var err error
 	// Check picture exists first.
@@ -186,10 +218,15 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 // AddPictureFromBytes provides the method to add picture in a sheet by given
 // picture format set (such as offset, scale, aspect ratio setting and print
 // settings), file base name, extension name and file bytes, supported image
-// types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. Note that
-// this function only supports adding pictures placed over the cells currently,
-// and doesn't support adding pictures placed in cells or creating the Kingsoft
-// WPS Office embedded image cells. For example:
+// types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. Set the
+// InsertType to PictureInsertTypePlaceInCell to add a picture placed in the
+// cell, which will be sorted and filtered with the rows. Set the InsertType to
+// PictureInsertTypeIMAGE to add an IMAGE formula function with the cached
+// image, the source URL of the image is specified by the Hyperlink field of
+// the picture format set, and the AltText field will be used as the
+// alternative text for both of these insert types. Note that this function
+// doesn't support creating the Kingsoft WPS Office embedded image cells. For
+// example:
 //
 //	package main
 //
@@ -221,6 +258,16 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	        fmt.Println(err)
 //	        return
 //	    }
+//	    // Insert a picture placed in the cell.
+//	    if err := f.AddPictureFromBytes("Sheet1", "B2", &excelize.Picture{
+//	        Extension:  ".jpg",
+//	        File:       file,
+//	        Format:     &excelize.GraphicOptions{AltText: "Excel Logo"},
+//	        InsertType: excelize.PictureInsertTypePlaceInCell,
+//	    }); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
 //	    if err := f.SaveAs("Book1.xlsx"); err != nil {
 //	        fmt.Println(err)
 //	    }
@@ -232,7 +279,11 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	if !ok {
 		return ErrImgExt
 	}
-	if pic.InsertType != PictureInsertTypePlaceOverCells {
+	switch pic.InsertType {
+	case PictureInsertTypePlaceOverCells:
+	case PictureInsertTypePlaceInCell, PictureInsertTypeIMAGE:
+		return f.addCellPicture(sheet, cell, ext, pic)
+	default:
 		return ErrParameterInvalid
 	}
 	options := parseGraphicOptions(pic.Format)
@@ -255,18 +306,7 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
 	mediaStr := ".." + strings.TrimPrefix(f.addMedia(pic.File, ext), "xl")
-	var drawingRID int
-	if rels, _ := f.relsReader(drawingRels); rels != nil {
-		for _, rel := range rels.Relationships {
-			if rel.Type == SourceRelationshipImage && rel.Target == mediaStr {
-				drawingRID, _ = strconv.Atoi(strings.TrimPrefix(rel.ID, "rId"))
-				break
-			}
-		}
-	}
-	if drawingRID == 0 {
-		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
-	}
+	drawingRID := f.addDrawingImageRels(drawingRels, mediaStr)
 	// Add picture with hyperlink.
 	if options.Hyperlink != "" && options.HyperlinkType != "" {
 		if options.HyperlinkType == "External" {
@@ -286,6 +326,22 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	return err
 }
 
+// addDrawingImageRels provides a function to add the image relationship of
+// the drawing part by given drawing relationships part path and the media
+// target, and returns the relationship ID. The existing relationship of the
+// same media will be reused.
+func (f *File) addDrawingImageRels(drawingRels, target string) int {
+	if rels, _ := f.relsReader(drawingRels); rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipImage && rel.Target == target {
+				rID, _ := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId"))
+				return rID
+			}
+		}
+	}
+	return f.addRels(drawingRels, SourceRelationshipImage, target, "")
+}
+
 // addSheetLegacyDrawing provides a function to add legacy drawing element to
 // xl/worksheets/sheet%d.xml by given worksheet name and relationship index.
 func (f *File) addSheetLegacyDrawing(sheet string, rID int) {
@@ -320,13 +376,7 @@ func (f *File) addSheetPicture(sheet string, rID int) error {
 // countDrawings provides a function to get drawing files count storage in the
 // folder xl/drawings.
 func (f *File) countDrawings() int {
-	drawings := map[string]struct{}{}
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/drawings/drawing") {
-			drawings[k.(string)] = struct{}{}
-		}
-		return true
-	})
+	drawings := f.getPartNames("xl/drawings/drawing")
 	f.Drawings.Range(func(rel, value interface{}) bool {
 		if strings.Contains(rel.(string), "xl/drawings/drawing") {
 			drawings[rel.(string)] = struct{}{}
@@ -375,6 +425,27 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	to.RowOff = y2 * EMU
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
+	pic, err := f.newPicture(cNvPrID, rID, hyperlinkRID, ext, opts)
+	if err != nil {
+		return err
+	}
+
+	twoCellAnchor.Pic = pic
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Locked,
+		FPrintsWithSheet: *opts.PrintObject,
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// newPicture provides a function to create a picture by given picture ID,
+// relationship ID of the image, relationship ID of the hyperlink, image
+// extension name and format sets.
+func (f *File) newPicture(cNvPrID, rID, hyperlinkRID int, ext string, opts *GraphicOptions) (*xlsxPic, error) {
 	pic := xlsxPic{}
 	pic.NvPicPr.CNvPicPr.PicLocks.NoChangeAspect = opts.LockAspectRatio
 	pic.NvPicPr.CNvPr.ID = cNvPrID
@@ -402,30 +473,381 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 		}
 	}
 	pic.SpPr.PrstGeom.Prst = "rect"
+	return &pic, f.setPictureFormat(&pic, opts)
+}
 
-	twoCellAnchor.Pic = &pic
-	twoCellAnchor.ClientData = &xdrClientData{
-		FLocksWithSheet:  *opts.Locked,
-		FPrintsWithSheet: *opts.PrintObject,
+// setPictureFormat provides a function to set the cropping, rotation, flip,
+// border, transparency, brightness, contrast and shadow effect of the picture
+// by given format set.
+func (f *File) setPictureFormat(pic *xlsxPic, opts *GraphicOptions) error {
+	if opts.Transparency < 0 || opts.Transparency > 100 ||
+		opts.Brightness < -100 || opts.Brightness > 100 ||
+		opts.Contrast < -100 || opts.Contrast > 100 {
+		return ErrParameterInvalid
+	}
+	if opts.Crop != nil {
+		crop := opts.Crop
+		for _, val := range []float64{crop.Left, crop.Top, crop.Right, crop.Bottom} {
+			if val < 0 || val >= 100 {
+				return ErrParameterInvalid
+			}
+		}
+		if crop.Left+crop.Right >= 100 || crop.Top+crop.Bottom >= 100 {
+			return ErrParameterInvalid
+		}
+		pic.BlipFill.SrcRect = &xlsxSrcRect{
+			L: int(crop.Left * 1000), T: int(crop.Top * 1000),
+			R: int(crop.Right * 1000), B: int(crop.Bottom * 1000),
+		}
+	}
+	if opts.Transparency > 0 {
+		pic.BlipFill.Blip.AlphaModFix = &xlsxAlphaModFix{Amt: (100 - opts.Transparency) * 1000}
+	}
+	if opts.Brightness != 0 || opts.Contrast != 0 {
+		pic.BlipFill.Blip.Lum = &xlsxLum{Bright: opts.Brightness * 1000, Contrast: opts.Contrast * 1000}
+	}
+	if opts.Border != nil {
+		if opts.Border.Dash != "" && inStrSlice(supportedDrawingLineDashTypes, opts.Border.Dash, true) == -1 {
+			return ErrParameterInvalid
+		}
+		color := "000000"
+		if opts.Border.Color != "" {
+			color = strings.ReplaceAll(strings.ToUpper(opts.Border.Color), "#", "")
+		}
+		pic.SpPr.Ln.SolidFill = &xlsxInnerXML{Content: fmt.Sprintf(`<a:srgbClr val="%s"/>`, color)}
+		if opts.Border.Width > 0 {
+			pic.SpPr.Ln.W = f.ptToEMUs(opts.Border.Width)
+		}
+		if opts.Border.Dash != "" {
+			pic.SpPr.Ln.PrstDash = &attrValString{Val: stringPtr(opts.Border.Dash)}
+		}
+	}
+	return setShapeProperties(&pic.SpPr, opts)
+}
+
+// extractPictureFormat provides a function to extract the cropping, rotation,
+// flip, border, transparency, brightness, contrast and shadow effect settings
+// of the picture by given decoded picture.
+func extractPictureFormat(pic *decodePic, opts *GraphicOptions) {
+	if rect := pic.BlipFill.SrcRect; rect != nil {
+		opts.Crop = &PictureCrop{
+			Left: float64(rect.L) / 1000, Top: float64(rect.T) / 1000,
+			Right: float64(rect.R) / 1000, Bottom: float64(rect.B) / 1000,
+		}
+	}
+	if pic.BlipFill.Blip.AlphaModFix != nil {
+		opts.Transparency = 100 - pic.BlipFill.Blip.AlphaModFix.Amt/1000
+	}
+	if pic.BlipFill.Blip.Lum != nil {
+		opts.Brightness = pic.BlipFill.Blip.Lum.Bright / 1000
+		opts.Contrast = pic.BlipFill.Blip.Lum.Contrast / 1000
+	}
+	if ln := pic.SpPr.Ln; ln != nil && (ln.SolidFill != nil || ln.W != 0 || ln.PrstDash != nil) {
+		opts.Border = &PictureBorder{Width: float64(ln.W) / 12700}
+		if ln.SolidFill != nil && ln.SolidFill.SrgbClr != nil {
+			opts.Border.Color = "#" + ln.SolidFill.SrgbClr.Val
+		}
+		if ln.PrstDash != nil && ln.PrstDash.Val != nil {
+			opts.Border.Dash = *ln.PrstDash.Val
+		}
+	}
+	extractShapeProperties(&pic.SpPr, opts)
+}
+
+// addCellPicture provides a function to add a picture placed in the cell, or
+// a picture inserted by the IMAGE formula function with the cached image, by
+// given worksheet name, cell reference, image extension name and picture.
+func (f *File) addCellPicture(sheet, cell, ext string, pic *Picture) error {
+	opts := pic.Format
+	if opts == nil {
+		opts = &GraphicOptions{}
+	}
+	if pic.InsertType == PictureInsertTypeIMAGE && opts.Hyperlink == "" {
+		return ErrParameterRequired
+	}
+	switch ext {
+	case ".emf", ".emz", ".svg", ".wmf", ".wmz":
+	default:
+		if _, _, err := image.DecodeConfig(bytes.NewReader(pic.File)); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	if _, _, err = CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(pic.File, ext), "xl")
+	var (
+		structure xlsxRichValueStructure
+		values    []string
+	)
+	if pic.InsertType == PictureInsertTypePlaceInCell {
+		structure, values, err = f.addRichValueLocalImage(mediaStr)
+	} else {
+		structure, values, err = f.addRichValueWebImage(opts.Hyperlink, mediaStr)
+	}
+	if err != nil {
+		return err
+	}
+	if opts.AltText != "" {
+		structure.K = append(structure.K, xlsxRichValueKey{N: "Text", T: "s"})
+		values = append(values, opts.AltText)
+	}
+	richValueIdx, err := f.addRichValue(structure, values)
+	if err != nil {
+		return err
+	}
+	valueMetadataIdx, err := f.addRichValueMetadata(richValueIdx)
+	if err != nil {
+		return err
+	}
+	if err = f.addRichDataParts(pic.InsertType); err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.Vm = nil
+	if err = f.removeFormula(c, ws, sheet); err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V, c.IS, c.Vm = "e", formulaErrorVALUE, nil, uintPtr(uint(valueMetadataIdx))
+	if pic.InsertType == PictureInsertTypeIMAGE {
+		args := []string{opts.Hyperlink}
+		if opts.AltText != "" {
+			args = append(args, opts.AltText)
+		}
+		for i, arg := range args {
+			args[i] = "\"" + strings.ReplaceAll(arg, "\"", "\"\"") + "\""
+		}
+		c.F = &xlsxF{Content: "_xlfn.IMAGE(" + strings.Join(args, ",") + ")"}
+	}
+	return err
+}
+
+// addRichValueLocalImage provides a function to add the relationship of the
+// picture placed in the cell into xl/richData/richValueRel.xml, and returns
+// the rich value structure and values of the local image.
+func (f *File) addRichValueLocalImage(mediaStr string) (xlsxRichValueStructure, []string, error) {
+	structure := xlsxRichValueStructure{T: "_localImage", K: []xlsxRichValueKey{
+		{N: "_rvRel:LocalImageIdentifier", T: "i"}, {N: "CalcOrigin", T: "i"},
+	}}
+	richValueRels, err := f.richValueRelReader()
+	if err != nil {
+		return structure, nil, err
+	}
+	relIdx := -1
+	for idx := range richValueRels.Rels {
+		rel := &richValueRels.Rels[idx]
+		if rel.RID == "" {
+			rel.ID, rel.RID = "", rel.ID
+		}
+		if r := f.getRichDataRichValueRelRelationships(rel.RID); relIdx == -1 && r != nil &&
+			r.Type == SourceRelationshipImage && r.Target == mediaStr {
+			relIdx = idx
+		}
+	}
+	if relIdx == -1 {
+		rID := f.addRels(defaultXMLRdRichValueRelRels, SourceRelationshipImage, mediaStr, "")
+		richValueRels.Rels = append(richValueRels.Rels, xlsxRichValueRelRelationship{RID: "rId" + strconv.Itoa(rID)})
+		relIdx = len(richValueRels.Rels) - 1
+	}
+	richValueRels.XMLNS = NameSpaceSpreadSheetRichValueRel
+	richValueRels.R = SourceRelationship.Value
+	output, err := xml.Marshal(richValueRels)
+	f.saveFileList(defaultXMLRdRichValueRel, output)
+	return structure, []string{strconv.Itoa(relIdx), "5"}, err
+}
+
+// addRichValueWebImage provides a function to add the web image supporting
+// rich data of the picture inserted by the IMAGE formula function into
+// xl/richData/rdRichValueWebImage.xml, and returns the rich value structure
+// and values of the web image.
+func (f *File) addRichValueWebImage(address, mediaStr string) (xlsxRichValueStructure, []string, error) {
+	structure := xlsxRichValueStructure{T: "_webimage", K: []xlsxRichValueKey{
+		{N: "WebImageIdentifier", T: "i"}, {N: "CalcOrigin", T: "i"},
+		{N: "ComputedImage", T: "b"}, {N: "ImageSizing", T: "i"},
+	}}
+	webImages, err := f.richValueWebImageReader()
+	if err != nil {
+		return structure, nil, err
+	}
+	addressRID := f.addRels(defaultXMLRdRichValueWebImagePartRels, SourceRelationshipHyperLink, address, "External")
+	blipRID := f.addRels(defaultXMLRdRichValueWebImagePartRels, SourceRelationshipImage, mediaStr, "")
+	webImages.WebImageSrd = append(webImages.WebImageSrd, xlsxWebImageSupportingRichData{
+		Address: xlsxExternalReference{RID: "rId" + strconv.Itoa(addressRID)},
+		Blip:    xlsxExternalReference{RID: "rId" + strconv.Itoa(blipRID)},
+	})
+	webImages.XMLNS = NameSpaceSpreadSheetWebImage
+	output, err := xml.Marshal(webImages)
+	f.saveFileList(defaultXMLRdRichValueWebImagePart, output)
+	return structure, []string{strconv.Itoa(len(webImages.WebImageSrd) - 1), "1", "0", "0"}, err
+}
+
+// addRichValue provides a function to add a rich value with given structure
+// and values into xl/richData/rdrichvalue.xml, and returns the index of the
+// rich value.
+func (f *File) addRichValue(structure xlsxRichValueStructure, values []string) (int, error) {
+	structures, err := f.richValueStructureReader()
+	if err != nil {
+		return -1, err
+	}
+	richValue, err := f.richValueReader()
+	if err != nil {
+		return -1, err
+	}
+	structureIdx := -1
+	for idx, s := range structures.S {
+		if s.T == structure.T && len(s.K) == len(structure.K) {
+			structureIdx = idx
+			for i, k := range s.K {
+				if k != structure.K[i] {
+					structureIdx = -1
+					break
+				}
+			}
+		}
+		if structureIdx != -1 {
+			break
+		}
+	}
+	if structureIdx == -1 {
+		structures.S = append(structures.S, structure)
+		structureIdx = len(structures.S) - 1
+	}
+	structures.XMLNS = NameSpaceSpreadSheetRichData
+	structures.Count = len(structures.S)
+	output, err := xml.Marshal(structures)
+	if err != nil {
+		return -1, err
+	}
+	f.saveFileList(defaultXMLRdRichValueStructurePart, output)
+	richValue.Rv = append(richValue.Rv, xlsxRichValue{S: structureIdx, V: values})
+	richValue.XMLNS = NameSpaceSpreadSheetRichData
+	richValue.Count = len(richValue.Rv)
+	output, err = xml.Marshal(richValue)
+	f.saveFileList(defaultXMLRdRichValuePart, output)
+	return len(richValue.Rv) - 1, err
+}
+
+// addRichValueMetadata provides a function to add the value metadata which
+// reference to the rich value by given rich value index into xl/metadata.xml,
+// and returns the one-based index of the value metadata block.
+func (f *File) addRichValueMetadata(richValueIdx int) (int, error) {
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return 0, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	var typeIdx int
+	for idx, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLRICHVALUE" {
+			typeIdx = idx + 1
+			break
+		}
+	}
+	if typeIdx == 0 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLRICHVALUE", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType)
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	futureIdx := -1
+	for idx, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name == "XLRICHVALUE" {
+			futureIdx = idx
+			break
+		}
+	}
+	if futureIdx == -1 {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{Name: "XLRICHVALUE"})
+		futureIdx = len(metadata.FutureMetadata) - 1
+	}
+	futureMetadata := &metadata.FutureMetadata[futureIdx]
+	futureMetadata.Bk = append(futureMetadata.Bk, xlsxFutureMetadataBlock{ExtLst: &xlsxInnerXML{
+		Content: fmt.Sprintf(`<ext uri="%s" xmlns:xlrd="%s"><xlrd:rvb i="%d"/></ext>`,
+			ExtURIRichValueBlock, NameSpaceSpreadSheetRichData, richValueIdx),
+	}})
+	futureMetadata.Count = len(futureMetadata.Bk)
+	if metadata.ValueMetadata == nil {
+		metadata.ValueMetadata = &xlsxMetadataBlocks{}
+	}
+	metadata.ValueMetadata.Bk = append(metadata.ValueMetadata.Bk, xlsxMetadataBlock{
+		Rc: []xlsxMetadataRecord{{T: typeIdx, V: len(futureMetadata.Bk) - 1}},
+	})
+	metadata.ValueMetadata.Count = len(metadata.ValueMetadata.Bk)
+	metadata.XMLNS = NameSpaceSpreadSheet.Value
+	output, err := xml.Marshal(metadata)
+	f.saveFileList(defaultXMLMetadata, output)
+	return metadata.ValueMetadata.Count, err
+}
+
+// addRichDataParts provides a function to add the workbook relationships and
+// content types of the metadata and rich data parts by given picture insert
+// type.
+func (f *File) addRichDataParts(insertType PictureInsertType) error {
+	if _, ok := f.Pkg.Load(defaultXMLRdRichValueTypesPart); !ok {
+		f.saveFileList(defaultXMLRdRichValueTypesPart, []byte(templateRdRichValueTypes))
+	}
+	parts := [][]string{
+		{"metadata", SourceRelationshipSheetMetadata, defaultXMLMetadata},
+		{"rdRichValue", SourceRelationshipRdRichValue, defaultXMLRdRichValuePart},
+		{"rdRichValueStructure", SourceRelationshipRdRichValueStructure, defaultXMLRdRichValueStructurePart},
+		{"rdRichValueTypes", SourceRelationshipRdRichValueTypes, defaultXMLRdRichValueTypesPart},
+		{"richValueRel", SourceRelationshipRichValueRel, defaultXMLRdRichValueRel},
+	}
+	if insertType == PictureInsertTypeIMAGE {
+		parts[4] = []string{"rdRichValueWebImage", SourceRelationshipRdRichValueWebImage, defaultXMLRdRichValueWebImagePart}
+	}
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if err = f.addContentTypePart(0, part[0]); err != nil {
+			return err
+		}
+		var exist bool
+		if rels != nil {
+			rels.mu.Lock()
+			for _, rel := range rels.Relationships {
+				if rel.Type == part[1] {
+					exist = true
+					break
+				}
+			}
+			rels.mu.Unlock()
+		}
+		if !exist {
+			f.addRels(relPath, part[1], strings.TrimPrefix(part[2], "xl/"), "")
+		}
 	}
-	content.mu.Lock()
-	defer content.mu.Unlock()
-	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
-	f.Drawings.Store(drawingXML, content)
 	return err
 }
 
 // countMedia provides a function to get media files count storage in the
 // folder xl/media/image.
 func (f *File) countMedia() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/media/image") {
-			count++
-		}
-		return true
-	})
-	return count
+	return len(f.getPartNames("xl/media/image"))
 }
 
 // addMedia provides a function to add a picture into folder xl/media/image by
@@ -584,6 +1006,7 @@ func (f *File) DeletePicture(sheet, cell string) error {
 	f.Pkg.Range(checkPicRef)
 	if !used {
 		f.Pkg.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
+		f.zipFiles.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
 	}
 	f.deleteDrawingRels(drawingRels, rID)
 	return err
@@ -602,17 +1025,23 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	cond2 := func(from *decodeFrom) bool { return from.Col == col && from.Row == row }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
-		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
+		if buffer, _ := f.loadPkg(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			var dePic decodePic
+			if output, err := xml.Marshal(a.Pic); err == nil {
+				_ = f.xmlNewDecoder(bytes.NewReader(output)).Decode(&dePic)
+			}
+			extractPictureFormat(&dePic, pic.Format)
 			pics = append(pics, pic)
 		}
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
-		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
+		if buffer, _ := f.loadPkg(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			extractPictureFormat(a.Pic, pic.Format)
 			pics = append(pics, pic)
 		}
 	}
@@ -763,14 +1192,14 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 	cond := func(from *xlsxFrom) bool { return true }
 	cond2 := func(from *decodeFrom) bool { return true }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
-		if _, ok := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
+		if _, ok := f.loadPkg(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
 		}
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
-		if _, ok := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
+		if _, ok := f.loadPkg(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
@@ -898,7 +1327,14 @@ func (f *File) getImageCellRel(c *xlsxC, pic *Picture) (*xlsxRelationship, error
 		return r, err
 	}
 	rv := richValue.Rv[richValueIdx].V
-	if len(rv) == 2 && rv[1] == "5" {
+	if structures, _ := f.richValueStructureReader(); pic.Format != nil && richValue.Rv[richValueIdx].S < len(structures.S) {
+		for idx, key := range structures.S[richValue.Rv[richValueIdx].S].K {
+			if key.N == "Text" && idx < len(rv) {
+				pic.Format.AltText = rv[idx]
+			}
+		}
+	}
+	if len(rv) >= 2 && rv[1] == "5" {
 		pic.InsertType = PictureInsertTypePlaceInCell
 		return f.getRichDataRichValueRel(rv[0])
 	}
@@ -925,7 +1361,7 @@ func (f *File) getCellImages(sheet, cell string) ([]Picture, error) {
 			return "", true, err
 		}
 		pic.Extension = filepath.Ext(r.Target)
-		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
+		if buffer, _ := f.loadPkg(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pics = append(pics, pic)
 		}
@@ -962,7 +1398,7 @@ func (f *File) getDispImages(sheet, cell string) ([]Picture, error) {
 			for _, r := range rels.Relationships {
 				if r.ID == cellImg.Pic.BlipFill.Blip.Embed {
 					pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypeDISPIMG}
-					if buffer, _ := f.Pkg.Load("xl/" + r.Target); buffer != nil {
+					if buffer, _ := f.loadPkg("xl/" + r.Target); buffer != nil {
 						pic.File = buffer.([]byte)
 						pic.Format.AltText = cellImg.Pic.NvPicPr.CNvPr.Descr
 						pics = append(pics, pic)
@@ -60,7 +60,7 @@ func TestAddPicture(t *testing.T) {
 	// Test add picture to worksheet from bytes
 	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "Q1", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{AltText: "Excel Logo"}}))
 	// Test add picture to worksheet from bytes with unsupported insert type
-	assert.Equal(t, ErrParameterInvalid, f.AddPictureFromBytes("Sheet1", "Q1", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{AltText: "Excel Logo"}, InsertType: PictureInsertTypePlaceInCell}))
+	assert.Equal(t, ErrParameterInvalid, f.AddPictureFromBytes("Sheet1", "Q1", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{AltText: "Excel Logo"}, InsertType: PictureInsertTypeDISPIMG}))
 	// Test add picture to worksheet from bytes with illegal cell reference
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddPictureFromBytes("Sheet1", "A", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{AltText: "Excel Logo"}}))
 
@@ -145,6 +145,73 @@ func TestAddPictureErrors(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPictureFormat(t *testing.T) {
+	f := NewFile()
+	format := &GraphicOptions{
+		AltText:        "Excel Logo",
+		Crop:           &PictureCrop{Left: 10, Top: 12.5, Right: 5, Bottom: 20},
+		Rotation:       45,
+		FlipHorizontal: true,
+		FlipVertical:   true,
+		Border:         &PictureBorder{Color: "#FF0000", Width: 2, Dash: "dashDot"},
+		Transparency:   30,
+		Brightness:     20,
+		Contrast:       -40,
+		Shadow:         &PictureShadow{Color: "#4472C4", Transparency: 60, Blur: 4, Distance: 3, Angle: 45},
+	}
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), format))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{Rotation: -90, Border: &PictureBorder{}}))
+	expected := func() []*GraphicOptions {
+		return []*GraphicOptions{
+			{
+				AltText:        "Excel Logo",
+				Crop:           &PictureCrop{Left: 10, Top: 12.5, Right: 5, Bottom: 20},
+				Rotation:       45,
+				FlipHorizontal: true,
+				FlipVertical:   true,
+				Border:         &PictureBorder{Color: "#FF0000", Width: 2, Dash: "dashDot"},
+				Transparency:   30,
+				Brightness:     20,
+				Contrast:       -40,
+				Shadow:         &PictureShadow{Color: "#4472C4", Transparency: 60, Blur: 4, Distance: 3, Angle: 45},
+			},
+			{Rotation: 270, Border: &PictureBorder{Color: "#000000"}},
+		}
+	}
+	check := func(f *File) {
+		for i, cell := range []string{"A1", "F1"} {
+			pics, err := f.GetPictures("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Len(t, pics, 1)
+			assert.Equal(t, expected()[i], pics[0].Format)
+		}
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureFormat.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureFormat.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+
+	// Test add picture with invalid format settings
+	f = NewFile()
+	for _, opts := range []*GraphicOptions{
+		{Crop: &PictureCrop{Left: -1}},
+		{Crop: &PictureCrop{Top: 100}},
+		{Crop: &PictureCrop{Left: 60, Right: 40}},
+		{Crop: &PictureCrop{Top: 50, Bottom: 50}},
+		{Transparency: 101},
+		{Brightness: -101},
+		{Contrast: 101},
+		{Border: &PictureBorder{Dash: "unknown"}},
+		{Shadow: &PictureShadow{Transparency: -1}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), opts))
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestGetPicture(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
@@ -304,6 +371,103 @@ func TestAddPictureFromBytes(t *testing.T) {
 	assert.EqualError(t, f.AddPictureFromBytes("Sheet:1", fmt.Sprint("A", 1), &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "logo"}}), ErrSheetNameInvalid.Error())
 }
 
+func TestAddCellPicture(t *testing.T) {
+	f := NewFile()
+	imgFile, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	// Test add pictures placed in cells
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A2", &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "Excel Logo"}, InsertType: PictureInsertTypePlaceInCell}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=1+1"))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A3", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}))
+	// Test add pictures by IMAGE formula function
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "B1", &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{Hyperlink: "https://github.com/xuri/excelize/logo.png", AltText: "Excel \"Logo\""}, InsertType: PictureInsertTypeIMAGE}))
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, `_xlfn.IMAGE("https://github.com/xuri/excelize/logo.png","Excel ""Logo""")`, formula)
+	formula, err = f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	cells, err := f.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B1", "A2", "A3"}, cells)
+	// Test the image file and the relationships of the same image are only stored once
+	richValueRels, err := f.richValueRelReader()
+	assert.NoError(t, err)
+	assert.Len(t, richValueRels.Rels, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCellPicture.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddCellPicture.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]Picture{
+		"A1": {Extension: ".png", File: imgFile, Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceInCell},
+		"A2": {Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "Excel Logo"}, InsertType: PictureInsertTypePlaceInCell},
+		"B1": {Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "Excel \"Logo\""}, InsertType: PictureInsertTypeIMAGE},
+	} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, []Picture{expected}, pics, cell)
+	}
+	// Test add picture placed in the cell into the workbook contains cell pictures
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "C1", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}))
+	pics, err := f.GetPictures("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	metadata, err := f.metadataReader()
+	assert.NoError(t, err)
+	assert.Len(t, metadata.MetadataTypes.MetadataType, 1)
+	assert.Len(t, metadata.ValueMetadata.Bk, 5)
+	structures, err := f.richValueStructureReader()
+	assert.NoError(t, err)
+	assert.Len(t, structures.S, 3)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add picture by IMAGE formula function without source URL
+	assert.Equal(t, ErrParameterRequired, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypeIMAGE}))
+	// Test add picture placed in the cell on not exists worksheet
+	assert.EqualError(t, f.AddPictureFromBytes("SheetN", "A1", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}), "sheet SheetN does not exist")
+	// Test add picture placed in the cell with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddPictureFromBytes("Sheet1", "A", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}))
+	// Test add picture placed in the cell with unsupported charset rich data parts
+	for _, part := range []string{defaultXMLRdRichValueRel, defaultXMLRdRichValueWebImagePart, defaultXMLRdRichValueStructurePart, defaultXMLRdRichValuePart, defaultXMLMetadata} {
+		f = NewFile()
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		insertType := PictureInsertTypePlaceInCell
+		if part == defaultXMLRdRichValueWebImagePart {
+			insertType = PictureInsertTypeIMAGE
+		}
+		assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{Hyperlink: "https://github.com/xuri/excelize/logo.png"}, InsertType: insertType}), "XML syntax error on line 1: invalid UTF-8", part)
+		assert.NoError(t, f.Close())
+	}
+	// Test add picture placed in the cell with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: imgFile, InsertType: PictureInsertTypePlaceInCell}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test add vector pictures placed in the cells
+	f = NewFile()
+	for cell, name := range map[string]string{
+		"A1": filepath.Join("test", "images", "excel.emf"),
+		"A2": filepath.Join("test", "images", "excel.emz"),
+		"A3": filepath.Join("test", "images", "excel.wmf"),
+		"A4": filepath.Join("test", "images", "excel.wmz"),
+		"A5": "excelize.svg",
+	} {
+		file, err := os.ReadFile(name)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddPictureFromBytes("Sheet1", cell, &Picture{Extension: filepath.Ext(name), File: file, InsertType: PictureInsertTypePlaceInCell}))
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, filepath.Ext(name), pics[0].Extension)
+		assert.Equal(t, file, pics[0].File)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestDeletePicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
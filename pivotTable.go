@@ -32,6 +32,9 @@ import (
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
 //	PivotStyleDark1 - PivotStyleDark28
+//
+// The name of the custom pivot table style which created by the AddTableStyle
+// function can also be used as the PivotTableStyleName.
 type PivotTableOptions struct {
 	pivotTableXML       string
 	pivotCacheXML       string
@@ -605,27 +608,13 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 // countPivotTables provides a function to get pivot table files count storage
 // in the folder xl/pivotTables.
 func (f *File) countPivotTables() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotTables/pivotTable") {
-			count++
-		}
-		return true
-	})
-	return count
+	return len(f.getPartNames("xl/pivotTables/pivotTable"))
 }
 
 // countPivotCache provides a function to get pivot table cache definition files
 // count storage in the folder xl/pivotCache.
 func (f *File) countPivotCache() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
-			count++
-		}
-		return true
-	})
-	return count
+	return len(f.getPartNames("xl/pivotCache/pivotCacheDefinition"))
 }
 
 // getPivotFieldsIndex convert the column of the first row in the data region
@@ -846,7 +835,7 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 // pivotTableReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotTables/pivotTable%d.xml.
 func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error) {
-	content, ok := f.Pkg.Load(path)
+	content, ok := f.loadPkg(path)
 	pivotTable := &xlsxPivotTableDefinition{}
 	if ok && content != nil {
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
@@ -860,7 +849,7 @@ func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error)
 // pivotCacheReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotCache/pivotCacheDefinition%d.xml.
 func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error) {
-	content, ok := f.Pkg.Load(path)
+	content, ok := f.loadPkg(path)
 	pivotCache := &xlsxPivotCacheDefinition{}
 	if ok && content != nil {
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
@@ -923,6 +912,7 @@ func (f *File) genPivotCacheDefinitionID() int {
 		decodeExtLst                  = new(decodeExtLst)
 		decodeX14PivotCacheDefinition = new(decodeX14PivotCacheDefinition)
 	)
+	f.loadZipFiles("xl/pivotCache/pivotCacheDefinition")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
 			pc, err := f.pivotCacheReader(k.(string))
@@ -19,6 +19,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mohae/deepcopy"
 )
@@ -353,6 +354,389 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 	return true, f.xmlNewDecoder(tempFile), tempFile, err
 }
 
+// CellData directly maps the typed value and attributes of a cell returned
+// by the row reader. The Value is float64 or int for the number cell, bool
+// for the boolean cell, time.Time for the date cell and the number cell with
+// date and time number format, which type will be CellTypeDate, string for the text, formula string result
+// and error cell, and nil for the cell without value.
+type CellData struct {
+	Col     int
+	Type    CellType
+	StyleID int
+	Formula string
+	Value   interface{}
+}
+
+// RowReaderOptions directly maps the settings of the row reader.
+//
+// Range specifies the rectangular range reference of the cells to read, for
+// example "B2:D10", all cells in the worksheet will be read by default.
+//
+// SkipHiddenRows specifies if skip the hidden rows.
+type RowReaderOptions struct {
+	Range          string
+	SkipHiddenRows bool
+}
+
+// RowReader defines a typed iterator to a sheet.
+type RowReader struct {
+	err                  error
+	f                    *File
+	tempFile             *os.File
+	decoder              *xml.Decoder
+	sst                  *xlsxSST
+	opts                 RowReaderOptions
+	coordinates          []int
+	curRow               int
+	curRowOpts           RowOpts
+	cells                []CellData
+	dateStyles           map[int]bool
+	date1904, sstTemp    bool
+	needClose, completed bool
+}
+
+// NewRowReader returns a typed rows iterator by given worksheet name and
+// options, used for streaming reading data for a worksheet with a large data
+// in one pass. The values of cells will be returned as typed values without
+// applying the number format, and the empty rows and cells will be skipped.
+// For example, read typed values of the cells in range A2:C10000 of the
+// visible rows on Sheet1:
+//
+//	rr, err := f.NewRowReader("Sheet1", excelize.RowReaderOptions{
+//	    Range:          "A2:C10000",
+//	    SkipHiddenRows: true,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rr.Next() {
+//	    for _, cell := range rr.Cells() {
+//	        fmt.Println(rr.CurrentRow(), cell.Col, cell.Type, cell.Value)
+//	    }
+//	}
+//	if err = rr.Error(); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err = rr.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) NewRowReader(sheet string, opts ...RowReaderOptions) (*RowReader, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	rr := RowReader{f: f, coordinates: []int{1, 1, MaxColumns, TotalRows}, dateStyles: make(map[int]bool)}
+	for i := range opts {
+		rr.opts = opts[i]
+	}
+	if rr.opts.Range != "" {
+		coordinates, err := rangeRefToCoordinates(rr.opts.Range)
+		if err != nil {
+			return nil, err
+		}
+		_ = sortCoordinates(coordinates)
+		rr.coordinates = coordinates
+	}
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+		ws := worksheet.(*xlsxWorksheet)
+		ws.mu.Lock()
+		// Flush data
+		output, _ := xml.Marshal(ws)
+		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		ws.mu.Unlock()
+	}
+	var err error
+	f.loadZipFile(defaultXMLPathSharedStrings)
+	if _, rr.sstTemp = f.tempFiles.Load(defaultXMLPathSharedStrings); !rr.sstTemp {
+		if rr.sst, err = f.sharedStringsReader(); err != nil {
+			return nil, err
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		rr.date1904 = wb.WorkbookPr.Date1904
+	}
+	rr.needClose, rr.decoder, rr.tempFile, err = f.xmlDecoder(name)
+	return &rr, err
+}
+
+// Next will return true if it finds the next row element in the range, and
+// reads the cells of the row.
+func (rr *RowReader) Next() bool {
+	for !rr.completed {
+		token, err := rr.decoder.RawToken()
+		if err != nil {
+			if err != io.EOF {
+				rr.err = err
+			}
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local != "row" {
+				continue
+			}
+			rr.curRow++
+			if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+				rr.curRow = rowNum
+			}
+			rr.curRowOpts = extractRowOpts(xmlElement.Attr)
+			if rr.curRow > rr.coordinates[3] {
+				rr.completed = true
+				break
+			}
+			if rr.curRow < rr.coordinates[1] || (rr.opts.SkipHiddenRows && rr.curRowOpts.Hidden) {
+				if rr.err = rr.skip(); rr.err != nil {
+					return false
+				}
+				continue
+			}
+			if rr.err = rr.readRow(); rr.err != nil {
+				return false
+			}
+			if len(rr.cells) > 0 {
+				return true
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				rr.completed = true
+			}
+		}
+	}
+	rr.cells = nil
+	return false
+}
+
+// CurrentRow will return the row number of the current row.
+func (rr *RowReader) CurrentRow() int {
+	return rr.curRow
+}
+
+// GetRowOpts will return the RowOpts of the current row.
+func (rr *RowReader) GetRowOpts() RowOpts {
+	return rr.curRowOpts
+}
+
+// Cells will return the typed cells of the current row in the range, the
+// empty cells without value, formula and style will be skipped.
+func (rr *RowReader) Cells() []CellData {
+	return rr.cells
+}
+
+// Error will return the error when the error occurs.
+func (rr *RowReader) Error() error {
+	return rr.err
+}
+
+// Close closes the open worksheet XML file in the system temporary
+// directory.
+func (rr *RowReader) Close() error {
+	if rr.tempFile != nil {
+		return rr.tempFile.Close()
+	}
+	return nil
+}
+
+// skip provides a function to skip the rest of the element which start
+// element has been read.
+func (rr *RowReader) skip() error {
+	for depth := 1; depth > 0; {
+		token, err := rr.decoder.RawToken()
+		if err != nil {
+			return err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
+
+// readRow provides a function to read the cells of the current row.
+func (rr *RowReader) readRow() error {
+	rr.cells = make([]CellData, 0, len(rr.cells))
+	var col int
+	for {
+		token, err := rr.decoder.RawToken()
+		if err != nil {
+			return err
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local != "c" {
+				if err = rr.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if col, err = rr.readCell(&xmlElement, col+1); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// readCell provides a function to read the typed value of the cell by given
+// cell start element and default column number, and returns the column
+// number of the cell.
+func (rr *RowReader) readCell(xmlElement *xml.StartElement, col int) (int, error) {
+	var (
+		err                 error
+		cellType, inElement string
+		hasFormula          bool
+		value, text         []byte
+		cell                = CellData{Col: col}
+	)
+	for _, attr := range xmlElement.Attr {
+		switch attr.Name.Local {
+		case "r":
+			if cell.Col, _, err = CellNameToCoordinates(attr.Value); err != nil {
+				return col, err
+			}
+		case "s":
+			if cell.StyleID, err = strconv.Atoi(attr.Value); err != nil {
+				return col, err
+			}
+		case "t":
+			cellType = attr.Value
+		}
+	}
+	for depth := 0; ; {
+		token, err := rr.decoder.RawToken()
+		if err != nil {
+			return cell.Col, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "rPh" || element.Name.Local == "extLst" {
+				if err = rr.skip(); err != nil {
+					return cell.Col, err
+				}
+				continue
+			}
+			if inElement = element.Name.Local; inElement == "f" {
+				hasFormula = true
+			}
+			depth++
+		case xml.CharData:
+			switch inElement {
+			case "v":
+				value = append(value, element...)
+			case "f":
+				cell.Formula += string(element)
+			case "t":
+				text = append(text, element...)
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				if cell.Col < rr.coordinates[0] || cell.Col > rr.coordinates[2] {
+					return cell.Col, nil
+				}
+				if cell.Type, cell.Value, err = rr.getCellValue(cellType, cell.StyleID, value, text); err != nil {
+					return cell.Col, err
+				}
+				if cell.Value != nil || hasFormula || cell.StyleID != 0 {
+					rr.cells = append(rr.cells, cell)
+				}
+				return cell.Col, nil
+			}
+			depth, inElement = depth-1, ""
+		}
+	}
+}
+
+// getCellValue provides a function to get the type and typed value of the
+// cell by given cell data type, style ID, and content of value and inline
+// string elements.
+func (rr *RowReader) getCellValue(cellType string, styleID int, value, text []byte) (CellType, interface{}, error) {
+	switch cellType {
+	case "b":
+		return CellTypeBool, len(value) > 0 && (value[0] == '1' || value[0] == 't'), nil
+	case "d":
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, string(value)); err == nil {
+				return CellTypeDate, t, nil
+			}
+		}
+		return CellTypeDate, string(value), nil
+	case "e":
+		return CellTypeError, string(value), nil
+	case "s":
+		if len(value) == 0 {
+			return CellTypeSharedString, nil, nil
+		}
+		idx, err := strconv.Atoi(strings.TrimSpace(string(value)))
+		if err != nil {
+			return CellTypeSharedString, nil, err
+		}
+		if rr.sstTemp {
+			return CellTypeSharedString, rr.f.getFromStringItem(idx), nil
+		}
+		rr.sst.mu.Lock()
+		defer rr.sst.mu.Unlock()
+		if idx < len(rr.sst.SI) {
+			return CellTypeSharedString, rr.sst.SI[idx].String(), nil
+		}
+		return CellTypeSharedString, string(value), nil
+	case "str":
+		if len(value) == 0 {
+			return CellTypeFormula, nil, nil
+		}
+		return CellTypeFormula, string(value), nil
+	case "inlineStr":
+		return CellTypeInlineString, string(text), nil
+	}
+	if len(value) == 0 {
+		return CellTypeUnset, nil, nil
+	}
+	if n, err := strconv.Atoi(string(value)); err == nil && !rr.isDateStyle(styleID) {
+		return CellTypeNumber, n, nil
+	}
+	n, err := strconv.ParseFloat(string(value), 64)
+	if err != nil {
+		return CellTypeNumber, string(value), nil
+	}
+	if rr.isDateStyle(styleID) {
+		return CellTypeDate, timeFromExcelTime(n, rr.date1904), nil
+	}
+	return CellTypeNumber, n, nil
+}
+
+// isDateStyle provides a function to check if the number format of the given
+// style ID is a date and time number format.
+func (rr *RowReader) isDateStyle(styleID int) bool {
+	if styleID == 0 {
+		return false
+	}
+	if isDate, ok := rr.dateStyles[styleID]; ok {
+		return isDate
+	}
+	var isDate bool
+	if styleSheet, err := rr.f.stylesReader(); err == nil && styleSheet.CellXfs != nil &&
+		styleID > 0 && styleID < len(styleSheet.CellXfs.Xf) && styleSheet.CellXfs.Xf[styleID].NumFmtID != nil {
+		numFmtID := *styleSheet.CellXfs.Xf[styleID].NumFmtID
+		fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID)
+		if !ok {
+			fmtCode, _ = rr.f.getBuiltInNumFmtCode(numFmtID)
+		}
+		isDate = isDateTimeNumFmt(fmtCode)
+	}
+	rr.dateStyles[styleID] = isDate
+	return isDate
+}
+
 // SetRowHeight provides a function to set the height of a single row. If the
 // value of height is 0, will hide the specified row, if the value of height is
 // -1, will unset the custom row height. For example, set the height of the
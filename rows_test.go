@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -148,6 +149,164 @@ func TestRowsError(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestNewRowReader(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Hello", 1, 1.5, true, date}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "B1+C1"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"World", 2}))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B4", &[]interface{}{4, 4.5}))
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D4", "D4", styleID))
+
+	rr, err := f.NewRowReader("Sheet1")
+	assert.NoError(t, err)
+	var results [][]CellData
+	var rowNums []int
+	for rr.Next() {
+		results = append(results, rr.Cells())
+		rowNums = append(rowNums, rr.CurrentRow())
+	}
+	assert.NoError(t, rr.Error())
+	assert.NoError(t, rr.Close())
+	assert.Equal(t, []int{1, 2, 4}, rowNums)
+	assert.Equal(t, []CellData{
+		{Col: 1, Type: CellTypeSharedString, Value: "Hello"},
+		{Col: 2, Type: CellTypeNumber, Value: 1},
+		{Col: 3, Type: CellTypeNumber, Value: 1.5},
+		{Col: 4, Type: CellTypeBool, Value: true},
+		{Col: 5, Type: CellTypeDate, StyleID: results[0][4].StyleID, Value: date},
+		{Col: 6, Type: CellTypeFormula, Formula: "B1+C1"},
+	}, results[0])
+	assert.Len(t, results[1], 2)
+	assert.Equal(t, []CellData{
+		{Col: 2, Type: CellTypeNumber, Value: 4},
+		{Col: 3, Type: CellTypeNumber, Value: 4.5},
+		{Col: 4, Type: CellTypeUnset, StyleID: styleID},
+	}, results[2])
+
+	// Test read cells in range and skip hidden rows
+	rr, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "C4:B1", SkipHiddenRows: true})
+	assert.NoError(t, err)
+	results, rowNums = nil, nil
+	for rr.Next() {
+		results = append(results, rr.Cells())
+		rowNums = append(rowNums, rr.CurrentRow())
+		assert.Equal(t, rr.GetRowOpts().Hidden, false)
+	}
+	assert.NoError(t, rr.Close())
+	assert.Equal(t, []int{1, 4}, rowNums)
+	assert.Equal(t, [][]CellData{
+		{{Col: 2, Type: CellTypeNumber, Value: 1}, {Col: 3, Type: CellTypeNumber, Value: 1.5}},
+		{{Col: 2, Type: CellTypeNumber, Value: 4}, {Col: 3, Type: CellTypeNumber, Value: 4.5}},
+	}, results)
+	rr, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "A2:A2"})
+	assert.NoError(t, err)
+	assert.True(t, rr.Next())
+	assert.Equal(t, []CellData{{Col: 1, Type: CellTypeSharedString, Value: "World"}}, rr.Cells())
+	assert.False(t, rr.Next())
+	assert.Nil(t, rr.Cells())
+	assert.NoError(t, rr.Close())
+
+	// Test read cells with typed values
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+
+		`<row><c t="e"><v>#DIV/0!</v><f>1/0</f></c><c t="str"><f>"A"&amp;"B"</f><v>AB</v></c><c t="inlineStr"><is><r><t>Rich </t></r><r><t>Text</t></r><rPh><t>P</t></rPh></is></c></row>`+
+		`<row><c t="d"><v>2024-05-06T07:08:09Z</v></c><c t="d"><v>2024-05-06</v></c><c t="d"><v>-</v></c><c t="b"><v>0</v></c><c t="s"></c><c t="s"><v>100</v></c><c><v>1E+20</v></c><c><v>text</v></c><c/><x:extLst xmlns:x="x"/></row>`+
+		`<row r="5"/></sheetData></worksheet>`))
+	rr, err = f.NewRowReader("Sheet1")
+	assert.NoError(t, err)
+	results, rowNums = nil, nil
+	for rr.Next() {
+		results = append(results, rr.Cells())
+		rowNums = append(rowNums, rr.CurrentRow())
+	}
+	assert.NoError(t, rr.Error())
+	assert.NoError(t, rr.Close())
+	assert.Equal(t, []int{1, 2}, rowNums)
+	assert.Equal(t, [][]CellData{
+		{
+			{Col: 1, Type: CellTypeError, Formula: "1/0", Value: "#DIV/0!"},
+			{Col: 2, Type: CellTypeFormula, Formula: `"A"&"B"`, Value: "AB"},
+			{Col: 3, Type: CellTypeInlineString, Value: "Rich Text"},
+		},
+		{
+			{Col: 1, Type: CellTypeDate, Value: date},
+			{Col: 2, Type: CellTypeDate, Value: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)},
+			{Col: 3, Type: CellTypeDate, Value: "-"},
+			{Col: 4, Type: CellTypeBool, Value: false},
+			{Col: 6, Type: CellTypeSharedString, Value: "100"},
+			{Col: 7, Type: CellTypeNumber, Value: 1e20},
+			{Col: 8, Type: CellTypeNumber, Value: "text"},
+		},
+	}, results)
+
+	// Test read cells with shared string table in temporary file
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNewRowReader.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestNewRowReader.xlsx"), Options{UnzipXMLSizeLimit: 64})
+	assert.NoError(t, err)
+	rr, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "A1:A1"})
+	assert.NoError(t, err)
+	assert.True(t, rr.Next())
+	assert.Equal(t, []CellData{{Col: 1, Type: CellTypeSharedString, Value: "Hello"}}, rr.Cells())
+	assert.NoError(t, rr.Close())
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test create row reader with invalid sheet name
+	_, err = f.NewRowReader("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test create row reader on not exists worksheet
+	_, err = f.NewRowReader("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test create row reader with invalid range reference
+	_, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "A1"})
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test read cells with invalid cell reference, style ID and shared string index
+	for _, content := range []string{
+		`<c r="A"><v>1</v></c>`, `<c s="A"><v>1</v></c>`, `<c t="s"><v>A</v></c>`,
+	} {
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1">`+content+`</row></sheetData></worksheet>`))
+		rr, err = f.NewRowReader("Sheet1")
+		assert.NoError(t, err)
+		assert.False(t, rr.Next())
+		assert.Error(t, rr.Error())
+	}
+	// Test read rows with invalid XML
+	for _, content := range []string{
+		`<row r="1"><c><v>1</c></row>`, `<row r="1"><c><rPh></c></row>`, `<row r="1"><x></row>`, `<row r="1">`, `<row r="1"><c>`,
+	} {
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData>`+content))
+		rr, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "A1:A1"})
+		assert.NoError(t, err)
+		assert.False(t, rr.Next())
+		assert.Error(t, rr.Error())
+	}
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><x>`))
+	rr, err = f.NewRowReader("Sheet1", RowReaderOptions{Range: "A2:A2"})
+	assert.NoError(t, err)
+	assert.False(t, rr.Next())
+	assert.Error(t, rr.Error())
+	// Test create row reader with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.NewRowReader("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test create row reader with unsupported charset workbook
+	f.SharedStrings = nil
+	f.Pkg.Delete(defaultXMLPathSharedStrings)
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.NewRowReader("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRowHeight(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -1178,6 +1337,31 @@ func BenchmarkRows(b *testing.B) {
 	}
 }
 
+func BenchmarkRowReader(b *testing.B) {
+	f, _ := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	for i := 0; i < b.N; i++ {
+		rr, _ := f.NewRowReader("Sheet2")
+		var values int
+		for rr.Next() {
+			for _, cell := range rr.Cells() {
+				if cell.Value != nil {
+					values++
+				}
+			}
+		}
+		if values == 0 {
+			b.Error("no cell values were read")
+		}
+		if err := rr.Close(); err != nil {
+			b.Error(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		b.Error(err)
+	}
+	b.ReportAllocs()
+}
+
 // trimSliceSpace trim continually blank element in the tail of slice.
 func trimSliceSpace(s []string) []string {
 	for {
@@ -12,6 +12,11 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -78,6 +83,28 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// The 'Name' specifies the name of the shape, which can be used to update or
+// delete the shape, the default name is "Shape N". Set the 'Type' of the
+// 'Fill' to "gradient" with two colors to apply a gradient fill on the
+// shape, the 'Shading' of the gradient fill is the same as the cell style.
+// The 'Rotation', 'FlipHorizontal', 'FlipVertical' and 'Shadow' of the
+// 'Format' specifies the rotation angle in degrees, flip and outer shadow
+// effect of the shape. For example, add an ellipse with the gradient fill and
+// shadow rotated by 30 degrees:
+//
+//	err := f.AddShape("Sheet1",
+//	    &excelize.Shape{
+//	        Cell: "G6",
+//	        Name: "Badge",
+//	        Type: "ellipse",
+//	        Fill: excelize.Fill{Type: "gradient", Color: []string{"FFFFFF", "8EB9FF"}, Shading: 16},
+//	        Format: excelize.GraphicOptions{
+//	            Rotation: 30,
+//	            Shadow:   &excelize.PictureShadow{Color: "808080", Blur: 4, Distance: 3, Angle: 45},
+//	        },
+//	    },
+//	)
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -361,7 +388,28 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	if err != nil {
 		return err
 	}
+	shape, err := f.newShape(cNvPrID, opts)
+	if err != nil {
+		return err
+	}
+	twoCellAnchor.Sp = shape
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// newShape provides a function to create a shape by given shape ID and
+// format sets.
+func (f *File) newShape(cNvPrID int, opts *Shape) (*xdrSp, error) {
 	var solidColor string
+	name := opts.Name
+	if name == "" {
+		name = "Shape " + strconv.Itoa(cNvPrID)
+	}
 	if len(opts.Fill.Color) == 1 {
 		solidColor = opts.Fill.Color[0]
 	}
@@ -370,7 +418,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 		NvSpPr: &xdrNvSpPr{
 			CNvPr: &xlsxCNvPr{
 				ID:   cNvPrID,
-				Name: "Shape " + strconv.Itoa(cNvPrID),
+				Name: name,
 			},
 			CNvSpPr: &xdrCNvSpPr{
 				TxBox: true,
@@ -407,9 +455,15 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			W: f.ptToEMUs(*opts.Line.Width),
 		}
 	}
+	if err := setShapeFill(shape.SpPr, opts.Fill); err != nil {
+		return nil, err
+	}
+	if err := setShapeProperties(shape.SpPr, &opts.Format); err != nil {
+		return nil, err
+	}
 	defaultFont, err := f.GetDefaultFont()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(opts.Paragraph) < 1 {
 		opts.Paragraph = []RichTextRun{
@@ -427,53 +481,174 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 		}
 	}
 	for _, p := range opts.Paragraph {
-		u := "none"
-		font := &Font{}
-		if p.Font != nil {
-			font = p.Font
+		shape.TxBody.P = append(shape.TxBody.P, newShapeParagraph(p))
+	}
+	return &shape, err
+}
+
+// setShapeFill provides a function to set the gradient fill of the shape
+// properties by given fill settings. The shading styles of the gradient fill
+// are the same as the 'Fill.Shading' of the cell style.
+func setShapeFill(spPr *xlsxSpPr, fill Fill) error {
+	if fill.Type != "gradient" {
+		return nil
+	}
+	if len(fill.Color) != 2 || fill.Shading < 0 || fill.Shading > 16 {
+		return ErrParameterInvalid
+	}
+	colors := []string{
+		strings.ReplaceAll(strings.ToUpper(fill.Color[0]), "#", ""),
+		strings.ReplaceAll(strings.ToUpper(fill.Color[1]), "#", ""),
+	}
+	gradFill := xlsxGradFill{RotWithShape: true}
+	stops := [][]string{colors, {colors[1], colors[0]}, {colors[0], colors[1], colors[0]}}[fill.Shading%3]
+	if fill.Shading >= 12 {
+		stops = colors
+		rect := map[int]*xlsxSrcRect{
+			12: {R: 100000, B: 100000}, 13: {L: 100000, B: 100000},
+			14: {T: 100000, R: 100000}, 15: {L: 100000, T: 100000},
+			16: {L: 50000, T: 50000, R: 50000, B: 50000},
+		}[fill.Shading]
+		gradFill.Path = &xlsxPath{Path: "rect", FillToRect: rect}
+	} else {
+		gradFill.Lin = &xlsxLin{Ang: []int{5400000, 0, 18900000, 2700000}[fill.Shading/3]}
+	}
+	for i, color := range stops {
+		gradFill.GsLst = append(gradFill.GsLst, xlsxGs{
+			Pos: i * 100000 / (len(stops) - 1), SrgbClr: &xlsxSrgbClr{Val: color},
+		})
+	}
+	spPr.GradFill = &gradFill
+	return nil
+}
+
+// extractShapeFill provides a function to extract the gradient fill settings
+// of the shape by given decoded shape properties.
+func extractShapeFill(spPr *decodeSpPr) (Fill, bool) {
+	gradFill := spPr.GradFill
+	if gradFill == nil || len(gradFill.GsLst) < 2 {
+		return Fill{}, false
+	}
+	var colors []string
+	for _, gs := range gradFill.GsLst[:2] {
+		var color string
+		if gs.SrgbClr != nil {
+			color = gs.SrgbClr.Val
 		}
-		if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
-			u = supportedDrawingUnderlineTypes[idx]
+		colors = append(colors, color)
+	}
+	fill := Fill{Type: "gradient", Color: colors}
+	if len(gradFill.GsLst) > 2 {
+		fill.Shading = 2
+	}
+	if gradFill.Lin != nil {
+		for i, ang := range []int{5400000, 0, 18900000, 2700000} {
+			if gradFill.Lin.Ang == ang {
+				fill.Shading += i * 3
+			}
 		}
-		text := p.Text
-		if text == "" {
-			text = " "
+		return fill, true
+	}
+	fill.Shading = 16
+	if rect := gradFill.Path; rect != nil && rect.FillToRect != nil {
+		for shading, corner := range map[int]xlsxSrcRect{
+			12: {R: 100000, B: 100000}, 13: {L: 100000, B: 100000},
+			14: {T: 100000, R: 100000}, 15: {L: 100000, T: 100000},
+		} {
+			if *rect.FillToRect == corner {
+				fill.Shading = shading
+			}
 		}
-		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       font.Italic,
-					B:       font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      font.Size * 100,
-					Latin:   &xlsxCTTextFont{Typeface: font.Family},
-				},
-				T: text,
-			},
-			EndParaRPr: &aEndParaRPr{
-				Lang: "en-US",
-			},
+	}
+	return fill, true
+}
+
+// setShapeProperties provides a function to set the rotation, flip and
+// shadow effect of the shape properties by given graphic options.
+func setShapeProperties(spPr *xlsxSpPr, opts *GraphicOptions) error {
+	spPr.Xfrm.Rot = (opts.Rotation%360 + 360) % 360 * 60000
+	spPr.Xfrm.FlipH, spPr.Xfrm.FlipV = opts.FlipHorizontal, opts.FlipVertical
+	if opts.Shadow != nil {
+		if opts.Shadow.Transparency < 0 || opts.Shadow.Transparency > 100 {
+			return ErrParameterInvalid
 		}
-		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
-		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
-					Val: stringPtr(srgbClr),
-				},
+		color := "000000"
+		if opts.Shadow.Color != "" {
+			color = strings.ReplaceAll(strings.ToUpper(opts.Shadow.Color), "#", "")
+		}
+		spPr.EffectLst = &xlsxEffectList{OuterShdw: &xlsxOuterShadow{
+			BlurRad: int(opts.Shadow.Blur * 12700),
+			Dist:    int(opts.Shadow.Distance * 12700),
+			Dir:     (opts.Shadow.Angle%360 + 360) % 360 * 60000,
+			Algn:    "ctr",
+			SrgbClr: &xlsxSrgbClr{Val: color, Alpha: &attrValInt{Val: intPtr((100 - opts.Shadow.Transparency) * 1000)}},
+		}}
+	}
+	return nil
+}
+
+// extractShapeProperties provides a function to extract the rotation, flip
+// and shadow effect settings by given decoded shape properties.
+func extractShapeProperties(spPr *decodeSpPr, opts *GraphicOptions) {
+	opts.Rotation = spPr.Xfrm.Rot / 60000
+	opts.FlipHorizontal, opts.FlipVertical = spPr.Xfrm.FlipH, spPr.Xfrm.FlipV
+	if spPr.EffectLst != nil && spPr.EffectLst.OuterShdw != nil {
+		shadow := spPr.EffectLst.OuterShdw
+		opts.Shadow = &PictureShadow{
+			Blur:     float64(shadow.BlurRad) / 12700,
+			Distance: float64(shadow.Dist) / 12700,
+			Angle:    shadow.Dir / 60000,
+		}
+		if shadow.SrgbClr != nil {
+			opts.Shadow.Color = "#" + shadow.SrgbClr.Val
+			if shadow.SrgbClr.Alpha != nil && shadow.SrgbClr.Alpha.Val != nil {
+				opts.Shadow.Transparency = 100 - *shadow.SrgbClr.Alpha.Val/1000
 			}
 		}
-		shape.TxBody.P = append(shape.TxBody.P, paragraph)
 	}
-	twoCellAnchor.Sp = &shape
-	twoCellAnchor.ClientData = &xdrClientData{
-		FLocksWithSheet:  *opts.Format.Locked,
-		FPrintsWithSheet: *opts.Format.PrintObject,
+}
+
+// newShapeParagraph provides a function to create a paragraph of the shape
+// text body by given rich text run.
+func newShapeParagraph(p RichTextRun) *aP {
+	u := "none"
+	font := &Font{}
+	if p.Font != nil {
+		font = p.Font
 	}
-	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
-	f.Drawings.Store(drawingXML, content)
-	return err
+	if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
+		u = supportedDrawingUnderlineTypes[idx]
+	}
+	text := p.Text
+	if text == "" {
+		text = " "
+	}
+	paragraph := &aP{
+		R: &aR{
+			RPr: aRPr{
+				I:       font.Italic,
+				B:       font.Bold,
+				Lang:    "en-US",
+				AltLang: "en-US",
+				U:       u,
+				Sz:      font.Size * 100,
+				Latin:   &xlsxCTTextFont{Typeface: font.Family},
+			},
+			T: text,
+		},
+		EndParaRPr: &aEndParaRPr{
+			Lang: "en-US",
+		},
+	}
+	srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
+	if len(srgbClr) == 6 {
+		paragraph.R.RPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{
+				Val: stringPtr(srgbClr),
+			},
+		}
+	}
+	return paragraph
 }
 
 // setShapeRef provides a function to set color with hex model by given actual
@@ -496,3 +671,759 @@ func setShapeRef(color string, i int) *aRef {
 		},
 	}
 }
+
+// AddGroupShape provides the method to add a group shape, which contains
+// shapes, text boxes and pictures grouped together in a sheet by given
+// worksheet name and group shape format set. The 'Format' of the group shape
+// specifies the position, scale, positioning and print settings of the
+// group, and the 'OffsetX' and 'OffsetY' of the shapes and pictures in the
+// group specifies the position relative to the upper left corner of the group
+// in pixels. For example, add a group which contains a text box and a picture
+// in Sheet1:
+//
+//	file, err := os.ReadFile("logo.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddGroupShape("Sheet1", &excelize.GroupShape{
+//	    Cell: "B2",
+//	    Name: "Header",
+//	    Shapes: []excelize.Shape{
+//	        {
+//	            Type:      "rect",
+//	            Width:     200,
+//	            Height:    60,
+//	            Format:    excelize.GraphicOptions{OffsetX: 70},
+//	            Paragraph: []excelize.RichTextRun{{Text: "Company Name"}},
+//	        },
+//	    },
+//	    Pictures: []excelize.Picture{
+//	        {Extension: ".png", File: file, Format: &excelize.GraphicOptions{ScaleX: 0.5, ScaleY: 0.5}},
+//	    },
+//	})
+func (f *File) AddGroupShape(sheet string, opts *GroupShape) error {
+	if opts == nil || len(opts.Shapes)+len(opts.Pictures) == 0 {
+		return ErrParameterInvalid
+	}
+	format := parseGraphicOptions(&opts.Format)
+	if format.Positioning != "" && inStrSlice(supportedPositioning, format.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+	ws.mu.Unlock()
+	content, cNvPrID, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	name := opts.Name
+	if name == "" {
+		name = "Group " + strconv.Itoa(cNvPrID)
+	}
+	grpSp := xdrGrpSp{
+		NvGrpSpPr: &xdrNvGrpSpPr{
+			CNvPr:      &xlsxCNvPr{ID: cNvPrID, Name: name},
+			CNvGrpSpPr: &xlsxInnerXML{},
+		},
+		GrpSpPr: &xdrGrpSpPr{},
+	}
+	var width, height int
+	setChildXfrm := func(xfrm *xlsxXfrm, x, y, w, h int) {
+		xfrm.Off, xfrm.Ext = xlsxOff{X: x * EMU, Y: y * EMU}, aExt{Cx: w * EMU, Cy: h * EMU}
+		if x+w > width {
+			width = x + w
+		}
+		if y+h > height {
+			height = y + h
+		}
+	}
+	for i := range opts.Shapes {
+		shapeOpts := opts.Shapes[i]
+		options, err := parseShapeOptions(&shapeOpts)
+		if err != nil {
+			return err
+		}
+		cNvPrID++
+		sp, err := f.newShape(cNvPrID, options)
+		if err != nil {
+			return err
+		}
+		setChildXfrm(&sp.SpPr.Xfrm, options.Format.OffsetX, options.Format.OffsetY,
+			int(float64(options.Width)*options.Format.ScaleX), int(float64(options.Height)*options.Format.ScaleY))
+		grpSp.Sp = append(grpSp.Sp, sp)
+	}
+	for i := range opts.Pictures {
+		pic := opts.Pictures[i]
+		ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
+		if !ok {
+			return ErrImgExt
+		}
+		var picOpts GraphicOptions
+		if pic.Format != nil {
+			picOpts = *pic.Format
+		}
+		options := parseGraphicOptions(&picOpts)
+		img, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
+		if err != nil {
+			return err
+		}
+		rID := f.addDrawingImageRels(drawingRels, ".."+strings.TrimPrefix(f.addMedia(pic.File, ext), "xl"))
+		cNvPrID++
+		xPic, err := f.newPicture(cNvPrID, rID, 0, ext, options)
+		if err != nil {
+			return err
+		}
+		setChildXfrm(&xPic.SpPr.Xfrm, options.OffsetX, options.OffsetY,
+			int(float64(img.Width)*options.ScaleX), int(float64(img.Height)*options.ScaleY))
+		grpSp.Pic = append(grpSp.Pic, xPic)
+	}
+	grpSp.GrpSpPr.Xfrm = xlsxGrpXfrm{
+		Ext:   aExt{Cx: int(float64(width)*format.ScaleX) * EMU, Cy: int(float64(height)*format.ScaleY) * EMU},
+		ChExt: aExt{Cx: width * EMU, Cy: height * EMU},
+	}
+	_, twoCellAnchor, _, err := f.twoCellAnchorShape(sheet, drawingXML, opts.Cell, uint(width), uint(height), *format)
+	if err != nil {
+		return err
+	}
+	twoCellAnchor.GrpSp = &grpSp
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *format.Locked,
+		FPrintsWithSheet: *format.PrintObject,
+	}
+	content.mu.Lock()
+	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+	// Skip the object IDs used by the shapes and pictures within the group.
+	content.objectIDOffset = cNvPrID - len(content.OneCellAnchor) - len(content.TwoCellAnchor) - 1
+	content.mu.Unlock()
+	f.Drawings.Store(drawingXML, content)
+	if err = f.addContentTypePart(drawingID, "drawings"); err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// AddConnector provides the method to add a connector shape between two
+// shapes in a sheet by given worksheet name and connector format set. The
+// 'From' and 'To' specifies the name or the anchor cell reference of the
+// shapes to be connected, which should be two different shapes in the same
+// worksheet, the shapes within the group shapes can't be connected. The
+// connector starts from the side of the 'From' shape facing the 'To' shape,
+// and ends at the facing side of the 'To' shape. The 'Format' of the
+// connector supports the positioning, locked, print object and shadow
+// settings. For example, connect the shapes "Start" and "End" with an elbow
+// connector and an arrowhead at the end in Sheet1:
+//
+//	err := f.AddConnector("Sheet1", &excelize.Connector{
+//	    Type:     "bentConnector3",
+//	    From:     "Start",
+//	    To:       "End",
+//	    Line:     excelize.ShapeLine{Color: "4286F4"},
+//	    EndArrow: "triangle",
+//	})
+//
+// The following shows the type of connector shape supported by excelize:
+//
+//	straightConnector1 (default)
+//	bentConnector2
+//	bentConnector3
+//	bentConnector4
+//	bentConnector5
+//	curvedConnector2
+//	curvedConnector3
+//	curvedConnector4
+//	curvedConnector5
+//
+// The following shows the type of arrowheads of the 'BeginArrow' and
+// 'EndArrow' supported by excelize:
+//
+//	none (default)
+//	triangle
+//	stealth
+//	diamond
+//	oval
+//	arrow
+func (f *File) AddConnector(sheet string, opts *Connector) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	if opts.From == "" || opts.To == "" {
+		return ErrParameterRequired
+	}
+	connType := opts.Type
+	if connType == "" {
+		connType = "straightConnector1"
+	}
+	if inStrSlice(supportedDrawingConnectorTypes, connType, true) == -1 {
+		return ErrParameterInvalid
+	}
+	for _, arrow := range []string{opts.BeginArrow, opts.EndArrow} {
+		if arrow != "" && inStrSlice(supportedDrawingLineEndTypes, arrow, true) == -1 {
+			return ErrParameterInvalid
+		}
+	}
+	format := parseGraphicOptions(&opts.Format)
+	if format.Positioning != "" && inStrSlice(supportedPositioning, format.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
+	var start, end *decodeCellAnchor
+	if err := f.rangeDrawingShapes(sheet, func(_ *xdrCellAnchor, anchor *decodeCellAnchor) error {
+		if anchor.Sp == nil {
+			return nil
+		}
+		if start == nil && matchShape(anchor, opts.From) {
+			start = anchor
+		}
+		if end == nil && matchShape(anchor, opts.To) {
+			end = anchor
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if start == nil {
+		return newNoExistShapeError(opts.From)
+	}
+	if end == nil {
+		return newNoExistShapeError(opts.To)
+	}
+	if start == end {
+		return ErrParameterInvalid
+	}
+	x1, y1, stIdx, x2, y2, endIdx := f.getConnectionSites(sheet, start, end)
+	x, y, w, h := x1, y1, x2-x1, y2-y1
+	if x2 < x1 {
+		x, w = x2, x1-x2
+	}
+	if y2 < y1 {
+		y, h = y2, y1-y2
+	}
+	_, _, colEnd, rowEnd, colOff, rowOff := f.positionObjectPixels(sheet, 1, 1, x, y, w, h)
+	colIdx, rowIdx, _, _, fromColOff, fromRowOff := f.positionObjectPixels(sheet, 1, 1, x, y, 0, 0)
+	drawingXML, err := f.getSheetDrawingXML(sheet)
+	if err != nil {
+		return err
+	}
+	content, cNvPrID, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	name := opts.Name
+	if name == "" {
+		name = "Connector " + strconv.Itoa(cNvPrID)
+	}
+	color, lineWidth := "000000", float64(defaultShapeLineWidth)
+	if opts.Line.Color != "" {
+		color = strings.ReplaceAll(strings.ToUpper(opts.Line.Color), "#", "")
+	}
+	if opts.Line.Width != nil {
+		lineWidth = *opts.Line.Width
+	}
+	cxnSp := xdrCxnSp{
+		NvCxnSpPr: &xdrNvCxnSpPr{
+			CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: name},
+			CNvCxnSpPr: &xdrCNvCxnSpPr{
+				StCxn:  &xlsxConnection{ID: start.Sp.NvSpPr.CNvPr.ID, Idx: stIdx},
+				EndCxn: &xlsxConnection{ID: end.Sp.NvSpPr.CNvPr.ID, Idx: endIdx},
+			},
+		},
+		SpPr: &xlsxSpPr{
+			PrstGeom: xlsxPrstGeom{Prst: connType},
+			Ln: xlsxLineProperties{
+				W:         f.ptToEMUs(lineWidth),
+				SolidFill: &xlsxInnerXML{Content: fmt.Sprintf(`<a:srgbClr val="%s"/>`, color)},
+			},
+		},
+		Style: &xdrStyle{
+			LnRef:     &aRef{Idx: 1, SchemeClr: &attrValString{Val: stringPtr("accent1")}},
+			FillRef:   setShapeRef("", 0),
+			EffectRef: setShapeRef("", 0),
+			FontRef:   &aFontRef{Idx: "minor", SchemeClr: &attrValString{Val: stringPtr("tx1")}},
+		},
+	}
+	if opts.BeginArrow != "" {
+		cxnSp.SpPr.Ln.HeadEnd = &xlsxLineEnd{Type: opts.BeginArrow}
+	}
+	if opts.EndArrow != "" {
+		cxnSp.SpPr.Ln.TailEnd = &xlsxLineEnd{Type: opts.EndArrow}
+	}
+	if err = setShapeProperties(cxnSp.SpPr, format); err != nil {
+		return err
+	}
+	cxnSp.SpPr.Xfrm.FlipH, cxnSp.SpPr.Xfrm.FlipV = x2 < x1, y2 < y1
+	cxnSp.SpPr.Xfrm.Off = xlsxOff{X: x * EMU, Y: y * EMU}
+	cxnSp.SpPr.Xfrm.Ext = aExt{Cx: w * EMU, Cy: h * EMU}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &xdrCellAnchor{
+		EditAs: format.Positioning,
+		From:   &xlsxFrom{Col: colIdx, ColOff: fromColOff * EMU, Row: rowIdx, RowOff: fromRowOff * EMU},
+		To:     &xlsxTo{Col: colEnd, ColOff: colOff * EMU, Row: rowEnd, RowOff: rowOff * EMU},
+		CxnSp:  &cxnSp,
+		ClientData: &xdrClientData{
+			FLocksWithSheet:  *format.Locked,
+			FPrintsWithSheet: *format.PrintObject,
+		},
+	})
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// getConnectionSites provides a function to get the start and end points in
+// pixels relative to the upper left corner of the worksheet, and the
+// connection site index of the connector by given worksheet name and the
+// connected shapes. The connection site index of the top, left, bottom and
+// right side of the preset geometry are 0, 1, 2 and 3.
+func (f *File) getConnectionSites(sheet string, start, end *decodeCellAnchor) (int, int, int, int, int, int) {
+	sx, sy, sw, sh := f.getDrawingAnchorRect(sheet, start)
+	ex, ey, ew, eh := f.getDrawingAnchorRect(sheet, end)
+	switch {
+	case ex >= sx+sw:
+		return sx + sw, sy + sh/2, 3, ex, ey + eh/2, 1
+	case ex+ew <= sx:
+		return sx, sy + sh/2, 1, ex + ew, ey + eh/2, 3
+	case ey >= sy+sh:
+		return sx + sw/2, sy + sh, 2, ex + ew/2, ey, 0
+	default:
+		return sx + sw/2, sy, 0, ex + ew/2, ey + eh, 2
+	}
+}
+
+// getDrawingAnchorRect provides a function to get the position and size in
+// pixels relative to the upper left corner of the worksheet by given
+// worksheet name and the decoded cell anchor of the shape.
+func (f *File) getDrawingAnchorRect(sheet string, anchor *decodeCellAnchor) (int, int, int, int) {
+	position := func(col, colOff, row, rowOff int) (int, int) {
+		x, y := colOff/EMU, rowOff/EMU
+		for c := 1; c <= col; c++ {
+			x += f.getColWidth(sheet, c)
+		}
+		for r := 1; r <= row; r++ {
+			y += f.getRowHeight(sheet, r)
+		}
+		return x, y
+	}
+	x, y := position(anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff)
+	if anchor.To == nil {
+		var w, h int
+		if anchor.Sp.SpPr != nil {
+			w, h = anchor.Sp.SpPr.Xfrm.Ext.Cx/EMU, anchor.Sp.SpPr.Xfrm.Ext.Cy/EMU
+		}
+		return x, y, w, h
+	}
+	x2, y2 := position(anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff)
+	return x, y, x2 - x, y2 - y
+}
+
+// getDrawingMaxObjectID provides a function to get the maximum ID of the
+// drawing objects, including the objects within the group shapes, by given
+// drawing part.
+func getDrawingMaxObjectID(wsDr *xlsxWsDr) int {
+	var maxID int
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+		for _, anchor := range anchors {
+			output, err := xml.Marshal(anchor)
+			if err != nil {
+				continue
+			}
+			d := xml.NewDecoder(bytes.NewReader(output))
+			for token, err := d.RawToken(); err == nil; token, err = d.RawToken() {
+				if element, ok := token.(xml.StartElement); ok && element.Name.Local == "cNvPr" {
+					for _, attr := range element.Attr {
+						if id, _ := strconv.Atoi(attr.Value); attr.Name.Local == "id" && id > maxID {
+							maxID = id
+						}
+					}
+				}
+			}
+		}
+	}
+	return maxID
+}
+
+// GetShapes provides a function to get all shapes and text boxes in a
+// worksheet by given worksheet name. The anchor cell, name, preset geometry
+// type, fill and line color, and the text paragraphs of each shape will be
+// returned. Each paragraph of the shape text body is returned as a rich text
+// run, the text of the paragraph line breaks are returned as "\n", and the
+// font of the paragraph is the font of its first text run. The shapes within
+// the group shapes will be returned with the anchor cell of the group, and
+// the offsets relative to the upper left corner of the group. For example, get
+// all shapes in Sheet1:
+//
+//	shapes, err := f.GetShapes("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, shape := range shapes {
+//	    fmt.Println(shape.Cell, shape.Name, shape.Type)
+//	}
+func (f *File) GetShapes(sheet string) ([]Shape, error) {
+	var shapes []Shape
+	err := f.rangeDrawingShapes(sheet, func(_ *xdrCellAnchor, anchor *decodeCellAnchor) error {
+		if anchor.Sp != nil {
+			shapes = append(shapes, extractShape(anchor))
+			return nil
+		}
+		shapes = append(shapes, extractGroupShapes(anchor, anchor.GrpSp)...)
+		return nil
+	})
+	return shapes, err
+}
+
+// extractGroupShapes provides a function to extract the shapes within the
+// group shape and the nested group shapes by given decoded cell anchor and
+// group shape. The anchor cell of the shapes is the anchor cell of the group,
+// and the offsets of the shapes are relative to the upper left corner of the
+// group.
+func extractGroupShapes(anchor *decodeCellAnchor, grpSp *decodeGrpSp) []Shape {
+	var shapes []Shape
+	for _, sp := range grpSp.Sp {
+		child := *anchor
+		child.Sp, child.GrpSp = sp, nil
+		shape := extractShape(&child)
+		shape.Format.OffsetX, shape.Format.OffsetY = 0, 0
+		if sp.SpPr != nil {
+			shape.Format.OffsetX, shape.Format.OffsetY = sp.SpPr.Xfrm.Off.X/EMU, sp.SpPr.Xfrm.Off.Y/EMU
+		}
+		shapes = append(shapes, shape)
+	}
+	for _, nested := range grpSp.GrpSp {
+		shapes = append(shapes, extractGroupShapes(anchor, nested)...)
+	}
+	return shapes
+}
+
+// SetShapeText provides a function to replace the text paragraphs of the
+// shapes or text boxes by given worksheet name, shape name or anchor cell
+// reference, and the paragraphs. The shape will be matched by name at first,
+// or by the cell reference of the shape anchor. The body properties and list
+// styles of the existing text body will be kept. If the font of a paragraph
+// is not specified, the paragraph properties and run properties of the
+// existing paragraph at the same position, or the last existing paragraph
+// will be used. The line breaks "\n" in the text will be set as the line
+// break elements in the paragraph. For example, fill in the text box named
+// "Title" in Sheet1:
+//
+//	err := f.SetShapeText("Sheet1", "Title", []excelize.RichTextRun{
+//	    {Text: "Quarterly Report"},
+//	})
+func (f *File) SetShapeText(sheet, shape string, paragraph []RichTextRun) error {
+	var found bool
+	err := f.rangeDrawingShapes(sheet, func(cellAnchor *xdrCellAnchor, anchor *decodeCellAnchor) error {
+		if anchor.Sp == nil || !matchShape(anchor, shape) {
+			return nil
+		}
+		found = true
+		content, err := f.setShapeTextBody(anchor.Content, paragraph)
+		if err != nil {
+			return err
+		}
+		*cellAnchor = xdrCellAnchor{EditAs: anchor.EditAs, GraphicFrame: content}
+		return err
+	})
+	if err == nil && !found {
+		err = newNoExistShapeError(shape)
+	}
+	return err
+}
+
+// DeleteShape provides a function to delete the shapes or text boxes by given
+// worksheet name, shape name or anchor cell reference. The shape will be
+// matched by name at first, or by the cell reference of the shape anchor, all
+// matched shapes will be deleted. The group shape will be matched by the name
+// of the group or the cell reference of the group anchor, and be deleted with
+// all shapes and pictures within it, the shapes within the group shapes can't
+// be deleted separately. For example, delete the shape named "Shape 2" in
+// Sheet1:
+//
+//	err := f.DeleteShape("Sheet1", "Shape 2")
+func (f *File) DeleteShape(sheet, shape string) error {
+	deleted := map[*xdrCellAnchor]bool{}
+	err := f.rangeDrawingShapes(sheet, func(cellAnchor *xdrCellAnchor, anchor *decodeCellAnchor) error {
+		if matchShape(anchor, shape) {
+			deleted[cellAnchor] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(deleted) == 0 {
+		return newNoExistShapeError(shape)
+	}
+	drawingXML, _ := f.getSheetDrawingXML(sheet)
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	filter := func(anchors []*xdrCellAnchor) []*xdrCellAnchor {
+		var result []*xdrCellAnchor
+		for _, anchor := range anchors {
+			if !deleted[anchor] {
+				result = append(result, anchor)
+			}
+		}
+		return result
+	}
+	wsDr.OneCellAnchor = filter(wsDr.OneCellAnchor)
+	wsDr.TwoCellAnchor = filter(wsDr.TwoCellAnchor)
+	f.Drawings.Store(drawingXML, wsDr)
+	return err
+}
+
+// getSheetDrawingXML provides a function to get the drawing part path of the
+// worksheet by given worksheet name, returns empty if the worksheet doesn't
+// contain any drawing object.
+func (f *File) getSheetDrawingXML(sheet string) (string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil || ws.Drawing == nil {
+		return "", err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	return strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/"), err
+}
+
+// rangeDrawingShapes provides a function to iterate the shapes in the drawing
+// part of the worksheet by given worksheet name and callback function. The
+// callback function receives the cell anchor of the shape and the decoded
+// cell anchor, which inner XML content of the anchor is always available,
+// regardless of whether the anchor was loaded from the workbook or added by
+// the current session. The group shapes are included, and the shape of the
+// decoded cell anchor is nil for the group shape.
+func (f *File) rangeDrawingShapes(sheet string, fn func(cellAnchor *xdrCellAnchor, anchor *decodeCellAnchor) error) error {
+	drawingXML, err := f.getSheetDrawingXML(sheet)
+	if err != nil || drawingXML == "" {
+		return err
+	}
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.TwoCellAnchor, wsDr.OneCellAnchor} {
+		for _, cellAnchor := range anchors {
+			output, err := xml.Marshal(cellAnchor)
+			if err != nil {
+				return err
+			}
+			var anchor decodeCellAnchor
+			if err = f.xmlNewDecoder(bytes.NewReader(output)).Decode(&anchor); err != nil && err != io.EOF {
+				return err
+			}
+			if anchor.From == nil || (anchor.Sp == nil && anchor.GrpSp == nil) {
+				continue
+			}
+			if err = fn(cellAnchor, &anchor); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// matchShape returns if the given cell anchor of the shape matched with given
+// shape name or anchor cell reference.
+func matchShape(anchor *decodeCellAnchor, shape string) bool {
+	if sp := anchor.Sp; sp != nil && sp.NvSpPr != nil && sp.NvSpPr.CNvPr != nil && sp.NvSpPr.CNvPr.Name == shape {
+		return true
+	}
+	if grpSp := anchor.GrpSp; grpSp != nil && grpSp.CNvPr != nil && grpSp.CNvPr.Name == shape {
+		return true
+	}
+	cell, err := CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+	return err == nil && strings.EqualFold(cell, strings.ReplaceAll(shape, "$", ""))
+}
+
+// extractShape provides a function to extract the shape settings by given
+// decoded cell anchor.
+func extractShape(anchor *decodeCellAnchor) Shape {
+	sp := anchor.Sp
+	shape := Shape{
+		Macro: sp.Macro,
+		Format: GraphicOptions{
+			OffsetX:     anchor.From.ColOff / EMU,
+			OffsetY:     anchor.From.RowOff / EMU,
+			Positioning: anchor.EditAs,
+		},
+		Line: ShapeLine{Width: float64Ptr(defaultShapeLineWidth)},
+	}
+	shape.Cell, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+	if sp.NvSpPr != nil && sp.NvSpPr.CNvPr != nil {
+		shape.Name = sp.NvSpPr.CNvPr.Name
+	}
+	if anchor.ClientData != nil {
+		shape.Format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+		shape.Format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+	}
+	var fillColor string
+	if sp.Style != nil {
+		if sp.Style.FillRef != nil && sp.Style.FillRef.SrgbClr != nil {
+			fillColor = sp.Style.FillRef.SrgbClr.Val
+		}
+		if sp.Style.LnRef != nil && sp.Style.LnRef.SrgbClr != nil {
+			shape.Line.Color = sp.Style.LnRef.SrgbClr.Val
+		}
+	}
+	if sp.SpPr != nil {
+		shape.Type = sp.SpPr.PrstGeom.Prst
+		shape.Width = uint(sp.SpPr.Xfrm.Ext.Cx / EMU)
+		shape.Height = uint(sp.SpPr.Xfrm.Ext.Cy / EMU)
+		if sp.SpPr.SolidFill != nil && sp.SpPr.SolidFill.SrgbClr != nil {
+			fillColor = sp.SpPr.SolidFill.SrgbClr.Val
+		}
+		if ln := sp.SpPr.Ln; ln != nil {
+			if ln.W > 0 {
+				shape.Line.Width = float64Ptr(float64(ln.W) / 12700)
+			}
+			if ln.SolidFill != nil && ln.SolidFill.SrgbClr != nil {
+				shape.Line.Color = ln.SolidFill.SrgbClr.Val
+			}
+		}
+	}
+	if fillColor != "" {
+		shape.Fill = Fill{Type: "pattern", Color: []string{fillColor}, Pattern: 1}
+	}
+	if sp.SpPr != nil {
+		if fill, ok := extractShapeFill(sp.SpPr); ok {
+			shape.Fill = fill
+		}
+		extractShapeProperties(sp.SpPr, &shape.Format)
+	}
+	if sp.TxBody != nil {
+		for _, p := range sp.TxBody.P {
+			shape.Paragraph = append(shape.Paragraph, extractShapeParagraph(p))
+		}
+	}
+	return shape
+}
+
+// extractShapeParagraph provides a function to extract the rich text run by
+// given decoded paragraph of the shape text body.
+func extractShapeParagraph(p decodeP) RichTextRun {
+	var run RichTextRun
+	for _, r := range p.Content {
+		switch r.XMLName.Local {
+		case "r", "fld":
+			run.Text += r.T
+			if run.Font != nil || r.RPr == nil {
+				continue
+			}
+			run.Font = &Font{
+				Bold:   r.RPr.B,
+				Italic: r.RPr.I,
+				Strike: r.RPr.Strike != "" && r.RPr.Strike != "noStrike",
+				Size:   r.RPr.Sz / 100,
+			}
+			if r.RPr.U != "none" {
+				run.Font.Underline = r.RPr.U
+			}
+			if r.RPr.Latin != nil {
+				run.Font.Family = r.RPr.Latin.Typeface
+			}
+			if r.RPr.SolidFill != nil && r.RPr.SolidFill.SrgbClr != nil {
+				run.Font.Color = r.RPr.SolidFill.SrgbClr.Val
+			}
+		case "br":
+			run.Text += "\n"
+		}
+	}
+	return run
+}
+
+// setShapeTextBody provides a function to replace the paragraphs of the shape
+// text body by given inner XML content of the cell anchor and paragraphs, and
+// returns the updated content. The line breaks in the text of the paragraphs
+// will be set as the line break elements.
+func (f *File) setShapeTextBody(content string, paragraph []RichTextRun) (string, error) {
+	var anchor decodeTextShapeAnchor
+	if err := f.xmlNewDecoder(strings.NewReader("<anchor>" + content + "</anchor>")).
+		Decode(&anchor); err != nil && err != io.EOF {
+		return content, err
+	}
+	if anchor.Sp == nil {
+		return content, ErrParameterInvalid
+	}
+	txBody := &xlsxTextBody{BodyPr: &xlsxInnerXMLAttrs{Attrs: []xml.Attr{
+		{Name: xml.Name{Local: "vertOverflow"}, Value: "clip"},
+		{Name: xml.Name{Local: "horzOverflow"}, Value: "clip"},
+		{Name: xml.Name{Local: "wrap"}, Value: "none"},
+		{Name: xml.Name{Local: "rtlCol"}, Value: "0"},
+		{Name: xml.Name{Local: "anchor"}, Value: "t"},
+	}}}
+	var templates []decodeTextP
+	if body := anchor.Sp.TxBody; body != nil {
+		if body.BodyPr != nil {
+			txBody.BodyPr = body.BodyPr
+		}
+		txBody.LstStyle, templates = body.LstStyle, body.P
+	}
+	for i, run := range paragraph {
+		var (
+			tmpl decodeTextP
+			rPr  interface{}
+			p    xlsxTextP
+		)
+		if len(templates) > 0 {
+			tmpl = templates[len(templates)-1]
+			if i < len(templates) {
+				tmpl = templates[i]
+			}
+		}
+		p.PPr = tmpl.PPr
+		if len(tmpl.R) > 0 && tmpl.R[0].RPr != nil {
+			rPr = tmpl.R[0].RPr
+		}
+		if run.Font != nil || rPr == nil {
+			shapeParagraph := newShapeParagraph(run)
+			rPr, p.EndParaRPr = &shapeParagraph.R.RPr, shapeParagraph.EndParaRPr
+		}
+		text := run.Text
+		if text == "" {
+			text = " "
+		}
+		for j, line := range strings.Split(text, "\n") {
+			if j > 0 {
+				p.Content = append(p.Content, xlsxTextRun{XMLName: xml.Name{Local: "a:br"}, RPr: rPr})
+			}
+			if line != "" {
+				p.Content = append(p.Content, xlsxTextRun{XMLName: xml.Name{Local: "a:r"}, RPr: rPr, T: stringPtr(line)})
+			}
+		}
+		txBody.P = append(txBody.P, p)
+	}
+	output, err := xml.Marshal(xlsxTextShapeAnchor{
+		Pos: anchor.Pos, From: anchor.From, To: anchor.To, Ext: anchor.Ext,
+		Sp: &xlsxTextSp{
+			Attrs: anchor.Sp.Attrs, NvSpPr: anchor.Sp.NvSpPr, SpPr: anchor.Sp.SpPr,
+			Style: anchor.Sp.Style, TxBody: txBody,
+		},
+		ClientData: anchor.ClientData,
+	})
+	if err != nil {
+		return content, err
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(string(output), "<xlsxTextShapeAnchor>"), "</xlsxTextShapeAnchor>"), err
+}
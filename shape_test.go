@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -113,3 +114,353 @@ func TestAddDrawingShape(t *testing.T) {
 		},
 	), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestGetShapes(t *testing.T) {
+	f := NewFile()
+	// Test get shapes on worksheet without drawing
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, shapes)
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1",
+		Name: "Title",
+		Type: "rect",
+		Line: ShapeLine{Color: "4286F4", Width: float64Ptr(1.2)},
+		Fill: Fill{Color: []string{"8EB9FF"}, Pattern: 1},
+		Paragraph: []RichTextRun{
+			{Text: "{{title}}", Font: &Font{Bold: true, Family: "Times New Roman", Size: 18, Color: "777777", Underline: "sng"}},
+			{Text: "{{subtitle}}", Font: &Font{Italic: true, Size: 11}},
+		},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "C3", Type: "ellipse"}))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, "A1", shapes[0].Cell)
+	assert.Equal(t, "Title", shapes[0].Name)
+	assert.Equal(t, "rect", shapes[0].Type)
+	assert.Equal(t, ShapeLine{Color: "4286F4", Width: float64Ptr(1.2)}, shapes[0].Line)
+	assert.Equal(t, []string{"8EB9FF"}, shapes[0].Fill.Color)
+	assert.Equal(t, []RichTextRun{
+		{Text: "{{title}}", Font: &Font{Bold: true, Family: "Times New Roman", Size: 18, Color: "777777", Underline: "sng"}},
+		{Text: "{{subtitle}}", Font: &Font{Italic: true, Size: 11}},
+	}, shapes[0].Paragraph)
+	assert.Equal(t, "C3", shapes[1].Cell)
+	assert.Equal(t, "Shape 4", shapes[1].Name)
+	assert.Equal(t, "ellipse", shapes[1].Type)
+	// Test get shapes with not exist worksheet
+	_, err = f.GetShapes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get shapes with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetShapes("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetShapeText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1",
+		Name: "Title",
+		Type: "rect",
+		Paragraph: []RichTextRun{
+			{Text: "{{title}}", Font: &Font{Bold: true, Size: 18, Color: "777777"}},
+		},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "D4", Type: "rect"}))
+	// Test set shape text by shape name, and inherit the existing font
+	assert.NoError(t, f.SetShapeText("Sheet1", "Title", []RichTextRun{
+		{Text: "Quarterly <Report>"},
+		{Text: "Q1 & Q2"},
+		{Text: "Summary", Font: &Font{Italic: true, Size: 10, Color: "FF0000"}},
+	}))
+	// Test set shape text by anchor cell reference
+	assert.NoError(t, f.SetShapeText("Sheet1", "$D$4", []RichTextRun{{Text: "Note"}}))
+	path := filepath.Join("test", "TestSetShapeText.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, []RichTextRun{
+		{Text: "Quarterly <Report>", Font: &Font{Bold: true, Size: 18, Color: "777777"}},
+		{Text: "Q1 & Q2", Font: &Font{Bold: true, Size: 18, Color: "777777"}},
+		{Text: "Summary", Font: &Font{Italic: true, Size: 10, Color: "FF0000"}},
+	}, shapes[0].Paragraph)
+	assert.Equal(t, "Note", shapes[1].Paragraph[0].Text)
+	// Test set shape text with line breaks
+	assert.NoError(t, f.SetShapeText("Sheet1", "$D$4", []RichTextRun{{Text: "Line1\nLine2"}}))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Line1\nLine2", shapes[1].Paragraph[0].Text)
+	// Test set shape text for the shape loaded from the workbook
+	assert.NoError(t, f.SetShapeText("Sheet1", "Title", []RichTextRun{{Text: "Annual Report"}}))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{{Text: "Annual Report", Font: &Font{Bold: true, Size: 18, Color: "777777"}}}, shapes[0].Paragraph)
+	// Test set shape text with not exist shape
+	assert.EqualError(t, f.SetShapeText("Sheet1", "Shape 10", nil), "shape Shape 10 does not exist")
+	// Test set shape text with not exist worksheet
+	assert.EqualError(t, f.SetShapeText("SheetN", "Title", nil), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetShapeTextBody(t *testing.T) {
+	// Test set text body for the shape without text body
+	f := NewFile()
+	content, err := f.setShapeTextBody(`<xdr:sp><xdr:spPr/></xdr:sp>`, []RichTextRun{{Text: "A"}})
+	assert.NoError(t, err)
+	assert.Contains(t, content, `<xdr:spPr></xdr:spPr><xdr:txBody><a:bodyPr`)
+	assert.Contains(t, content, `<a:t>A</a:t>`)
+	// Test set text body with line breaks
+	content, err = f.setShapeTextBody(`<xdr:sp><xdr:spPr/></xdr:sp>`, []RichTextRun{{Text: "A\nB"}})
+	assert.NoError(t, err)
+	assert.Contains(t, content, `<a:t>A</a:t></a:r><a:br>`)
+	assert.NotContains(t, content, "A\nB")
+	// Test set text body for the content without shape
+	_, err = f.setShapeTextBody(`<xdr:pic/>`, nil)
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test set text body with invalid content
+	_, err = f.setShapeTextBody(`<xdr:sp`, nil)
+	assert.Error(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteShape(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A1", Name: "Title", Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "B2", Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "C3", Type: "rect"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.DeleteShape("Sheet1", "Title"))
+	assert.NoError(t, f.DeleteShape("Sheet1", "B2"))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	assert.Equal(t, "C3", shapes[0].Cell)
+	// Test delete shape keeps the picture on the same anchor cell
+	pics, err := f.GetPictures("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	path := filepath.Join("test", "TestDeleteShape.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteShape("Sheet1", "C3"))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, shapes)
+	// Test delete shape with not exist shape
+	assert.EqualError(t, f.DeleteShape("Sheet1", "C3"), "shape C3 does not exist")
+	// Test delete shape with not exist worksheet
+	assert.EqualError(t, f.DeleteShape("SheetN", "C3"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeFormat(t *testing.T) {
+	f := NewFile()
+	shadow := &PictureShadow{Color: "#808080", Transparency: 60, Blur: 4, Distance: 3, Angle: 45}
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell:   "A1",
+		Type:   "rect",
+		Fill:   Fill{Type: "gradient", Color: []string{"#FFFFFF", "#4286F4"}, Shading: 4},
+		Format: GraphicOptions{Rotation: -90, FlipHorizontal: true, Shadow: shadow},
+	}))
+	for _, shading := range []int{0, 3, 8, 9, 13, 16} {
+		assert.NoError(t, f.AddShape("Sheet1", &Shape{
+			Cell: "D1", Type: "ellipse", Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "4286F4"}, Shading: shading},
+		}))
+	}
+	path := filepath.Join("test", "TestAddShapeFormat.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 7)
+	// The reversed gradient variant is returned with the reversed gradient colors
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"4286F4", "FFFFFF"}, Shading: 3}, shapes[0].Fill)
+	assert.Equal(t, 270, shapes[0].Format.Rotation)
+	assert.True(t, shapes[0].Format.FlipHorizontal)
+	assert.False(t, shapes[0].Format.FlipVertical)
+	assert.Equal(t, shadow, shapes[0].Format.Shadow)
+	for i, shading := range []int{0, 3, 8, 9, 13, 16} {
+		assert.Equal(t, shading, shapes[i+1].Fill.Shading)
+	}
+	// Test add shape with invalid gradient fill
+	for _, fill := range []Fill{
+		{Type: "gradient", Color: []string{"FFFFFF"}},
+		{Type: "gradient", Color: []string{"FFFFFF", "4286F4"}, Shading: 17},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect", Fill: fill}))
+	}
+	// Test add shape with invalid shadow transparency
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{
+		Cell: "A1", Type: "rect", Format: GraphicOptions{Shadow: &PictureShadow{Transparency: 101}},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddGroupShape(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H1", Type: "rect"}))
+	assert.NoError(t, f.AddGroupShape("Sheet1", &GroupShape{
+		Cell: "B2",
+		Name: "Header",
+		Shapes: []Shape{
+			{Type: "rect", Width: 200, Height: 60, Format: GraphicOptions{OffsetX: 70}, Paragraph: []RichTextRun{{Text: "Company"}}},
+			{Type: "ellipse", Width: 40, Height: 40, Format: GraphicOptions{OffsetX: 280, OffsetY: 10}},
+		},
+		Pictures: []Picture{
+			{Extension: ".png", File: file, Format: &GraphicOptions{ScaleX: 0.1, ScaleY: 0.1, AltText: "Logo"}},
+			{Extension: ".png", File: file, Format: &GraphicOptions{OffsetY: 70, ScaleX: 0.1, ScaleY: 0.1}},
+		},
+		Format: GraphicOptions{Positioning: "oneCell", ScaleX: 2},
+	}))
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, wsDr.TwoCellAnchor, 2)
+	grpSp := wsDr.TwoCellAnchor[1].GrpSp
+	assert.Equal(t, "Header", grpSp.NvGrpSpPr.CNvPr.Name)
+	assert.Equal(t, 3, grpSp.NvGrpSpPr.CNvPr.ID)
+	assert.Len(t, grpSp.Sp, 2)
+	assert.Len(t, grpSp.Pic, 2)
+	assert.Equal(t, []int{4, 5}, []int{grpSp.Sp[0].NvSpPr.CNvPr.ID, grpSp.Sp[1].NvSpPr.CNvPr.ID})
+	assert.Equal(t, []int{6, 7}, []int{grpSp.Pic[0].NvPicPr.CNvPr.ID, grpSp.Pic[1].NvPicPr.CNvPr.ID})
+	assert.Equal(t, grpSp.Pic[0].BlipFill.Blip.Embed, grpSp.Pic[1].BlipFill.Blip.Embed)
+	assert.Equal(t, aExt{Cx: 320 * EMU, Cy: grpSp.GrpSpPr.Xfrm.ChExt.Cy}, grpSp.GrpSpPr.Xfrm.ChExt)
+	assert.Equal(t, 640*EMU, grpSp.GrpSpPr.Xfrm.Ext.Cx)
+	// Test add shape and connector after the group shape with unique object ID
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H20", Type: "rect"}))
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{From: "H1", To: "H20"}))
+	assert.Equal(t, "Shape 8", wsDr.TwoCellAnchor[2].Sp.NvSpPr.CNvPr.Name)
+	assert.Equal(t, 9, wsDr.TwoCellAnchor[3].CxnSp.NvCxnSpPr.CNvPr.ID)
+	// Test get the shapes within the group shape
+	checkShapes := func(f *File) []Shape {
+		shapes, err := f.GetShapes("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, shapes, 4)
+		assert.Equal(t, []string{"H1", "B2", "B2", "H20"}, []string{shapes[0].Cell, shapes[1].Cell, shapes[2].Cell, shapes[3].Cell})
+		assert.Equal(t, []string{"rect", "ellipse"}, []string{shapes[1].Type, shapes[2].Type})
+		assert.Equal(t, []int{70, 0, 280, 10}, []int{shapes[1].Format.OffsetX, shapes[1].Format.OffsetY, shapes[2].Format.OffsetX, shapes[2].Format.OffsetY})
+		assert.Equal(t, []uint{200, 60}, []uint{shapes[1].Width, shapes[1].Height})
+		assert.Equal(t, "Company", shapes[1].Paragraph[0].Text)
+		return shapes
+	}
+	shapes := checkShapes(f)
+	// Test add connector and set text with the group shape
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "Header", To: "H1"}), "shape Header does not exist")
+	assert.EqualError(t, f.SetShapeText("Sheet1", "Header", []RichTextRun{{Text: "Title"}}), "shape Header does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddGroupShape.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test delete the group shape loaded from the workbook
+	f, err = OpenFile(filepath.Join("test", "TestAddGroupShape.xlsx"))
+	assert.NoError(t, err)
+	checkShapes(f)
+	assert.Equal(t, newNoExistShapeError(shapes[1].Name), f.DeleteShape("Sheet1", shapes[1].Name))
+	assert.NoError(t, f.DeleteShape("Sheet1", "Header"))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, []string{"H1", "H20"}, []string{shapes[0].Cell, shapes[1].Cell})
+	// Test get the shapes within the nested group shapes
+	shapes = extractGroupShapes(&decodeCellAnchor{From: &decodeFrom{Col: 1, Row: 1}}, &decodeGrpSp{
+		GrpSp: []*decodeGrpSp{{Sp: []*decodeSp{{NvSpPr: &decodeNvSpPr{CNvPr: &decodeCNvPr{Name: "Shape"}}}}}},
+	})
+	assert.Len(t, shapes, 1)
+	assert.Equal(t, "B2", shapes[0].Cell)
+	assert.Equal(t, "Shape", shapes[0].Name)
+	// Test add group shape with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", &GroupShape{Cell: "A1"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", &GroupShape{
+		Cell: "A1", Shapes: []Shape{{Type: "rect"}}, Format: GraphicOptions{Positioning: "x"},
+	}))
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", &GroupShape{Cell: "A1", Shapes: []Shape{{}}}))
+	assert.Equal(t, ErrImgExt, f.AddGroupShape("Sheet1", &GroupShape{Cell: "A1", Pictures: []Picture{{Extension: ".txt"}}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", &GroupShape{
+		Cell: "A1", Pictures: []Picture{{Extension: ".png", File: file, Format: &GraphicOptions{Transparency: 101}}},
+	}))
+	assert.Equal(t, ErrParameterInvalid, f.AddGroupShape("Sheet1", &GroupShape{
+		Cell: "A1", Shapes: []Shape{{Type: "rect", Fill: Fill{Type: "gradient"}}},
+	}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		f.AddGroupShape("Sheet1", &GroupShape{Cell: "A", Shapes: []Shape{{Type: "rect"}}}))
+	assert.EqualError(t, f.AddGroupShape("SheetN", &GroupShape{Cell: "A1", Shapes: []Shape{{Type: "rect"}}}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test add group shape with unsupported charset drawing part
+	f = NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect"}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddGroupShape("Sheet1", &GroupShape{Cell: "A1", Shapes: []Shape{{Type: "rect"}}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddConnector(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "B2", Name: "Start", Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H2", Name: "Right", Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "B20", Name: "Below", Type: "rect"}))
+	for i, c := range []struct {
+		opts      *Connector
+		st, end   xlsxConnection
+		flipH     bool
+		flipV     bool
+		connType  string
+		tailArrow *xlsxLineEnd
+	}{
+		{&Connector{From: "Start", To: "Right", EndArrow: "triangle"}, xlsxConnection{ID: 2, Idx: 3}, xlsxConnection{ID: 3, Idx: 1}, false, false, "straightConnector1", &xlsxLineEnd{Type: "triangle"}},
+		{&Connector{From: "Right", To: "Start", Type: "bentConnector3"}, xlsxConnection{ID: 3, Idx: 1}, xlsxConnection{ID: 2, Idx: 3}, true, false, "bentConnector3", nil},
+		{&Connector{From: "Start", To: "B20"}, xlsxConnection{ID: 2, Idx: 2}, xlsxConnection{ID: 4, Idx: 0}, false, false, "straightConnector1", nil},
+		{&Connector{From: "Below", To: "Start"}, xlsxConnection{ID: 4, Idx: 0}, xlsxConnection{ID: 2, Idx: 2}, false, true, "straightConnector1", nil},
+	} {
+		assert.NoError(t, f.AddConnector("Sheet1", c.opts))
+		wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+		assert.NoError(t, err)
+		cxnSp := wsDr.TwoCellAnchor[len(wsDr.TwoCellAnchor)-1].CxnSp
+		assert.Equal(t, c.st, *cxnSp.NvCxnSpPr.CNvCxnSpPr.StCxn, i)
+		assert.Equal(t, c.end, *cxnSp.NvCxnSpPr.CNvCxnSpPr.EndCxn, i)
+		assert.Equal(t, c.flipH, cxnSp.SpPr.Xfrm.FlipH, i)
+		assert.Equal(t, c.flipV, cxnSp.SpPr.Xfrm.FlipV, i)
+		assert.Equal(t, c.connType, cxnSp.SpPr.PrstGeom.Prst, i)
+		assert.Equal(t, c.tailArrow, cxnSp.SpPr.Ln.TailEnd, i)
+	}
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{
+		Name: "Link", From: "Start", To: "Right", Line: ShapeLine{Color: "#4286F4", Width: float64Ptr(2)},
+		BeginArrow: "oval", EndArrow: "stealth", Format: GraphicOptions{Shadow: &PictureShadow{}},
+	}))
+	path := filepath.Join("test", "TestAddConnector.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	// Test add connector between the shapes loaded from the workbook
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Below", Type: "curvedConnector3"}))
+	// Test add connector with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", nil))
+	assert.Equal(t, ErrParameterRequired, f.AddConnector("Sheet1", &Connector{From: "Start"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Right", Type: "line"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Right", EndArrow: "x"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Right", Format: GraphicOptions{Positioning: "x"}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Right", Format: GraphicOptions{Shadow: &PictureShadow{Transparency: -1}}}))
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "X", To: "Right"}), "shape X does not exist")
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Y"}), "shape Y does not exist")
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "Start"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "Start", To: "B2"}))
+	assert.EqualError(t, f.AddConnector("SheetN", &Connector{From: "Start", To: "Right"}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
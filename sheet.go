@@ -136,45 +136,59 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
 // serialize structure.
 func (f *File) workSheetWriter() {
-	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
-	)
+	buffer := bytes.NewBuffer(nil)
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
-			sheet := ws.(*xlsxWorksheet)
-			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
-				_ = f.mergeOverlapCells(sheet)
-			}
-			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
-				f.mergeExpandedCols(sheet)
-			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
-			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
-					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
-			}
-			sheet.DecodeAlternateContent = nil
 			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
-			_, ok := f.checked.Load(p.(string))
-			if ok {
-				f.Sheet.Delete(p.(string))
-				f.checked.Delete(p.(string))
-			}
+			f.saveWorkSheet(p.(string), ws.(*xlsxWorksheet), buffer)
 			buffer.Reset()
 		}
 		return true
 	})
 }
 
+// saveWorkSheet provides a function to serialize the worksheet by given
+// worksheet part path into the package with the given buffer, and release
+// the checked worksheet in memory.
+func (f *File) saveWorkSheet(path string, ws *xlsxWorksheet, buffer *bytes.Buffer) {
+	f.prepareWorkSheet(path, ws)
+	_ = xml.NewEncoder(buffer).Encode(ws)
+	f.saveFileList(path, replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, buffer.Bytes())))
+	f.releaseWorkSheet(path)
+}
+
+// releaseWorkSheet provides a function to release the checked worksheet in
+// memory by given worksheet part path after it has been serialized into the
+// package.
+func (f *File) releaseWorkSheet(path string) {
+	if _, ok := f.checked.Load(path); ok {
+		f.Sheet.Delete(path)
+		f.checked.Delete(path)
+	}
+}
+
+// prepareWorkSheet provides a function to prepare the worksheet by given
+// worksheet part path before marshaling it.
+func (f *File) prepareWorkSheet(path string, ws *xlsxWorksheet) {
+	if ws.MergeCells != nil && len(ws.MergeCells.Cells) > 0 {
+		_ = f.mergeOverlapCells(ws)
+	}
+	if ws.Cols != nil && len(ws.Cols.Col) > 0 {
+		f.mergeExpandedCols(ws)
+	}
+	ws.SheetData.Row = trimRow(&ws.SheetData)
+	if ws.SheetPr != nil || ws.Drawing != nil || ws.Hyperlinks != nil || ws.Picture != nil || ws.TableParts != nil {
+		f.addNameSpaces(path, SourceRelationship)
+	}
+	if ws.DecodeAlternateContent != nil {
+		ws.AlternateContent = &xlsxAlternateContent{
+			Content: ws.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+		}
+	}
+	ws.DecodeAlternateContent = nil
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -486,6 +500,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.zipFiles.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -590,6 +607,7 @@ func (f *File) DeleteSheet(sheet string) error {
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
 		f.Pkg.Delete(rels)
+		f.zipFiles.Delete(sheetXML)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
 		f.xmlAttr.Delete(sheetXML)
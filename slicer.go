@@ -19,6 +19,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -51,19 +52,30 @@ import (
 // ItemDesc specifies descending (Z-A) item sorting, this setting is optional,
 // and the default setting is false (represents ascending).
 //
+// SelectedItems specifies the selected items of the slicer, the table or pivot
+// table will be filtered by the selected items when opening the workbook, this
+// setting is optional, and all items are selected by default. An error will be
+// returned if any selected item doesn't exist in the slicer field.
+//
 // Format specifies the format of the slicer, this setting is optional.
 type SlicerOptions struct {
-	Name          string
-	Cell          string
-	TableSheet    string
-	TableName     string
-	Caption       string
-	Macro         string
-	Width         uint
-	Height        uint
-	DisplayHeader *bool
-	ItemDesc      bool
-	Format        GraphicOptions
+	slicerXML       string
+	slicerCacheXML  string
+	slicerCacheName string
+	slicerSheetName string
+	slicerSheetRID  string
+	Name            string
+	Cell            string
+	TableSheet      string
+	TableName       string
+	Caption         string
+	Macro           string
+	Width           uint
+	Height          uint
+	DisplayHeader   *bool
+	ItemDesc        bool
+	SelectedItems   []string
+	Format          GraphicOptions
 }
 
 // AddSlicer function inserts a slicer by giving the worksheet name and slicer
@@ -90,6 +102,9 @@ func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
 	if err != nil {
 		return err
 	}
+	if err = f.checkSlicerItems(opts, table, pivotTable, colIdx); err != nil {
+		return err
+	}
 	extURI, ns := ExtURISlicerListX14, NameSpaceDrawingMLA14
 	if table != nil {
 		extURI = ExtURISlicerListX15
@@ -103,6 +118,11 @@ func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
 	if err != nil {
 		return err
 	}
+	if len(opts.SelectedItems) > 0 {
+		if err = f.setSlicerCacheItems(slicerCacheName, colIdx, opts, table, pivotTable); err != nil {
+			return err
+		}
+	}
 	slicerName := f.genSlicerName(opts.Name)
 	if err := f.addDrawingSlicer(sheet, slicerName, ns, opts); err != nil {
 		return err
@@ -146,26 +166,28 @@ func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
 	return opts, nil
 }
 
-// countSlicers provides a function to get slicer files count storage in the
-// folder xl/slicers.
+// countSlicers provides a function to get the maximum ID of the slicer files
+// storage in the folder xl/slicers.
 func (f *File) countSlicers() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/slicers/slicer") {
-			count++
-		}
-		return true
-	})
-	return count
+	return f.getMaxPartID("xl/slicers/slicer")
 }
 
-// countSlicerCache provides a function to get slicer cache files count storage
-// in the folder xl/SlicerCaches.
+// countSlicerCache provides a function to get the maximum ID of the slicer
+// cache files storage in the folder xl/SlicerCaches.
 func (f *File) countSlicerCache() int {
+	return f.getMaxPartID("xl/slicerCaches/slicerCache")
+}
+
+// getMaxPartID provides a function to get the maximum ID of the XML parts in
+// the package by given path prefix of the parts.
+func (f *File) getMaxPartID(prefix string) int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
-			count++
+		if !strings.HasPrefix(k.(string), prefix) {
+			return true
+		}
+		if id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k.(string), prefix), ".xml")); err == nil && id > count {
+			count = id
 		}
 		return true
 	})
@@ -349,8 +371,9 @@ func (f *File) genSlicerName(name string) string {
 	return slicerName
 }
 
-// genSlicerCacheName generates a unique slicer cache name by giving the slicer name.
-func (f *File) genSlicerCacheName(name string) string {
+// genSlicerCacheName generates a unique slicer or timeline cache name by giving
+// the prefix of the cache name and the field name.
+func (f *File) genSlicerCacheName(prefix, name string) string {
 	var (
 		cnt             int
 		definedNames    []string
@@ -372,7 +395,7 @@ func (f *File) genSlicerCacheName(name string) string {
 		}
 		slicerCacheName += "_"
 	}
-	slicerCacheName = fmt.Sprintf("Slicer_%s", slicerCacheName)
+	slicerCacheName = prefix + slicerCacheName
 	for {
 		tmp := slicerCacheName
 		if cnt > 0 {
@@ -427,7 +450,7 @@ func (f *File) setSlicerCache(sheet string, colIdx int, opts *SlicerOptions, tab
 	if ok {
 		return slicerCacheName, nil
 	}
-	slicerCacheName = f.genSlicerCacheName(opts.Name)
+	slicerCacheName = f.genSlicerCacheName("Slicer_", opts.Name)
 	return slicerCacheName, f.addSlicerCache(slicerCacheName, colIdx, opts, table, pivotTable)
 }
 
@@ -571,8 +594,8 @@ func (f *File) addPivotCacheSlicer(opts *PivotTableOptions) (int, error) {
 	return pivotCacheID, err
 }
 
-// addDrawingSlicer adds a slicer shape and fallback shape by giving the
-// worksheet name, slicer name, and slicer options.
+// addDrawingSlicer adds a slicer or timeline shape and fallback shape by giving
+// the worksheet name, slicer name, and slicer options.
 func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *SlicerOptions) error {
 	drawingID := f.countDrawings() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
@@ -600,7 +623,22 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 			},
 		},
 	}
+	fallbackText := []string{
+		"This shape represents a table slicer. Table slicers are not supported in this version of Excel.",
+		"If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used.",
+	}
+	if ns.Value == NameSpaceDrawingMLTimeSlicer.Value { // timeline
+		graphicFrame.Graphic.GraphicData = &xlsxGraphicData{
+			URI:  ns.Value,
+			Tsle: &xlsxTsle{XMLNS: ns.Value, Name: slicerName},
+		}
+		fallbackText = []string{"Timeline: Works in Excel 2013 or higher. Do not move or resize."}
+	}
 	graphic, _ := xml.Marshal(graphicFrame)
+	var paragraphs []*aP
+	for _, text := range fallbackText {
+		paragraphs = append(paragraphs, &aP{R: &aR{T: text}})
+	}
 	sp := xdrSp{
 		Macro: opts.Macro,
 		NvSpPr: &xdrNvSpPr{
@@ -621,10 +659,7 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 		},
 		TxBody: &xdrTxBody{
 			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
-			P: []*aP{
-				{R: &aR{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}},
-				{R: &aR{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}},
-			},
+			P:      paragraphs,
 		},
 	}
 	shape, _ := xml.Marshal(sp)
@@ -639,6 +674,9 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 	if ns.Value == NameSpaceDrawingMLSlicerX15.Value { // table slicer
 		choice.XMLNSSle15 = ns.Value
 	}
+	if ns.Value == NameSpaceDrawingMLTimeSlicer.Value { // timeline
+		choice.XMLNSTsle = ns.Value
+	}
 	fallback := xlsxFallback{Content: string(shape)}
 	choiceBytes, _ := xml.Marshal(choice)
 	shapeBytes, _ := xml.Marshal(fallback)
@@ -725,3 +763,1201 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// GetSlicers provides the method to get all slicers in a worksheet by a given
+// worksheet name. Note that the Name of the returned slicer options is the
+// unique slicer name in the workbook, which could be different from the field
+// name of the table or pivot table. For example, get all slicers on Sheet1:
+//
+//	slicers, err := f.GetSlicers("Sheet1")
+func (f *File) GetSlicers(sheet string) ([]SlicerOptions, error) {
+	var (
+		slicers      []SlicerOptions
+		ws, err      = f.workSheetReader(sheet)
+		decodeExtLst = new(decodeExtLst)
+	)
+	if err != nil || ws.ExtLst == nil {
+		return slicers, err
+	}
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return slicers, err
+	}
+	anchors, err := f.getSlicerAnchors(sheet)
+	if err != nil {
+		return slicers, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerListX14 && ext.URI != ExtURISlicerListX15 {
+			continue
+		}
+		slicerList := new(decodeSlicerList)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList)
+		for _, slicer := range slicerList.Slicer {
+			if slicer.RID == "" {
+				continue
+			}
+			target := f.getSheetRelationshipsTargetByID(sheet, slicer.RID)
+			slicerXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+			opts, err := f.getSlicers(sheet, slicer.RID, slicerXML, anchors)
+			if err != nil {
+				return slicers, err
+			}
+			slicers = append(slicers, opts...)
+		}
+	}
+	return slicers, err
+}
+
+// getSlicers provides a function to get the slicers settings in the slicer
+// part by given worksheet name, relationship ID, slicer part path and the
+// decoded cell anchors of the slicer shapes.
+func (f *File) getSlicers(sheet, rID, slicerXML string, anchors map[string]*decodeSlicerAnchor) ([]SlicerOptions, error) {
+	var slicers []SlicerOptions
+	content, err := f.slicerReader(slicerXML)
+	if err != nil {
+		return slicers, err
+	}
+	for _, slicer := range content.Slicer {
+		opts := SlicerOptions{
+			slicerXML:       slicerXML,
+			slicerCacheName: slicer.Cache,
+			slicerSheetName: sheet,
+			slicerSheetRID:  rID,
+			Name:            slicer.Name,
+			Caption:         slicer.Caption,
+			DisplayHeader:   slicer.ShowCaption,
+		}
+		if err = f.extractSlicerCache(&opts); err != nil {
+			return slicers, err
+		}
+		if anchor, ok := anchors[slicer.Name]; ok {
+			f.extractSlicerAnchor(sheet, anchor, &opts)
+		}
+		slicers = append(slicers, opts)
+	}
+	return slicers, err
+}
+
+// getSlicerAnchors provides a function to get the decoded cell anchors of the
+// slicer and timeline shapes in the worksheet by given worksheet name, the
+// keys of the map are the names of the shapes.
+func (f *File) getSlicerAnchors(sheet string) (map[string]*decodeSlicerAnchor, error) {
+	anchors := map[string]*decodeSlicerAnchor{}
+	drawingXML, err := f.getSheetDrawingXML(sheet)
+	if err != nil || drawingXML == "" {
+		return anchors, err
+	}
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return anchors, err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, cellAnchor := range wsDr.TwoCellAnchor {
+		if anchor := decodeSlicerCellAnchor(cellAnchor); anchor != nil {
+			anchors[anchor.AlternateContent.GraphicFrame.CNvPr.Name] = anchor
+		}
+	}
+	return anchors, err
+}
+
+// decodeSlicerCellAnchor provides a function to decode the cell anchor of the
+// slicer or timeline shape, returns nil if the given cell anchor is not a
+// slicer or timeline shape.
+func decodeSlicerCellAnchor(cellAnchor *xdrCellAnchor) *decodeSlicerAnchor {
+	output, err := xml.Marshal(cellAnchor)
+	if err != nil {
+		return nil
+	}
+	var anchor decodeSlicerAnchor
+	if err = xml.NewDecoder(bytes.NewReader(output)).Decode(&anchor); err != nil && err != io.EOF {
+		return nil
+	}
+	if anchor.From == nil || anchor.AlternateContent == nil || anchor.AlternateContent.GraphicFrame == nil ||
+		anchor.AlternateContent.GraphicFrame.CNvPr == nil {
+		return nil
+	}
+	return &anchor
+}
+
+// extractSlicerAnchor provides a function to extract the position, size and
+// format settings of the slicer by given worksheet name and decoded cell
+// anchor of the slicer shape.
+func (f *File) extractSlicerAnchor(sheet string, anchor *decodeSlicerAnchor, opts *SlicerOptions) {
+	opts.Cell, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+	opts.Format.OffsetX, opts.Format.OffsetY = anchor.From.ColOff/EMU, anchor.From.RowOff/EMU
+	if anchor.To != nil {
+		_, _, w, h := f.getDrawingAnchorRect(sheet, &decodeCellAnchor{
+			From: anchor.From, To: anchor.To,
+		})
+		opts.Width, opts.Height = uint(w), uint(h)
+	}
+	if anchor.ClientData != nil {
+		opts.Format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+		opts.Format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+	}
+	if anchor.AlternateContent.Sp != nil {
+		opts.Macro = anchor.AlternateContent.Sp.Macro
+	}
+}
+
+// getSlicerCache provides a function to get the slicer cache part path and
+// definition by given slicer cache name, returns empty path if the slicer
+// cache doesn't exist.
+func (f *File) getSlicerCache(slicerCacheName string) (string, *xlsxSlicerCacheDefinition, error) {
+	var (
+		err            error
+		slicerCacheXML string
+		slicerCache    *xlsxSlicerCacheDefinition
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
+			return true
+		}
+		decodeSlicerCache := new(xlsxSlicerCacheDefinition)
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+			Decode(decodeSlicerCache); err != nil && err != io.EOF {
+			return false
+		}
+		if err = nil; decodeSlicerCache.Name == slicerCacheName {
+			slicerCacheXML, slicerCache = k.(string), decodeSlicerCache
+			return false
+		}
+		return true
+	})
+	return slicerCacheXML, slicerCache, err
+}
+
+// getTableSlicerCache provides a function to get the decoded table slicer
+// cache in the extension list of the slicer cache, returns nil if the slicer
+// cache isn't a table slicer cache.
+func (f *File) getTableSlicerCache(slicerCache *xlsxSlicerCacheDefinition) (*xlsxExt, *decodeTableSlicerCache) {
+	if slicerCache.ExtLst == nil {
+		return nil, nil
+	}
+	ext := new(xlsxExt)
+	_ = f.xmlNewDecoder(strings.NewReader(slicerCache.ExtLst.Ext)).Decode(ext)
+	if ext.URI != ExtURISlicerCacheDefinition {
+		return nil, nil
+	}
+	tableSlicerCache := new(decodeTableSlicerCache)
+	_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(tableSlicerCache)
+	return ext, tableSlicerCache
+}
+
+// extractSlicerCache provides a function to extract the data source, items
+// sorting and selected items of the slicer by given slicer options.
+func (f *File) extractSlicerCache(opts *SlicerOptions) error {
+	slicerCacheXML, slicerCache, err := f.getSlicerCache(opts.slicerCacheName)
+	if err != nil || slicerCache == nil {
+		return err
+	}
+	opts.slicerCacheXML = slicerCacheXML
+	if slicerCache.PivotTables != nil && len(slicerCache.PivotTables.PivotTable) > 0 {
+		pivotTable := slicerCache.PivotTables.PivotTable[0]
+		opts.TableSheet, opts.TableName = f.GetSheetMap()[pivotTable.TabID], pivotTable.Name
+		if slicerCache.Data == nil || slicerCache.Data.Tabular == nil {
+			return err
+		}
+		tabular := slicerCache.Data.Tabular
+		opts.ItemDesc = tabular.SortOrder == "descending"
+		if tabular.Items == nil {
+			return err
+		}
+		return f.extractPivotSlicerItems(slicerCache.SourceName, tabular.Items.I, opts)
+	}
+	_, tableSlicerCache := f.getTableSlicerCache(slicerCache)
+	if tableSlicerCache == nil {
+		return err
+	}
+	opts.ItemDesc = tableSlicerCache.SortOrder == "descending"
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tables {
+		if tbl.table.ID != tableSlicerCache.TableID {
+			continue
+		}
+		opts.TableSheet, opts.TableName = tbl.sheet, tbl.table.Name
+		if tbl.table.AutoFilter == nil {
+			break
+		}
+		for _, fc := range tbl.table.AutoFilter.FilterColumn {
+			if fc == nil || fc.ColID != tableSlicerCache.Column-1 || fc.Filters == nil {
+				continue
+			}
+			for _, filter := range fc.Filters.Filter {
+				opts.SelectedItems = append(opts.SelectedItems, filter.Val)
+			}
+			if fc.Filters.Blank {
+				opts.SelectedItems = append(opts.SelectedItems, "")
+			}
+		}
+	}
+	return err
+}
+
+// extractPivotSlicerItems provides a function to extract the selected items of
+// the pivot table slicer by given field name, slicer cache items and slicer
+// options. The index of the slicer cache items is the order of the distinct
+// values of the field in the pivot table data source.
+func (f *File) extractPivotSlicerItems(name string, items []xlsxTabularSlicerCacheItem, opts *SlicerOptions) error {
+	pivotTables, err := f.GetPivotTables(opts.TableSheet)
+	if err != nil {
+		return err
+	}
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name != opts.TableName {
+			continue
+		}
+		order, err := f.getTableFieldsOrder(&PivotTableOptions{DataRange: pivotTable.DataRange})
+		if err != nil {
+			return err
+		}
+		values, err := f.getSlicerSourceItems(&pivotTable, inStrSlice(order, name, true))
+		if err != nil {
+			return err
+		}
+		// the slicer cache items which don't match the field items are the
+		// placeholder written on adding the slicer, all items are selected
+		if len(items) != len(values) {
+			break
+		}
+		var selected []string
+		for _, item := range items {
+			if item.S && item.X < len(values) {
+				selected = append(selected, values[item.X])
+			}
+		}
+		if len(selected) < len(values) {
+			opts.SelectedItems = selected
+		}
+		break
+	}
+	return err
+}
+
+// getSlicerSourceItems provides a function to get the distinct values of the
+// field in the pivot table data source by given pivot table options and the
+// index of the field, the values are in order of appearance.
+func (f *File) getSlicerSourceItems(pivotTable *PivotTableOptions, colIdx int) ([]string, error) {
+	var items []string
+	if colIdx == -1 {
+		return items, nil
+	}
+	if err := f.getPivotTableDataRange(pivotTable); err != nil {
+		return items, err
+	}
+	dataSheet, coordinates, err := f.adjustRange(pivotTable.pivotDataRange)
+	if err != nil {
+		return items, newPivotTableDataRangeError(err.Error())
+	}
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		cell, _ := CoordinatesToCellName(coordinates[0]+colIdx, row)
+		value, err := f.GetCellValue(dataSheet, cell)
+		if err != nil {
+			return items, err
+		}
+		if inStrSlice(items, value, true) == -1 {
+			items = append(items, value)
+		}
+	}
+	return items, err
+}
+
+// SetSlicerItems provides the method to set the selected items and items
+// sorting of a slicer by given slicer name, the selected items and if sort
+// the items in descending (Z-A) order. All items will be selected if the
+// selected items is empty, and an error will be returned if any selected item
+// doesn't exist in the slicer field. Note that the slicer cache may be shared by the
+// slicers of the same field, the settings will take effect on all these
+// slicers. For example, select the items "Apple" and "Orange" of the slicer
+// named "Column1":
+//
+//	err := f.SetSlicerItems("Column1", []string{"Apple", "Orange"}, false)
+func (f *File) SetSlicerItems(name string, selectedItems []string, itemDesc bool) error {
+	opts, err := f.getSlicer(name)
+	if err != nil {
+		return err
+	}
+	source := &SlicerOptions{TableSheet: opts.TableSheet, TableName: opts.TableName}
+	_, slicerCache, err := f.getSlicerCache(opts.slicerCacheName)
+	if err != nil {
+		return err
+	}
+	if slicerCache == nil {
+		return newNoExistSlicerError(name)
+	}
+	source.Name, source.SelectedItems, source.ItemDesc = slicerCache.SourceName, selectedItems, itemDesc
+	table, pivotTable, colIdx, err := f.getSlicerSource(source)
+	if err != nil {
+		return err
+	}
+	if err = f.checkSlicerItems(source, table, pivotTable, colIdx); err != nil {
+		return err
+	}
+	return f.setSlicerCacheItems(opts.slicerCacheName, colIdx, source, table, pivotTable)
+}
+
+// checkSlicerItems provides a function to check if the selected items exist
+// in the slicer field by given slicer options, table or pivot table options
+// and the index of the slicer field.
+func (f *File) checkSlicerItems(opts *SlicerOptions, table *Table, pivotTable *PivotTableOptions, colIdx int) error {
+	if len(opts.SelectedItems) == 0 {
+		return nil
+	}
+	source := pivotTable
+	if table != nil {
+		coordinates, err := rangeRefToCoordinates(table.Range)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if table.ShowTotalsRow {
+			coordinates[3]--
+		}
+		ref, _ := coordinatesToRangeRef(coordinates)
+		dataRange := fmt.Sprintf("%s!%s", opts.TableSheet, ref)
+		source = &PivotTableOptions{DataRange: dataRange, pivotDataRange: dataRange}
+	}
+	items, err := f.getSlicerSourceItems(source, colIdx)
+	if err != nil {
+		return err
+	}
+	for _, item := range opts.SelectedItems {
+		if inStrSlice(items, item, true) == -1 {
+			return newNoExistSlicerItemError(item)
+		}
+	}
+	return err
+}
+
+// setSlicerCacheItems provides a function to set the selected items and the
+// items sorting of the slicer cache by given slicer cache name, column index,
+// slicer, and table or pivot table options. The table will be filtered by the
+// selected items for the table slicer.
+func (f *File) setSlicerCacheItems(slicerCacheName string, colIdx int, opts *SlicerOptions, table *Table, pivotTable *PivotTableOptions) error {
+	var sortOrder string
+	slicerCacheXML, slicerCache, err := f.getSlicerCache(slicerCacheName)
+	if err != nil || slicerCache == nil {
+		return err
+	}
+	if opts.ItemDesc {
+		sortOrder = "descending"
+	}
+	if pivotTable != nil && slicerCache.Data != nil && slicerCache.Data.Tabular != nil {
+		items, err := f.getSlicerSourceItems(pivotTable, colIdx)
+		if err != nil {
+			return err
+		}
+		tabular := slicerCache.Data.Tabular
+		tabular.SortOrder = sortOrder
+		tabular.Items = &xlsxTabularSlicerCacheItems{Count: len(items)}
+		for i, item := range items {
+			tabular.Items.I = append(tabular.Items.I, xlsxTabularSlicerCacheItem{
+				X: i, S: len(opts.SelectedItems) == 0 || inStrSlice(opts.SelectedItems, item, true) != -1,
+			})
+		}
+	}
+	if ext, tableSlicerCache := f.getTableSlicerCache(slicerCache); table != nil && ext != nil {
+		tableSlicerBytes, _ := xml.Marshal(&xlsxTableSlicerCache{
+			TableID:        tableSlicerCache.TableID,
+			Column:         tableSlicerCache.Column,
+			SortOrder:      sortOrder,
+			CustomListSort: tableSlicerCache.CustomListSort,
+			CrossFilter:    tableSlicerCache.CrossFilter,
+		})
+		ext.Content = string(tableSlicerBytes)
+		extLstBytes, _ := xml.Marshal(&decodeExtLst{Ext: []*xlsxExt{ext}})
+		slicerCache.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+		if err = f.setTableSlicerFilter(opts.TableSheet, table, colIdx, opts.SelectedItems); err != nil {
+			return err
+		}
+	}
+	slicerCache.XMLNSXMC, slicerCache.XMLNSX = SourceRelationshipCompatibility.Value, NameSpaceSpreadSheet.Value
+	slicerCache.XMLNSX15, slicerCache.XMLNSXR10 = NameSpaceSpreadSheetX15.Value, NameSpaceSpreadSheetXR10.Value
+	slicerCacheBytes, err := xml.Marshal(slicerCache)
+	f.saveFileList(slicerCacheXML, slicerCacheBytes)
+	return err
+}
+
+// setTableSlicerFilter provides a function to set the filter criteria of the
+// table column by given worksheet name, table, column index and selected
+// items, and hides the table rows that don't match the criteria.
+func (f *File) setTableSlicerFilter(sheet string, table *Table, colIdx int, selectedItems []string) error {
+	var t xlsxTable
+	content, ok := f.Pkg.Load(table.tableXML)
+	if !ok {
+		return nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(&t); err != nil && err != io.EOF {
+		return err
+	}
+	if t.AutoFilter == nil {
+		coordinates, err := rangeRefToCoordinates(t.Ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		coordinates[3] -= t.TotalsRowCount
+		t.AutoFilter = &xlsxAutoFilter{}
+		t.AutoFilter.Ref, _ = coordinatesToRangeRef(coordinates)
+	}
+	var filterColumns []*xlsxFilterColumn
+	prev := &xlsxAutoFilter{Ref: t.AutoFilter.Ref, FilterColumn: t.AutoFilter.FilterColumn}
+	for _, fc := range t.AutoFilter.FilterColumn {
+		if fc != nil && fc.ColID != colIdx {
+			filterColumns = append(filterColumns, fc)
+		}
+	}
+	if len(selectedItems) > 0 {
+		filters := &xlsxFilters{}
+		for _, item := range selectedItems {
+			if item == "" {
+				filters.Blank = true
+				continue
+			}
+			filters.Filter = append(filters.Filter, &xlsxFilter{Val: item})
+		}
+		filterColumns = append(filterColumns, &xlsxFilterColumn{ColID: colIdx, Filters: filters})
+		sort.Slice(filterColumns, func(i, j int) bool {
+			return filterColumns[i].ColID < filterColumns[j].ColID
+		})
+	}
+	t.AutoFilter.FilterColumn = filterColumns
+	output, err := xml.Marshal(t)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(table.tableXML, output)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return f.applyFilter(sheet, ws, prev, t.AutoFilter)
+}
+
+// getSlicer provides a function to get the slicer settings by given slicer
+// name, returns an error if the slicer doesn't exist in the workbook.
+func (f *File) getSlicer(name string) (SlicerOptions, error) {
+	for _, sheet := range f.GetSheetList() {
+		slicers, err := f.GetSlicers(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return SlicerOptions{}, err
+		}
+		for _, opts := range slicers {
+			if opts.Name == name {
+				return opts, err
+			}
+		}
+	}
+	return SlicerOptions{}, newNoExistSlicerError(name)
+}
+
+// DeleteSlicer provides the method to delete a slicer by a given slicer name.
+// The slicer cache, the slicer cache defined name and the workbook extension
+// list entry will be deleted if no other slicer uses the slicer cache, and the
+// filter criteria set by the table slicer will be cleared and the rows hidden
+// by it will be unhidden. For example, delete the slicer named "Column1":
+//
+//	err := f.DeleteSlicer("Column1")
+func (f *File) DeleteSlicer(name string) error {
+	opts, err := f.getSlicer(name)
+	if err != nil {
+		return err
+	}
+	slicers, err := f.slicerReader(opts.slicerXML)
+	if err != nil {
+		return err
+	}
+	for i, slicer := range slicers.Slicer {
+		if slicer.Name == name {
+			slicers.Slicer = append(slicers.Slicer[:i], slicers.Slicer[i+1:]...)
+			break
+		}
+	}
+	if len(slicers.Slicer) > 0 {
+		output, err := xml.Marshal(slicers)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(opts.slicerXML, output)
+	} else if err = f.deleteSheetSlicer(&opts); err != nil {
+		return err
+	}
+	if err = f.deleteDrawingSlicer(opts.slicerSheetName, name); err != nil {
+		return err
+	}
+	if f.isSlicerCacheInUse(opts.slicerCacheName) || opts.slicerCacheXML == "" {
+		return err
+	}
+	if err = f.clearTableSlicerFilter(&opts); err != nil {
+		return err
+	}
+	return f.deleteSlicerCache(&opts)
+}
+
+// clearTableSlicerFilter provides a function to clear the filter criteria of
+// the table column which set by the table slicer, and unhide the table rows
+// hidden by the selected items of the slicer by given slicer options.
+func (f *File) clearTableSlicerFilter(opts *SlicerOptions) error {
+	_, slicerCache, err := f.getSlicerCache(opts.slicerCacheName)
+	if err != nil || slicerCache == nil {
+		return err
+	}
+	if ext, _ := f.getTableSlicerCache(slicerCache); ext == nil {
+		return err
+	}
+	table, _, colIdx, err := f.getSlicerSource(&SlicerOptions{
+		Name: slicerCache.SourceName, TableSheet: opts.TableSheet, TableName: opts.TableName,
+	})
+	if err != nil || table == nil {
+		return err
+	}
+	return f.setTableSlicerFilter(opts.TableSheet, table, colIdx, nil)
+}
+
+// deleteSheetSlicer provides a function to delete the slicer part, the
+// relationship and the slicer list extension entry of the worksheet by given
+// slicer options.
+func (f *File) deleteSheetSlicer(opts *SlicerOptions) error {
+	ws, err := f.workSheetReader(opts.slicerSheetName)
+	if err != nil {
+		return err
+	}
+	f.Pkg.Delete(opts.slicerXML)
+	_ = f.removeContentTypesPart(ContentTypeSlicer, "/"+opts.slicerXML)
+	f.deleteSheetRelationships(opts.slicerSheetName, opts.slicerSheetRID)
+	if ws.ExtLst == nil {
+		return err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for idx := 0; idx < len(decodeExtLst.Ext); idx++ {
+		ext := decodeExtLst.Ext[idx]
+		if ext.URI != ExtURISlicerListX14 && ext.URI != ExtURISlicerListX15 {
+			continue
+		}
+		slicerList, x14SlicerList := new(decodeSlicerList), new(xlsxX14SlicerList)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList)
+		for _, slicer := range slicerList.Slicer {
+			if slicer.RID != opts.slicerSheetRID {
+				x14SlicerList.Slicer = append(x14SlicerList.Slicer, &xlsxX14Slicer{RID: slicer.RID})
+			}
+		}
+		if len(x14SlicerList.Slicer) == 0 {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:idx], decodeExtLst.Ext[idx+1:]...)
+			idx--
+			continue
+		}
+		slicerListBytes, _ := xml.Marshal(x14SlicerList)
+		ext.Content = string(slicerListBytes)
+	}
+	ws.ExtLst = nil
+	if len(decodeExtLst.Ext) > 0 {
+		extLstBytes, err := xml.Marshal(decodeExtLst)
+		ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+		return err
+	}
+	return err
+}
+
+// deleteDrawingSlicer provides a function to delete the slicer shape in the
+// drawing part of the worksheet by given worksheet name and slicer name.
+func (f *File) deleteDrawingSlicer(sheet, name string) error {
+	drawingXML, err := f.getSheetDrawingXML(sheet)
+	if err != nil || drawingXML == "" {
+		return err
+	}
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for idx := 0; idx < len(wsDr.TwoCellAnchor); idx++ {
+		if anchor := decodeSlicerCellAnchor(wsDr.TwoCellAnchor[idx]); anchor != nil &&
+			anchor.AlternateContent.GraphicFrame.CNvPr.Name == name {
+			wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
+			idx--
+		}
+	}
+	return err
+}
+
+// isSlicerCacheInUse provides a function to check if the slicer cache is used
+// by any slicer in the workbook by given slicer cache name.
+func (f *File) isSlicerCacheInUse(slicerCacheName string) bool {
+	var inUse bool
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/slicers/slicer") {
+			slicers, err := f.slicerReader(k.(string))
+			if err != nil {
+				return true
+			}
+			for _, slicer := range slicers.Slicer {
+				if slicer.Cache == slicerCacheName {
+					inUse = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return inUse
+}
+
+// deleteSlicerCache provides a function to delete the slicer cache part, the
+// relationship, the slicer caches extension entry and the defined name of the
+// slicer cache in the workbook by given slicer options.
+func (f *File) deleteSlicerCache(opts *SlicerOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	f.Pkg.Delete(opts.slicerCacheXML)
+	_ = f.removeContentTypesPart(ContentTypeSlicerCache, "/"+opts.slicerCacheXML)
+	_ = f.DeleteDefinedName(&DefinedName{Name: opts.slicerCacheName})
+	var rID string
+	if rels != nil {
+		rels.mu.Lock()
+		for k, v := range rels.Relationships {
+			target := strings.TrimPrefix(v.Target, "/")
+			if !strings.HasPrefix(target, "xl/") {
+				target = "xl/" + target
+			}
+			if v.Type == SourceRelationshipSlicerCache && target == opts.slicerCacheXML {
+				rID = v.ID
+				rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if wb.ExtLst == nil || rID == "" {
+		return err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + wb.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for idx := 0; idx < len(decodeExtLst.Ext); idx++ {
+		ext := decodeExtLst.Ext[idx]
+		if ext.URI != ExtURISlicerCachesX14 && ext.URI != ExtURISlicerCachesX15 {
+			continue
+		}
+		decodeSlicerCaches := new(decodeSlicerCaches)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeSlicerCaches)
+		var content string
+		for _, slicerCache := range decodeSlicerCaches.SlicerCache {
+			if slicerCache.RID != rID {
+				slicerCacheBytes, _ := xml.Marshal(xlsxX14SlicerCache{RID: slicerCache.RID})
+				content += string(slicerCacheBytes)
+			}
+		}
+		if content == "" {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:idx], decodeExtLst.Ext[idx+1:]...)
+			idx--
+			continue
+		}
+		var slicerCachesBytes []byte
+		if ext.URI == ExtURISlicerCachesX14 {
+			slicerCachesBytes, _ = xml.Marshal(&xlsxX14SlicerCaches{XMLNS: NameSpaceSpreadSheetX14.Value, Content: content})
+		}
+		if ext.URI == ExtURISlicerCachesX15 {
+			slicerCachesBytes, _ = xml.Marshal(&xlsxX15SlicerCaches{XMLNS: NameSpaceSpreadSheetX14.Value, Content: content})
+		}
+		ext.Content = string(slicerCachesBytes)
+	}
+	wb.ExtLst = nil
+	if len(decodeExtLst.Ext) > 0 {
+		extLstBytes, err := xml.Marshal(decodeExtLst)
+		wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+		return err
+	}
+	return err
+}
+
+// TimelineOptions represents the settings of the timeline.
+//
+// Name specifies the timeline field name, should be an existing date field
+// name of the given pivot table, this setting is required.
+//
+// Cell specifies the left top cell coordinates the position for inserting the
+// timeline, this setting is required.
+//
+// TableSheet specifies the worksheet name of the pivot table, this setting is
+// required.
+//
+// TableName specifies the name of the pivot table, this setting is required.
+//
+// Caption specifies the caption of the timeline, this setting is optional.
+//
+// Level specifies the time level of the timeline, this setting is optional,
+// the possible values are "years", "quarters", "months" and "days", and the
+// default setting is "months".
+//
+// StartDate and EndDate specifies the selected date range of the timeline,
+// this setting is optional, and the default setting is no selection. The
+// StartDate and EndDate should be set at the same time.
+//
+// Style specifies the built-in or custom timeline style name, this setting is
+// optional. The built-in timeline style names:
+//
+//	TimeSlicerStyleLight1 - TimeSlicerStyleLight6
+//	TimeSlicerStyleDark1 - TimeSlicerStyleDark6
+//
+// Macro used for set macro for the timeline, the workbook extension should be
+// XLSM or XLTM.
+//
+// Width specifies the width of the timeline, this setting is optional.
+//
+// Height specifies the height of the timeline, this setting is optional.
+//
+// DisplayHeader specifies if display header of the timeline, this setting is
+// optional, the default setting is display.
+//
+// DisplaySelectionLabel specifies if display selection label of the timeline,
+// this setting is optional, the default setting is display.
+//
+// DisplayTimeLevel specifies if display time level of the timeline, this
+// setting is optional, the default setting is display.
+//
+// DisplayScrollbar specifies if display horizontal scrollbar of the timeline,
+// this setting is optional, the default setting is display.
+//
+// Format specifies the format of the timeline, this setting is optional.
+type TimelineOptions struct {
+	Name                  string
+	Cell                  string
+	TableSheet            string
+	TableName             string
+	Caption               string
+	Level                 string
+	StartDate             time.Time
+	EndDate               time.Time
+	Style                 string
+	Macro                 string
+	Width                 uint
+	Height                uint
+	DisplayHeader         *bool
+	DisplaySelectionLabel *bool
+	DisplayTimeLevel      *bool
+	DisplayScrollbar      *bool
+	Format                GraphicOptions
+}
+
+// AddTimeline function inserts a timeline by giving the worksheet name and
+// timeline settings. The timeline filters the pivot table by the date field,
+// the field of the pivot table data source should contain date values.
+//
+// For example, insert a timeline on the Sheet1!G20 with field Date for the
+// pivot table named PivotTable1, and select the first quarter of 2024 in
+// months level:
+//
+//	err := f.AddTimeline("Sheet1", &excelize.TimelineOptions{
+//	    Name:       "Date",
+//	    Cell:       "G20",
+//	    TableSheet: "Sheet1",
+//	    TableName:  "PivotTable1",
+//	    Caption:    "Date",
+//	    Level:      "months",
+//	    StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    EndDate:    time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+//	    Style:      "TimeSlicerStyleLight2",
+//	})
+func (f *File) AddTimeline(sheet string, opts *TimelineOptions) error {
+	opts, level, err := parseTimelineOptions(opts)
+	if err != nil {
+		return err
+	}
+	table, pivotTable, colIdx, err := f.getSlicerSource(&SlicerOptions{
+		Name: opts.Name, TableSheet: opts.TableSheet, TableName: opts.TableName,
+	})
+	if err != nil {
+		return err
+	}
+	if table != nil {
+		return newNoExistTableError(opts.TableName)
+	}
+	bounds, err := f.getTimelineBounds(pivotTable, colIdx, opts.Name)
+	if err != nil {
+		return err
+	}
+	timelineID, err := f.addSheetTimeline(sheet)
+	if err != nil {
+		return err
+	}
+	timelineCacheName, err := f.setTimelineCache(opts, pivotTable, bounds)
+	if err != nil {
+		return err
+	}
+	timelineName := f.genSlicerName(opts.Name)
+	if err := f.addDrawingSlicer(sheet, timelineName, NameSpaceDrawingMLTimeSlicer, &SlicerOptions{
+		Cell:   opts.Cell,
+		Macro:  opts.Macro,
+		Width:  opts.Width,
+		Height: opts.Height,
+		Format: opts.Format,
+	}); err != nil {
+		return err
+	}
+	scrollPosition := bounds.StartDate
+	if !opts.StartDate.IsZero() {
+		scrollPosition = opts.StartDate.Format(timelineDateLayout)
+	}
+	return f.addTimeline(timelineID, xlsxTimeline{
+		Name:                    timelineName,
+		Cache:                   timelineCacheName,
+		Caption:                 opts.Caption,
+		ShowHeader:              opts.DisplayHeader,
+		ShowSelectionLabel:      opts.DisplaySelectionLabel,
+		ShowTimeLevel:           opts.DisplayTimeLevel,
+		ShowHorizontalScrollbar: opts.DisplayScrollbar,
+		Level:                   level,
+		SelectionLevel:          level,
+		ScrollPosition:          scrollPosition,
+		Style:                   opts.Style,
+	})
+}
+
+// parseTimelineOptions provides a function to parse the format settings of the
+// timeline with default value, and returns the time level index.
+func parseTimelineOptions(opts *TimelineOptions) (*TimelineOptions, int, error) {
+	if opts == nil {
+		return nil, 0, ErrParameterRequired
+	}
+	if opts.Name == "" || opts.Cell == "" || opts.TableSheet == "" || opts.TableName == "" {
+		return nil, 0, ErrParameterInvalid
+	}
+	if opts.Level == "" {
+		opts.Level = "months"
+	}
+	level := inStrSlice(supportedTimelineLevels, opts.Level, false)
+	if level == -1 {
+		return nil, 0, ErrParameterInvalid
+	}
+	if opts.StartDate.IsZero() != opts.EndDate.IsZero() || opts.StartDate.After(opts.EndDate) {
+		return nil, 0, ErrParameterInvalid
+	}
+	if opts.Width == 0 {
+		opts.Width = defaultTimelineWidth
+	}
+	if opts.Height == 0 {
+		opts.Height = defaultTimelineHeight
+	}
+	if opts.Format.PrintObject == nil {
+		opts.Format.PrintObject = boolPtr(true)
+	}
+	if opts.Format.Locked == nil {
+		opts.Format.Locked = boolPtr(false)
+	}
+	if opts.Format.ScaleX == 0 {
+		opts.Format.ScaleX = defaultDrawingScale
+	}
+	if opts.Format.ScaleY == 0 {
+		opts.Format.ScaleY = defaultDrawingScale
+	}
+	return opts, level, nil
+}
+
+// getTimelineBounds returns the date range of the timeline by giving the pivot
+// table options, the index and the name of the timeline field in the pivot
+// table data source. The bounds are started from the first day of the earliest
+// year, and ended on the first day of the year after the latest year.
+func (f *File) getTimelineBounds(pivotTable *PivotTableOptions, colIdx int, name string) (*xlsxTimelineRange, error) {
+	var (
+		date1904   bool
+		start, end time.Time
+		opts       = &PivotTableOptions{DataRange: pivotTable.DataRange}
+	)
+	if err := f.getPivotTableDataRange(opts); err != nil {
+		return nil, err
+	}
+	dataSheet, coordinates, err := f.adjustRange(opts.pivotDataRange)
+	if err != nil {
+		return nil, newPivotTableDataRangeError(err.Error())
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		cell, _ := CoordinatesToCellName(coordinates[0]+colIdx, row)
+		val, err := f.GetCellValue(dataSheet, cell, Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		num, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			continue
+		}
+		t := timeFromExcelTime(num, date1904)
+		if start.IsZero() || t.Before(start) {
+			start = t
+		}
+		if end.IsZero() || t.After(end) {
+			end = t
+		}
+	}
+	if start.IsZero() {
+		return nil, newInvalidTimelineFieldError(name)
+	}
+	return &xlsxTimelineRange{
+		StartDate: time.Date(start.Year(), time.January, 1, 0, 0, 0, 0, time.UTC).Format(timelineDateLayout),
+		EndDate:   time.Date(end.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC).Format(timelineDateLayout),
+	}, nil
+}
+
+// countTimelines provides a function to get timeline files count storage in
+// the folder xl/timelines.
+func (f *File) countTimelines() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/timelines/timeline") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// countTimelineCache provides a function to get timeline cache files count
+// storage in the folder xl/timelineCaches.
+func (f *File) countTimelineCache() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/timelineCaches/timelineCache") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// addSheetTimeline adds a new timeline and updates the namespace and
+// relationships parts of the worksheet by giving the worksheet name.
+func (f *File) addSheetTimeline(sheet string) (int, error) {
+	var (
+		timelineID   = f.countTimelines() + 1
+		ws, err      = f.workSheetReader(sheet)
+		decodeExtLst = new(decodeExtLst)
+		timelineRefs = new(decodeTimelineRefs)
+	)
+	if err != nil {
+		return timelineID, err
+	}
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return timelineID, err
+		}
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI == ExtURITimelineRefs {
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(timelineRefs)
+				for _, timelineRef := range timelineRefs.TimelineRef {
+					if timelineRef.RID != "" {
+						sheetRelationshipsTimelineXML := f.getSheetRelationshipsTargetByID(sheet, timelineRef.RID)
+						timelineID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsTimelineXML, "../timelines/timeline"), ".xml"))
+						return timelineID, err
+					}
+				}
+			}
+		}
+	}
+	sheetRelationshipsTimelineXML := "../timelines/timeline" + strconv.Itoa(timelineID) + ".xml"
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipTimeline, sheetRelationshipsTimelineXML, "")
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX15)
+	return timelineID, f.addSheetTimelineRef(ws, rID)
+}
+
+// addSheetTimelineRef adds a new timeline reference for the worksheet by
+// giving the worksheet relationships ID.
+func (f *File) addSheetTimelineRef(ws *xlsxWorksheet, rID int) error {
+	var (
+		decodeExtLst                   = new(decodeExtLst)
+		err                            error
+		timelineRefsBytes, extLstBytes []byte
+	)
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	timelineRefsBytes, _ = xml.Marshal(&xlsxX15TimelineRefs{
+		TimelineRef: []*xlsxX15TimelineRef{{RID: "rId" + strconv.Itoa(rID)}},
+	})
+	decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
+		xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
+		URI:   ExtURITimelineRefs, Content: string(timelineRefsBytes),
+	})
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err = xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// addTimeline adds a new timeline to the workbook by giving the timeline ID
+// and settings.
+func (f *File) addTimeline(timelineID int, timeline xlsxTimeline) error {
+	timelineXML := "xl/timelines/timeline" + strconv.Itoa(timelineID) + ".xml"
+	timelines, err := f.timelineReader(timelineXML)
+	if err != nil {
+		return err
+	}
+	if err := f.addContentTypePart(timelineID, "timeline"); err != nil {
+		return err
+	}
+	timelines.Timeline = append(timelines.Timeline, timeline)
+	output, err := xml.Marshal(timelines)
+	f.saveFileList(timelineXML, output)
+	return err
+}
+
+// setTimelineCache check if a timeline cache already exists or add a new
+// timeline cache by giving the timeline, pivot table options and the date
+// range of the timeline, and returns the timeline cache name.
+func (f *File) setTimelineCache(opts *TimelineOptions, pivotTable *PivotTableOptions, bounds *xlsxTimelineRange) (string, error) {
+	var ok bool
+	var timelineCacheName string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/timelineCaches/timelineCache") {
+			timelineCache := &xlsxTimelineCacheDefinition{}
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(timelineCache); err != nil && err != io.EOF {
+				return true
+			}
+			if timelineCache.SourceName != opts.Name || timelineCache.PivotTables == nil {
+				return true
+			}
+			for _, tbl := range timelineCache.PivotTables.PivotTable {
+				if tbl.Name == pivotTable.Name {
+					ok, timelineCacheName = true, timelineCache.Name
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if ok {
+		return timelineCacheName, nil
+	}
+	timelineCacheName = f.genSlicerCacheName("NativeTimeline_", opts.Name)
+	return timelineCacheName, f.addTimelineCache(timelineCacheName, opts, pivotTable, bounds)
+}
+
+// addTimelineCache adds a new timeline cache by giving the timeline cache
+// name, timeline, pivot table options and the date range of the timeline.
+func (f *File) addTimelineCache(timelineCacheName string, opts *TimelineOptions, pivotTable *PivotTableOptions, bounds *xlsxTimelineRange) error {
+	pivotCacheID, err := f.addPivotCacheSlicer(pivotTable)
+	if err != nil {
+		return err
+	}
+	timelineCacheID := f.countTimelineCache() + 1
+	timelineCache := xlsxTimelineCacheDefinition{
+		XMLNSXMC:   SourceRelationshipCompatibility.Value,
+		XMLNSX:     NameSpaceSpreadSheet.Value,
+		XMLNSXR10:  NameSpaceSpreadSheetXR10.Value,
+		Name:       timelineCacheName,
+		SourceName: opts.Name,
+		PivotTables: &xlsxSlicerCachePivotTables{
+			PivotTable: []xlsxSlicerCachePivotTable{
+				{TabID: f.getSheetID(opts.TableSheet), Name: pivotTable.Name},
+			},
+		},
+		State: &xlsxTimelineState{
+			MinimalRefreshVersion: 6,
+			LastRefreshVersion:    6,
+			PivotCacheID:          pivotCacheID,
+			FilterType:            "unknown",
+			Bounds:                bounds,
+		},
+	}
+	if !opts.StartDate.IsZero() {
+		timelineCache.State.SingleRangeFilterState = true
+		timelineCache.State.FilterType = "dateBetween"
+		timelineCache.State.Selection = &xlsxTimelineRange{
+			StartDate: opts.StartDate.Format(timelineDateLayout),
+			EndDate:   opts.EndDate.Format(timelineDateLayout),
+		}
+	}
+	timelineCacheXML := "xl/timelineCaches/timelineCache" + strconv.Itoa(timelineCacheID) + ".xml"
+	timelineCacheBytes, _ := xml.Marshal(timelineCache)
+	f.saveFileList(timelineCacheXML, timelineCacheBytes)
+	if err := f.addContentTypePart(timelineCacheID, "timelineCache"); err != nil {
+		return err
+	}
+	if err := f.addWorkbookTimelineCache(timelineCacheID); err != nil {
+		return err
+	}
+	return f.SetDefinedName(&DefinedName{Name: timelineCacheName, RefersTo: formulaErrorNA})
+}
+
+// addWorkbookTimelineCache add the association ID of the timeline cache in
+// workbook.xml.
+func (f *File) addWorkbookTimelineCache(timelineCacheID int) error {
+	var (
+		wb                                            *xlsxWorkbook
+		err                                           error
+		appendMode                                    bool
+		decodeExtLst                                  = new(decodeExtLst)
+		decodeTimelineCacheRefs                       = new(decodeTimelineCacheRefs)
+		timelineCacheRefBytes, refsBytes, extLstBytes []byte
+	)
+	if wb, err = f.workbookReader(); err != nil {
+		return err
+	}
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipTimelineCache, fmt.Sprintf("/xl/timelineCaches/timelineCache%d.xml", timelineCacheID), "")
+	timelineCacheRefBytes, _ = xml.Marshal(xlsxX15TimelineCacheRef{RID: fmt.Sprintf("rId%d", rID)})
+	if wb.ExtLst != nil { // append mode ext
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + wb.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		for idx, ext := range decodeExtLst.Ext {
+			if ext.URI == ExtURITimelineCacheRefs {
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeTimelineCacheRefs)
+				refsBytes, _ = xml.Marshal(xlsxX15TimelineCacheRefs{Content: decodeTimelineCacheRefs.Content + string(timelineCacheRefBytes)})
+				decodeExtLst.Ext[idx].Content = string(refsBytes)
+				appendMode = true
+			}
+		}
+	}
+	if !appendMode {
+		refsBytes, _ = xml.Marshal(xlsxX15TimelineCacheRefs{Content: string(timelineCacheRefBytes)})
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
+			xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
+			URI:   ExtURITimelineCacheRefs, Content: string(refsBytes),
+		})
+	}
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err = xml.Marshal(decodeExtLst)
+	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
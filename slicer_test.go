@@ -1,11 +1,13 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -216,12 +218,18 @@ func TestAddSlicer(t *testing.T) {
 		Name:  "Table1",
 		Range: "A1:D5",
 	}))
-	f.Pkg.Store("xl/slicers/slicer2.xml", MacintoshCyrillicCharset)
-	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
 		Name:       "Column1",
 		Cell:       "E1",
 		TableName:  "Table1",
 		TableSheet: "Sheet1",
+	}))
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:       "Column1",
+		Cell:       "I1",
+		TableName:  "Table1",
+		TableSheet: "Sheet1",
 	}), "XML syntax error on line 1: invalid UTF-8")
 	assert.NoError(t, f.Close())
 
@@ -370,7 +378,7 @@ func TestAddWorkbookSlicerCache(t *testing.T) {
 func TestGenSlicerCacheName(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Slicer_Column_1", RefersTo: formulaErrorNA}))
-	assert.Equal(t, "Slicer_Column_11", f.genSlicerCacheName("Column 1"))
+	assert.Equal(t, "Slicer_Column_11", f.genSlicerCacheName("Slicer_", "Column 1"))
 	assert.NoError(t, f.Close())
 }
 
@@ -384,3 +392,460 @@ func TestAddPivotCacheSlicer(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestGetSlicers(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Fruit", "Region", "Sales"}))
+	for i, row := range [][]interface{}{
+		{"Apple", "East", 10}, {"Orange", "West", 20}, {"Apple", "West", 30}, {"Banana", "East", 40},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Table1", Range: "A1:C5"}))
+	disable := false
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:          "Fruit",
+		Cell:          "E1",
+		TableSheet:    "Sheet1",
+		TableName:     "Table1",
+		Caption:       "Fruits",
+		Macro:         "Button1_Click",
+		Width:         200,
+		Height:        180,
+		DisplayHeader: &disable,
+		ItemDesc:      true,
+		SelectedItems: []string{"Apple"},
+	}))
+	for row, visible := range map[int]bool{2: true, 3: false, 4: true, 5: false} {
+		v, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, visible, v)
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C5",
+		PivotTableRange: "Sheet1!H20:K30",
+		Name:            "PivotTable1",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:          "Region",
+		Cell:          "H1",
+		TableSheet:    "Sheet1",
+		TableName:     "PivotTable1",
+		SelectedItems: []string{"West"},
+	}))
+	check := func(f *File) {
+		slicers, err := f.GetSlicers("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, slicers, 2)
+		// The pivot table slicers list is in front of the table slicers list
+		slicers[0], slicers[1] = slicers[1], slicers[0]
+		assert.Equal(t, "Fruit", slicers[0].Name)
+		assert.Equal(t, "E1", slicers[0].Cell)
+		assert.Equal(t, "Sheet1", slicers[0].TableSheet)
+		assert.Equal(t, "Table1", slicers[0].TableName)
+		assert.Equal(t, "Fruits", slicers[0].Caption)
+		assert.Equal(t, "Button1_Click", slicers[0].Macro)
+		assert.Equal(t, uint(200), slicers[0].Width)
+		assert.Equal(t, uint(180), slicers[0].Height)
+		assert.Equal(t, &disable, slicers[0].DisplayHeader)
+		assert.True(t, slicers[0].ItemDesc)
+		assert.Equal(t, []string{"Apple"}, slicers[0].SelectedItems)
+		assert.Equal(t, "Region", slicers[1].Name)
+		assert.Equal(t, "H1", slicers[1].Cell)
+		assert.Equal(t, "PivotTable1", slicers[1].TableName)
+		assert.False(t, slicers[1].ItemDesc)
+		assert.Equal(t, []string{"West"}, slicers[1].SelectedItems)
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSlicers.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestGetSlicers.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test get slicers without slicer
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	slicers, err := f.GetSlicers("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers)
+	// Test get slicers with not exist worksheet
+	_, err = f.GetSlicers("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get slicers with unsupported charset slicer
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get slicers with unsupported charset slicer cache
+	f, err = OpenFile(filepath.Join("test", "TestGetSlicers.xlsx"))
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/slicerCaches/slicerCache1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSlicers("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get slicers with unsupported charset worksheet extension list
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext><x14:slicerList></ext>"}
+	_, err = f.GetSlicers("Sheet1")
+	assert.Error(t, err)
+}
+
+func TestSetSlicerItems(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Fruit", "Region"}))
+	for i, row := range [][]string{{"Apple", "East"}, {"Orange", "West"}, {"", "West"}, {"Banana", "East"}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Table1", Range: "A1:B5"}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Fruit", Cell: "E1", TableSheet: "Sheet1", TableName: "Table1",
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Region", Cell: "H1", TableSheet: "Sheet1", TableName: "Table1",
+	}))
+	assert.NoError(t, f.SetSlicerItems("Fruit", []string{"Orange", ""}, true))
+	assert.NoError(t, f.SetSlicerItems("Region", []string{"West"}, false))
+	for row, visible := range map[int]bool{2: false, 3: true, 4: true, 5: false} {
+		v, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, visible, v)
+	}
+	slicers, err := f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Orange", ""}, slicers[0].SelectedItems)
+	assert.True(t, slicers[0].ItemDesc)
+	// Test select all items of the slicer
+	assert.NoError(t, f.SetSlicerItems("Fruit", nil, false))
+	assert.NoError(t, f.SetSlicerItems("Region", nil, false))
+	for row := 2; row <= 5; row++ {
+		v, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.True(t, v)
+	}
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers[0].SelectedItems)
+	assert.False(t, slicers[0].ItemDesc)
+	// Test set slicer items for the pivot table slicer
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:B5",
+		PivotTableRange: "Sheet1!K20:M30",
+		Name:            "PivotTable1",
+		Rows:            []PivotTableField{{Data: "Fruit"}},
+		Data:            []PivotTableField{{Data: "Region", Subtotal: "Count"}},
+	}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Fruit", Cell: "K1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}))
+	// Test get the selected items of the pivot table slicer with placeholder
+	// slicer cache items
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Fruit 1", slicers[0].Name)
+	assert.Empty(t, slicers[0].SelectedItems)
+	assert.NoError(t, f.SetSlicerItems("Fruit 1", []string{"Apple", "Banana"}, true))
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, slicers, 3)
+	assert.Equal(t, "Fruit 1", slicers[0].Name)
+	assert.Equal(t, []string{"Apple", "Banana"}, slicers[0].SelectedItems)
+	assert.True(t, slicers[0].ItemDesc)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSlicerItems.xlsx")))
+	// Test set slicer items with not exist slicer
+	assert.Equal(t, newNoExistSlicerError("SlicerN"), f.SetSlicerItems("SlicerN", nil, false))
+	// Test set slicer items with not exist items
+	assert.Equal(t, newNoExistSlicerItemError("Grape"), f.SetSlicerItems("Fruit", []string{"Apple", "Grape"}, false))
+	assert.Equal(t, newNoExistSlicerItemError("Grape"), f.SetSlicerItems("Fruit 1", []string{"Grape"}, false))
+	assert.Equal(t, newNoExistSlicerItemError("Grape"), f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Fruit", Cell: "N1", TableSheet: "Sheet1", TableName: "Table1", SelectedItems: []string{"Grape"},
+	}))
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, slicers, 3)
+	assert.Equal(t, []string{"Apple", "Banana"}, slicers[0].SelectedItems)
+	assert.Empty(t, slicers[1].SelectedItems)
+	// Test set slicer items with unsupported charset slicer cache
+	f.Pkg.Store("xl/slicerCaches/slicerCache1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSlicerItems("Fruit", nil, false), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetTableSlicerFilter(t *testing.T) {
+	f := NewFile()
+	tableXML := "xl/tables/table1.xml"
+	// Test set table slicer filter without auto filter
+	f.Pkg.Store(tableXML, []byte(fmt.Sprintf(`<table xmlns="%s" id="1" name="Table1" ref="A1:B4" totalsRowCount="1"/>`, NameSpaceSpreadSheet.Value)))
+	assert.NoError(t, f.setTableSlicerFilter("Sheet1", &Table{tableXML: tableXML}, 1, []string{"A"}))
+	var tbl xlsxTable
+	content, ok := f.Pkg.Load(tableXML)
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &tbl))
+	assert.Equal(t, "A1:B3", tbl.AutoFilter.Ref)
+	assert.Equal(t, 1, tbl.AutoFilter.FilterColumn[0].ColID)
+	// Test set table slicer filter with not exist table part
+	assert.NoError(t, f.setTableSlicerFilter("Sheet1", &Table{tableXML: "xl/tables/table2.xml"}, 1, nil))
+	// Test set table slicer filter with not exist worksheet
+	assert.EqualError(t, f.setTableSlicerFilter("SheetN", &Table{tableXML: tableXML}, 1, nil), "sheet SheetN does not exist")
+	// Test set table slicer filter with invalid table range reference
+	f.Pkg.Store(tableXML, []byte(fmt.Sprintf(`<table xmlns="%s" id="1" name="Table1" ref="A:B"/>`, NameSpaceSpreadSheet.Value)))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.setTableSlicerFilter("Sheet1", &Table{tableXML: tableXML}, 1, nil))
+	// Test set table slicer filter with unsupported charset table
+	f.Pkg.Store(tableXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.setTableSlicerFilter("Sheet1", &Table{tableXML: tableXML}, 1, nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteSlicer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Fruit", "Region"}))
+	for i, row := range [][]string{{"Apple", "East"}, {"Orange", "West"}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Table1", Range: "A1:B3"}))
+	for _, cell := range []string{"E1", "H1"} {
+		assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+			Name: "Fruit", Cell: cell, TableSheet: "Sheet1", TableName: "Table1",
+		}))
+	}
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Region", Cell: "K1", TableSheet: "Sheet1", TableName: "Table1",
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "N1", Type: "rect"}))
+	// Test delete a slicer which slicer cache used by another slicer
+	assert.NoError(t, f.DeleteSlicer("Fruit"))
+	slicers, err := f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, slicers, 2)
+	assert.Equal(t, "Fruit 1", slicers[0].Name)
+	assert.Equal(t, "H1", slicers[0].Cell)
+	assert.Len(t, f.GetDefinedName(), 2)
+	// Test delete the slicer and the slicer cache, the table filter criteria
+	// set by the slicer will be cleared
+	assert.NoError(t, f.SetSlicerItems("Fruit 1", []string{"Apple"}, false))
+	visible, err := f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	assert.NoError(t, f.DeleteSlicer("Fruit 1"))
+	assert.Len(t, f.GetDefinedName(), 1)
+	visible, err = f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	var tbl xlsxTable
+	content, ok := f.Pkg.Load(tables[0].tableXML)
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &tbl))
+	assert.Empty(t, tbl.AutoFilter.FilterColumn)
+	_, ok = f.Pkg.Load("xl/slicerCaches/slicerCache1.xml")
+	assert.False(t, ok)
+	// Test add a slicer after deleting the slicer cache
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Fruit", Cell: "E1", TableSheet: "Sheet1", TableName: "Table1",
+	}))
+	_, ok = f.Pkg.Load("xl/slicerCaches/slicerCache3.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteSlicer.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test delete all slicers in the workbook
+	f, err = OpenFile(filepath.Join("test", "TestDeleteSlicer.xlsx"))
+	assert.NoError(t, err)
+	for _, name := range []string{"Region", "Fruit"} {
+		assert.NoError(t, f.DeleteSlicer(name))
+	}
+	slicers, err = f.GetSlicers("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, slicers)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Nil(t, wb.ExtLst)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.ExtLst)
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	assert.Empty(t, f.GetDefinedName())
+	// Test delete slicer with not exist slicer
+	assert.Equal(t, newNoExistSlicerError("Fruit"), f.DeleteSlicer("Fruit"))
+	assert.NoError(t, f.Close())
+	// Test delete slicer with unsupported charset slicer
+	f, err = OpenFile(filepath.Join("test", "TestDeleteSlicer.xlsx"))
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/slicers/slicer1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteSlicer("Region"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test delete slicer cache with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deleteSlicerCache(&SlicerOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete worksheet slicer with not exist worksheet
+	assert.EqualError(t, f.deleteSheetSlicer(&SlicerOptions{slicerSheetName: "SheetN"}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddTimeline(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Date", "Region", "Sales"}))
+	for row := 2; row < 32; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), time.Date(2023, time.Month(row%12+1), row%28+1, 0, 0, 0, 0, time.UTC).AddDate(row/16, 0, 0)))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("B%d", row), []string{"East", "West"}[row%2]))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("C%d", row), row*100))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C31",
+		PivotTableRange: "Sheet1!E2:H20",
+		Name:            "PivotTable1",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Summarize by Sum"}},
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+		ShowRowHeaders:  true,
+		ShowColHeaders:  true,
+	}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A35", &[]string{"Date", "Region", "Sales"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Table1", Range: "A35:C40"}))
+	// Test add a timeline with selection
+	assert.NoError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "J2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+		Caption:    "Date",
+		Level:      "quarters",
+		StartDate:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+		Style:      "TimeSlicerStyleLight2",
+	}))
+	// Test add a timeline with the same field and pivot table
+	assert.NoError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "J12",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+		Level:      "days",
+		Width:      400,
+		Height:     150,
+	}))
+	timelines, err := f.timelineReader("xl/timelines/timeline1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, timelines.Timeline, 2)
+	assert.Equal(t, "Date", timelines.Timeline[0].Name)
+	assert.Equal(t, "Date 1", timelines.Timeline[1].Name)
+	assert.Equal(t, "NativeTimeline_Date", timelines.Timeline[0].Cache)
+	assert.Equal(t, timelines.Timeline[0].Cache, timelines.Timeline[1].Cache)
+	assert.Equal(t, 1, timelines.Timeline[0].Level)
+	assert.Equal(t, 3, timelines.Timeline[1].Level)
+	assert.Equal(t, "2023-01-01T00:00:00", timelines.Timeline[0].ScrollPosition)
+	assert.Equal(t, 1, f.countTimelineCache())
+	timelineCache := new(xlsxTimelineCacheDefinition)
+	content, ok := f.Pkg.Load("xl/timelineCaches/timelineCache1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), timelineCache))
+	assert.Equal(t, &xlsxTimelineRange{StartDate: "2023-01-01T00:00:00", EndDate: "2025-01-01T00:00:00"}, timelineCache.State.Bounds)
+	assert.Equal(t, &xlsxTimelineRange{StartDate: "2023-01-01T00:00:00", EndDate: "2023-03-31T00:00:00"}, timelineCache.State.Selection)
+	assert.Equal(t, "dateBetween", timelineCache.State.FilterType)
+	// Test add a timeline in another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddTimeline("Sheet2", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "A1",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTimeline.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add a timeline in the workbook with existing timeline
+	f, err = OpenFile(filepath.Join("test", "TestAddTimeline.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddTimeline("Sheet2", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "A10",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}))
+	assert.Equal(t, 2, f.countTimelines())
+	// Test add a timeline with invalid options
+	assert.Equal(t, ErrParameterRequired, f.AddTimeline("Sheet1", nil))
+	for _, opts := range []*TimelineOptions{
+		{Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1"},
+		{Name: "Date", TableSheet: "Sheet1", TableName: "PivotTable1"},
+		{Name: "Date", Cell: "A1", TableName: "PivotTable1"},
+		{Name: "Date", Cell: "A1", TableSheet: "Sheet1"},
+		{Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1", Level: "weeks"},
+		{Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1", StartDate: time.Now()},
+		{Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1", StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, -1)},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddTimeline("Sheet1", opts))
+	}
+	// Test add a timeline with not exist pivot table
+	assert.Equal(t, newNoExistTableError("PivotTable2"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable2",
+	}))
+	// Test add a timeline for the table
+	assert.Equal(t, newNoExistTableError("Table1"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "Table1",
+	}))
+	// Test add a timeline with not exist field
+	assert.Equal(t, newInvalidSlicerNameError("Month"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Month", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}))
+	// Test add a timeline with not date field
+	assert.Equal(t, newInvalidTimelineFieldError("Region"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Region", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}))
+	// Test add a timeline with not exist worksheet
+	assert.EqualError(t, f.AddTimeline("SheetN", &TimelineOptions{
+		Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}), "sheet SheetN does not exist")
+	// Test add a timeline with invalid cell reference
+	assert.EqualError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Date", Cell: "A", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add a timeline with unsupported charset timeline
+	f.Pkg.Store("xl/timelines/timeline1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test add a timeline with invalid worksheet extension list
+	f, err = OpenFile(filepath.Join("test", "TestAddTimeline.xlsx"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: "<>"}
+	assert.Error(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name: "Date", Cell: "A1", TableSheet: "Sheet1", TableName: "PivotTable1",
+	}))
+	assert.Error(t, f.addSheetTimelineRef(ws, 1))
+	// Test add a timeline with unsupported charset pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addTimelineCache("NativeTimeline_Date", &TimelineOptions{}, &PivotTableOptions{
+		pivotCacheXML: "xl/pivotCache/pivotCacheDefinition1.xml",
+	}, nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddWorkbookTimelineCache(t *testing.T) {
+	// Test add a workbook timeline cache with unsupported charset workbook
+	f := NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store("xl/workbook.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addWorkbookTimelineCache(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test add a workbook timeline cache with invalid workbook extension list
+	f = NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExtLst = &xlsxExtLst{Ext: "<>"}
+	assert.Error(t, f.addWorkbookTimelineCache(1))
+	assert.NoError(t, f.Close())
+}
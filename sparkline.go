@@ -534,3 +534,220 @@ func (f *File) appendSparkline(ws *xlsxWorksheet, group *xlsxX14SparklineGroup,
 	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// GetSparklines provides a function to get the sparklines in a worksheet by
+// given worksheet name, each sparkline group in the worksheet will be
+// returned as a sparkline options. The Style is the index of the first
+// matched preset style, and the SeriesColor will be set if the series color of
+// the sparkline group different from the preset style. For example, get the
+// sparklines on Sheet1:
+//
+//	sparklines, err := f.GetSparklines("Sheet1")
+func (f *File) GetSparklines(sheet string) ([]SparklineOptions, error) {
+	var sparklines []SparklineOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return sparklines, err
+	}
+	_, idx, groups, err := f.getSparklineGroups(ws)
+	if err != nil || idx == -1 {
+		return sparklines, err
+	}
+	for _, group := range groups.SparklineGroups {
+		sparklines = append(sparklines, extractSparklineGroup(group))
+	}
+	return sparklines, err
+}
+
+// getSparklineGroups provides a function to get the decoded extension list of
+// the worksheet, the index of the sparkline groups extension in the list and
+// the decoded sparkline groups. The index will be -1 if the sparkline groups
+// extension doesn't exist.
+func (f *File) getSparklineGroups(ws *xlsxWorksheet) (*decodeExtLst, int, *decodeX14SparklineGroups, error) {
+	var (
+		err          error
+		decodeExtLst = new(decodeExtLst)
+		groups       = new(decodeX14SparklineGroups)
+	)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return decodeExtLst, -1, groups, err
+	}
+	for idx, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURISparklineGroups {
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(groups); err != nil && err != io.EOF {
+				return decodeExtLst, idx, groups, err
+			}
+			return decodeExtLst, idx, groups, nil
+		}
+	}
+	return decodeExtLst, -1, groups, nil
+}
+
+// extractSparklineGroup provides a function to extract the sparkline options
+// by given decoded sparkline group.
+func extractSparklineGroup(group *decodeX14SparklineGroup) SparklineOptions {
+	opts := SparklineOptions{
+		CustMax:    group.ManualMax,
+		CustMin:    group.ManualMin,
+		Type:       "line",
+		Weight:     group.LineWeight,
+		DateAxis:   group.DateAxis,
+		Markers:    group.Markers,
+		High:       group.High,
+		Low:        group.Low,
+		First:      group.First,
+		Last:       group.Last,
+		Negative:   group.Negative,
+		Axis:       group.DisplayXAxis,
+		Hidden:     group.DisplayHidden,
+		Reverse:    group.RightToLeft,
+		EmptyCells: group.DisplayEmptyCellsAs,
+	}
+	if sparkType, ok := map[string]string{"column": "column", "stacked": "win_loss"}[group.Type]; ok {
+		opts.Type = sparkType
+	}
+	for _, sparkline := range group.Sparklines.Sparkline {
+		opts.Location = append(opts.Location, sparkline.Sqref)
+		opts.Range = append(opts.Range, sparkline.F)
+	}
+	opts.Style, opts.SeriesColor = getSparklineStyle(group)
+	return opts
+}
+
+// getSparklineStyle provides a function to get the index of the matched preset
+// style and the series color by given decoded sparkline group.
+func getSparklineStyle(group *decodeX14SparklineGroup) (int, string) {
+	presets := getSparklineGroupPresets()
+	match := func(preset *xlsxX14SparklineGroup, series bool) bool {
+		return (!series || equalColor(preset.ColorSeries, group.ColorSeries)) &&
+			equalColor(preset.ColorNegative, group.ColorNegative) &&
+			equalColor(preset.ColorMarkers, group.ColorMarkers) &&
+			equalColor(preset.ColorFirst, group.ColorFirst) &&
+			equalColor(preset.ColorLast, group.ColorLast) &&
+			equalColor(preset.ColorHigh, group.ColorHigh) &&
+			equalColor(preset.ColorLow, group.ColorLow)
+	}
+	for style, preset := range presets {
+		if match(preset, true) {
+			return style, ""
+		}
+	}
+	var seriesColor string
+	if group.ColorSeries != nil {
+		seriesColor = strings.TrimPrefix(group.ColorSeries.RGB, "FF")
+	}
+	for style, preset := range presets {
+		if match(preset, false) {
+			return style, seriesColor
+		}
+	}
+	return 0, seriesColor
+}
+
+// equalColor provides a function to check if the given two colors are the
+// same.
+func equalColor(a, b *xlsxColor) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if (a.Theme == nil) != (b.Theme == nil) || a.Theme != nil && *a.Theme != *b.Theme {
+		return false
+	}
+	return a.Auto == b.Auto && strings.EqualFold(a.RGB, b.RGB) && a.Indexed == b.Indexed && a.Tint == b.Tint
+}
+
+// DeleteSparkline provides a function to delete the sparklines in a worksheet
+// by given worksheet name and the location of the sparklines. The location
+// could be a cell reference or a range reference, all sparklines in the
+// location will be deleted, and the sparkline group will be deleted if all
+// sparklines in the group were deleted. For example, delete the sparkline in
+// the cell A1 and the sparklines in the range B1:B3 on Sheet1:
+//
+//	err := f.DeleteSparkline("Sheet1", "A1")
+//	err = f.DeleteSparkline("Sheet1", "B1:B3")
+func (f *File) DeleteSparkline(sheet, location string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var coordinates []int
+	if strings.Contains(location, ":") {
+		if coordinates, err = rangeRefToCoordinates(location); err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+	} else {
+		col, row, err := CellNameToCoordinates(strings.ReplaceAll(location, "$", ""))
+		if err != nil {
+			return err
+		}
+		coordinates = []int{col, row, col, row}
+	}
+	if ws.ExtLst == nil {
+		return err
+	}
+	decodeExtLst, idx, groups, err := f.getSparklineGroups(ws)
+	if err != nil || idx == -1 {
+		return err
+	}
+	var content string
+	for _, group := range groups.SparklineGroups {
+		var sparklines []*xlsxX14Sparkline
+		for _, sparkline := range group.Sparklines.Sparkline {
+			col, row, err := CellNameToCoordinates(strings.ReplaceAll(sparkline.Sqref, "$", ""))
+			if err == nil && cellInRange([]int{col, row}, coordinates) {
+				continue
+			}
+			sparklines = append(sparklines, &xlsxX14Sparkline{F: sparkline.F, Sqref: sparkline.Sqref})
+		}
+		if len(sparklines) == 0 {
+			continue
+		}
+		sparklineGroupBytes, _ := xml.Marshal(&xlsxX14SparklineGroup{
+			ManualMax:           group.ManualMax,
+			ManualMin:           group.ManualMin,
+			LineWeight:          group.LineWeight,
+			Type:                group.Type,
+			DateAxis:            group.DateAxis,
+			DisplayEmptyCellsAs: group.DisplayEmptyCellsAs,
+			Markers:             group.Markers,
+			High:                group.High,
+			Low:                 group.Low,
+			First:               group.First,
+			Last:                group.Last,
+			Negative:            group.Negative,
+			DisplayXAxis:        group.DisplayXAxis,
+			DisplayHidden:       group.DisplayHidden,
+			MinAxisType:         group.MinAxisType,
+			MaxAxisType:         group.MaxAxisType,
+			RightToLeft:         group.RightToLeft,
+			ColorSeries:         group.ColorSeries,
+			ColorNegative:       group.ColorNegative,
+			ColorAxis:           group.ColorAxis,
+			ColorMarkers:        group.ColorMarkers,
+			ColorFirst:          group.ColorFirst,
+			ColorLast:           group.ColorLast,
+			ColorHigh:           group.ColorHigh,
+			ColorLow:            group.ColorLow,
+			Sparklines:          xlsxX14Sparklines{Sparkline: sparklines},
+		})
+		content += string(sparklineGroupBytes)
+	}
+	if content == "" {
+		decodeExtLst.Ext = append(decodeExtLst.Ext[:idx], decodeExtLst.Ext[idx+1:]...)
+	} else {
+		sparklineGroupsBytes, _ := xml.Marshal(&xlsxX14SparklineGroups{
+			XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value,
+			Content: content,
+		})
+		decodeExtLst.Ext[idx].Content = string(sparklineGroupsBytes)
+	}
+	if ws.ExtLst = nil; len(decodeExtLst.Ext) == 0 {
+		return err
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
@@ -290,6 +290,133 @@ func TestAppendSparkline(t *testing.T) {
 	assert.EqualError(t, f.appendSparkline(ws, &xlsxX14SparklineGroup{}, &xlsxX14SparklineGroups{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSparklines(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2"},
+		Range:    []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+		Type:     "win_loss",
+		Style:    12,
+		Markers:  true,
+		Negative: true,
+		Axis:     true,
+		Reverse:  true,
+	}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location:    []string{"B1"},
+		Range:       []string{"Sheet3!A3:J3"},
+		Type:        "column",
+		Style:       20,
+		SeriesColor: "#E7E6E6",
+	}))
+	check := func(f *File) {
+		sparklines, err := f.GetSparklines("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []SparklineOptions{
+			{
+				Location:   []string{"A1", "A2"},
+				Range:      []string{"Sheet3!A1:J1", "Sheet3!A2:J2"},
+				Type:       "win_loss",
+				Style:      12,
+				Markers:    true,
+				Negative:   true,
+				Axis:       true,
+				Reverse:    true,
+				EmptyCells: "gap",
+			},
+			{
+				Location:    []string{"B1"},
+				Range:       []string{"Sheet3!A3:J3"},
+				Type:        "column",
+				Style:       20,
+				SeriesColor: "E7E6E6",
+				EmptyCells:  "gap",
+			},
+		}, sparklines)
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSparklines.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetSparklines.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test get sparklines without sparkline
+	sparklines, err := f.GetSparklines("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, sparklines)
+	// Test get sparklines with not exist worksheet
+	_, err = f.GetSparklines("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test get sparklines with unsupported charset extension list
+	f = NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get sparklines with invalid sparkline groups
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"><x14:sparklineGroups><x14:sparklineGroup></x14:sparklines></x14:sparklineGroups></ext>`, ExtURISparklineGroups)}
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <sparklineGroup> closed by </sparklines>")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteSparkline(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D3", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true}}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2", "A3"},
+		Range:    []string{"Sheet3!A1:J1", "Sheet3!A2:J2", "Sheet3!A3:J3"},
+		Markers:  true,
+	}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"B1", "B2"},
+		Range:    []string{"Sheet3!A4:J4", "Sheet3!A5:J5"},
+		Type:     "column",
+	}))
+	// Test delete a sparkline in the group
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "$A$2"))
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 2)
+	assert.Equal(t, []string{"A1", "A3"}, sparklines[0].Location)
+	assert.Equal(t, []string{"Sheet3!A1:J1", "Sheet3!A3:J3"}, sparklines[0].Range)
+	assert.True(t, sparklines[0].Markers)
+	// Test delete the whole sparkline group
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "B2:B1"))
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 1)
+	// Test delete a sparkline in the cell without sparkline
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "C1"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteSparkline.xlsx")))
+	// Test delete all sparklines in the worksheet
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "A1:A3"))
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, sparklines)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotContains(t, ws.ExtLst.Ext, ExtURISparklineGroups)
+	// Test delete sparklines without any extension list
+	assert.NoError(t, f.DeleteSparkline("Sheet2", "A1"))
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s"><x14:sparklineGroups xmlns:xm="%s"></x14:sparklineGroups></ext>`, ExtURISparklineGroups, NameSpaceSpreadSheetExcel2006Main.Value)}
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "A1"))
+	assert.Nil(t, ws.ExtLst)
+	// Test delete sparklines with invalid location
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteSparkline("Sheet1", "A"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteSparkline("Sheet1", "A:B"))
+	// Test delete sparklines with not exist worksheet
+	assert.EqualError(t, f.DeleteSparkline("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test delete sparklines with unsupported charset extension list
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	assert.EqualError(t, f.DeleteSparkline("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func prepareSparklineDataset() (*File, error) {
 	f := NewFile()
 	sheet2 := [][]int{
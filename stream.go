@@ -498,6 +498,77 @@ func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
 	return nil
 }
 
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value by given range reference and format options for the
+// StreamWriter. The conditional formats will be written when calling the
+// 'Flush' function. For example, highlight cells with value greater than 60 in
+// the range A1:A1048576:
+//
+//	format, err := f.NewConditionalStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = sw.SetConditionalFormat("A1:A1048576", []excelize.ConditionalFormatOptions{
+//	    {Type: "cell", Criteria: ">", Format: &format, Value: "60"},
+//	})
+//
+// See File.SetConditionalFormat for details on the format options.
+func (sw *StreamWriter) SetConditionalFormat(rangeRef string, opts []ConditionalFormatOptions) error {
+	return sw.file.SetConditionalFormat(sw.Sheet, rangeRef, opts)
+}
+
+// AddDataValidation provides a function to set data validation on a range of
+// the StreamWriter by given data validation object. The data validations will
+// be written when calling the 'Flush' function. For example, set a drop-down
+// list for the range B2:B1048576:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "B2:B1048576"
+//	err := dv.SetDropList([]string{"1", "2", "3"})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = sw.AddDataValidation(dv)
+//
+// See File.AddDataValidation for details on the data validation.
+func (sw *StreamWriter) AddDataValidation(dv *DataValidation) error {
+	return sw.file.AddDataValidation(sw.Sheet, dv)
+}
+
+// SetCellHyperLink provides a function to set cell hyperlink by given cell
+// reference and link URL for the StreamWriter. The hyperlinks will be written
+// when calling the 'Flush' function, and the value of the cell should be set
+// by the 'SetRow' function. For example, add an external hyperlink to the cell
+// A3:
+//
+//	display, tooltip := "https://github.com/xuri/excelize", "Excelize on GitHub"
+//	err := sw.SetCellHyperLink("A3", display, "External", excelize.HyperlinkOpts{
+//	    Display: &display,
+//	    Tooltip: &tooltip,
+//	})
+//
+// See File.SetCellHyperLink for details on the link types.
+func (sw *StreamWriter) SetCellHyperLink(cell, link, linkType string, opts ...HyperlinkOpts) error {
+	return sw.file.SetCellHyperLink(sw.Sheet, cell, link, linkType, opts...)
+}
+
+// AddComment provides a function to add comment (notes) in a cell by given
+// comment settings for the StreamWriter. The comments will be written when
+// calling the 'Flush' function. For example, add a comment in the cell A3:
+//
+//	err := sw.AddComment(excelize.Comment{
+//	    Cell:   "A3",
+//	    Author: "Excelize",
+//	    Text:   "This is a comment.",
+//	})
+//
+// See File.AddComment for details on the comment settings.
+func (sw *StreamWriter) AddComment(opts Comment) error {
+	return sw.file.AddComment(sw.Sheet, opts)
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -669,6 +740,11 @@ func (sw *StreamWriter) Flush() error {
 	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
 	_, _ = sw.rawData.WriteString(sw.tableParts)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
+	if sw.worksheet.ExtLst != nil {
+		_, _ = sw.rawData.WriteString(`<extLst>`)
+		_, _ = sw.rawData.WriteString(sw.worksheet.ExtLst.Ext)
+		_, _ = sw.rawData.WriteString(`</extLst>`)
+	}
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
 	if err := sw.rawData.Flush(); err != nil {
 		return err
@@ -682,6 +758,338 @@ func (sw *StreamWriter) Flush() error {
 	return nil
 }
 
+// StreamAppender defined the type of stream appender, which used for adding
+// or replacing rows on an existing worksheet without loading the worksheet
+// into memory.
+type StreamAppender struct {
+	sw       *StreamWriter
+	previous *StreamWriter
+	tempFile *os.File
+	source   io.ReaderAt
+	size     int64
+	decoder  *xml.Decoder
+	lastRow  int
+	pending  *streamAppendRow
+	trailer  int64
+	done     bool
+	prefix   string
+}
+
+// streamAppendRow directly maps the byte offsets of an existing row in the
+// worksheet part.
+type streamAppendRow struct {
+	num        int
+	start, end int64
+}
+
+// NewStreamAppender returns stream appender struct by given worksheet name
+// and row number used for adding or replacing rows on an existing worksheet
+// with large amounts of data. The existing rows up to the given row number
+// will be kept as is, and the rows after it can be added or replaced by the
+// 'SetRow' function, the existing rows which not be replaced will be kept.
+// The appender copies the worksheet part through a decoder without parsing
+// the worksheet, and other parts of the workbook will be kept untouched. Note
+// that you must call the 'Flush' method to end the streaming appending
+// process, ensure that the order of row numbers is ascending when set rows,
+// and the dimension of the worksheet will be removed since the used range is
+// unknown before ending the process. For example, append 1000 rows after the
+// row 100 on the worksheet Sheet1:
+//
+//	f, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer func() {
+//	    if err := f.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	sa, err := f.NewStreamAppender("Sheet1", 100)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rowID := 101; rowID <= 1100; rowID++ {
+//	    cell, err := excelize.CoordinatesToCellName(1, rowID)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    if err := sa.SetRow(cell, []interface{}{rowID, "Data"}); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
+//	if err := sa.Flush(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.Save(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) NewStreamAppender(sheet string, row int) (*StreamAppender, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	sheetID := f.getSheetID(sheet)
+	if sheetID == -1 {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	if row < 0 || row > TotalRows {
+		return nil, newInvalidRowNumberError(row)
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if ws, ok := f.Sheet.Load(sheetXMLPath); ok && ws != nil {
+		f.saveWorkSheet(sheetXMLPath, ws.(*xlsxWorksheet), bytes.NewBuffer(nil))
+	}
+	sa := &StreamAppender{
+		sw: &StreamWriter{file: f, Sheet: sheet, SheetID: sheetID, sheetWritten: true},
+	}
+	if err := sa.open(sheetXMLPath); err != nil {
+		return nil, err
+	}
+	err := sa.writeHeader()
+	if err == nil {
+		err = sa.copyRows(row)
+	}
+	if err != nil {
+		if sa.tempFile != nil {
+			_ = sa.tempFile.Close()
+		}
+		_ = sa.sw.rawData.Close()
+		return nil, err
+	}
+	if f.streams == nil {
+		f.streams = make(map[string]*StreamWriter)
+	}
+	f.streams[sheetXMLPath] = sa.sw
+	sa.sw.rows = row
+	return sa, nil
+}
+
+// open provides a function to open the source of the worksheet part by given
+// path in the zip, the source could be the previous stream writing result,
+// the data in memory or the system temporary file.
+func (sa *StreamAppender) open(name string) error {
+	f := sa.sw.file
+	if stream, ok := f.streams[name]; ok {
+		r, err := stream.rawData.Reader()
+		if err != nil {
+			return err
+		}
+		src := r.(interface {
+			io.ReaderAt
+			Size() int64
+		})
+		sa.previous, sa.source, sa.size = stream, src, src.Size()
+	} else if content, _ := f.loadPkg(name); content != nil {
+		sa.source, sa.size = bytes.NewReader(content.([]byte)), int64(len(content.([]byte)))
+	} else if tempFile, err := f.readTemp(name); tempFile != nil {
+		fi, err := tempFile.Stat()
+		if err != nil {
+			_ = tempFile.Close()
+			return err
+		}
+		sa.tempFile, sa.source, sa.size = tempFile, tempFile, fi.Size()
+	} else if err != nil {
+		return err
+	}
+	if sa.source == nil {
+		return ErrStreamSheetData
+	}
+	sa.decoder = xml.NewDecoder(io.NewSectionReader(sa.source, 0, sa.size))
+	return nil
+}
+
+// copy provides a function to copy the bytes in the given offset range of
+// the source worksheet part to the stream.
+func (sa *StreamAppender) copy(start, end int64) error {
+	if end <= start {
+		return nil
+	}
+	if _, err := io.Copy(&sa.sw.rawData, io.NewSectionReader(sa.source, start, end-start)); err != nil {
+		return err
+	}
+	return sa.sw.rawData.Sync()
+}
+
+// skip provides a function to skip the rest of the element which start
+// element has been read in the source worksheet part.
+func (sa *StreamAppender) skip() error {
+	for depth := 1; depth > 0; {
+		token, err := sa.decoder.RawToken()
+		if err != nil {
+			return err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
+
+// writeHeader provides a function to copy the worksheet part before the
+// sheetData element to the stream, and remove the dimension element. The
+// namespace prefix of the sheetData element in the source worksheet part will
+// be kept.
+func (sa *StreamAppender) writeHeader() error {
+	var (
+		depth int
+		start int64
+	)
+	for {
+		offset := sa.decoder.InputOffset()
+		token, err := sa.decoder.RawToken()
+		if err == io.EOF {
+			return ErrStreamSheetData
+		}
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if depth == 1 && element.Name.Local == "sheetData" {
+				if err = sa.copy(start, offset); err != nil {
+					return err
+				}
+				if sa.prefix = element.Name.Space; sa.prefix == "" {
+					_, _ = sa.sw.rawData.WriteString(`<sheetData>`)
+					return nil
+				}
+				// declare the default namespace for the unprefixed rows
+				_, _ = sa.sw.rawData.WriteString(`<` + sa.prefix + `:sheetData xmlns="` + NameSpaceSpreadSheet.Value + `">`)
+				return nil
+			}
+			if depth == 1 && element.Name.Local == "dimension" {
+				if err = sa.copy(start, offset); err != nil {
+					return err
+				}
+				if err = sa.skip(); err != nil {
+					return err
+				}
+				start = sa.decoder.InputOffset()
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// nextRow provides a function to read the next existing row in the sheet
+// data of the source worksheet part.
+func (sa *StreamAppender) nextRow() error {
+	for depth := 0; ; {
+		offset := sa.decoder.InputOffset()
+		token, err := sa.decoder.RawToken()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if depth == 0 && element.Name.Local == "row" {
+				sa.lastRow++
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "r" {
+						if sa.lastRow, err = strconv.Atoi(attr.Value); err != nil {
+							return err
+						}
+					}
+				}
+				sa.pending = &streamAppendRow{num: sa.lastRow, start: offset}
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				sa.trailer, sa.done = sa.decoder.InputOffset(), true
+				return nil
+			}
+			if depth--; depth == 0 && sa.pending != nil {
+				sa.pending.end = sa.decoder.InputOffset()
+				return nil
+			}
+		}
+	}
+}
+
+// copyRows provides a function to copy the existing rows which row number
+// less than or equal to the given row number to the stream.
+func (sa *StreamAppender) copyRows(row int) error {
+	for !sa.done {
+		if sa.pending == nil {
+			if err := sa.nextRow(); err != nil {
+				return err
+			}
+			continue
+		}
+		if sa.pending.num > row {
+			return nil
+		}
+		if err := sa.copy(sa.pending.start, sa.pending.end); err != nil {
+			return err
+		}
+		sa.sw.rows, sa.pending = sa.pending.num, nil
+	}
+	return nil
+}
+
+// SetRow writes an array to stream rows by giving starting cell reference and
+// a pointer to an array of values, the existing row with the same row number
+// will be replaced. Note that you must call the 'Flush' function to end the
+// streaming appending process.
+func (sa *StreamAppender) SetRow(cell string, values []interface{}, opts ...RowOpts) error {
+	_, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if row <= sa.sw.rows {
+		return newStreamSetRowError(row)
+	}
+	if err = sa.copyRows(row - 1); err != nil {
+		return err
+	}
+	if sa.pending != nil && sa.pending.num == row {
+		sa.pending = nil
+	}
+	return sa.sw.SetRow(cell, values, opts...)
+}
+
+// Flush ending the streaming appending process.
+func (sa *StreamAppender) Flush() error {
+	if err := sa.copyRows(TotalRows); err != nil {
+		return err
+	}
+	if sa.prefix != "" {
+		_, _ = sa.sw.rawData.WriteString(`</` + sa.prefix + `:sheetData>`)
+	} else {
+		_, _ = sa.sw.rawData.WriteString(`</sheetData>`)
+	}
+	if err := sa.copy(sa.trailer, sa.size); err != nil {
+		return err
+	}
+	if err := sa.sw.rawData.Flush(); err != nil {
+		return err
+	}
+	if sa.tempFile != nil {
+		if err := sa.tempFile.Close(); err != nil {
+			return err
+		}
+	}
+	if sa.previous != nil {
+		_ = sa.previous.rawData.Close()
+	}
+	sheetPath := sa.sw.file.sheetMap[sa.sw.Sheet]
+	sa.sw.file.Sheet.Delete(sheetPath)
+	sa.sw.file.checked.Delete(sheetPath)
+	sa.sw.file.Pkg.Delete(sheetPath)
+	return nil
+}
+
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
 // names order range.
 func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
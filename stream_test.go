@@ -264,6 +264,73 @@ func TestStreamInsertPageBreak(t *testing.T) {
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamInsertPageBreak.xlsx")))
 }
 
+func TestStreamWorksheetMetadata(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetConditionalFormat("A2:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+	}))
+	assert.NoError(t, sw.SetConditionalFormat("B2:B10", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "C2:C10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, sw.AddDataValidation(dv))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Value", "Bar", "Option", "Link"}))
+	for row := 2; row <= 10; row++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", row), []interface{}{row, row * 10, 1, "Link"}))
+	}
+	assert.NoError(t, sw.SetCellHyperLink("D2", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, sw.SetCellHyperLink("D3", "Sheet1!A1", "Location"))
+	assert.NoError(t, sw.AddComment(Comment{Cell: "A1", Author: "Excelize", Text: "Note"}))
+	// Test add metadata with invalid settings
+	assert.Equal(t, newInvalidLinkTypeError(""), sw.SetCellHyperLink("D4", "", ""))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.AddComment(Comment{Cell: "A"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWorksheetMetadata.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamWorksheetMetadata.xlsx"))
+	assert.NoError(t, err)
+	// Check the order of the elements in the worksheet
+	content, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	var offset int
+	for _, element := range []string{"<sheetData>", "<conditionalFormatting", "<dataValidations", "<hyperlinks", "<legacyDrawing", "<extLst"} {
+		idx := strings.LastIndex(string(content.([]byte)), element)
+		assert.Greater(t, idx, offset, element)
+		offset = idx
+	}
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 2)
+	assert.True(t, formats["B2:B10"][0].BarSolid)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "C2:C10", dvs[0].Sqref)
+	link, target, err := f.GetCellHyperLink("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	link, target, err = f.GetCellHyperLink("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet1!A1", target)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Note", comments[0].Text)
+	value, err := f.GetCellValue("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", value)
+	assert.NoError(t, f.Close())
+}
+
 func TestNewStreamWriter(t *testing.T) {
 	// Test error exceptions
 	file := NewFile()
@@ -454,3 +521,159 @@ func TestStreamWriterGetRowElement(t *testing.T) {
 		assert.False(t, ok)
 	}
 }
+
+func TestStreamAppender(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row, fmt.Sprintf("R%d", row)}))
+	}
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "D2"))
+	path := filepath.Join("test", "TestStreamAppender.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	expected := [][]string{
+		{"1", "R1"}, {"2", "R2"}, {"3", "New3"}, {"4", "R4"}, {"5", "R5"}, nil, {"7", "New7"},
+	}
+	appendRows := func(f *File) {
+		sa, err := f.NewStreamAppender("Sheet1", 2)
+		assert.NoError(t, err)
+		assert.NoError(t, sa.SetRow("A3", []interface{}{3, "New3"}))
+		assert.Equal(t, newStreamSetRowError(3), sa.SetRow("A3", []interface{}{3}))
+		assert.NoError(t, sa.SetRow("A7", []interface{}{7, "New7"}))
+		assert.NoError(t, sa.Flush())
+	}
+	// Test append rows with the worksheet in memory and in temporary file
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(path, opts)
+		assert.NoError(t, err)
+		appendRows(f)
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows)
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAppender2.xlsx")))
+		assert.NoError(t, f.Close())
+
+		f, err = OpenFile(filepath.Join("test", "TestStreamAppender2.xlsx"))
+		assert.NoError(t, err)
+		rows, err = f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows)
+		width, err := f.GetColWidth("Sheet1", "A")
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, width)
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 1)
+		assert.Equal(t, "C1", mergeCells[0].GetStartAxis())
+		assert.NoError(t, f.Close())
+	}
+
+	// Test append rows on the worksheet which has been loaded
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Updated"))
+	sa, err := f.NewStreamAppender("Sheet1", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, newStreamSetRowError(5), sa.SetRow("A5", []interface{}{5}))
+	assert.NoError(t, sa.SetRow("A6", []interface{}{6}))
+	assert.NoError(t, sa.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "Updated"}, {"2", "R2"}, {"3", "R3"}, {"4", "R4"}, {"5", "R5"}, {"6"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test append rows keeps other loaded worksheets in memory
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Sheet2"))
+	sa, err = f.NewStreamAppender("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.NoError(t, sa.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, sa.Flush())
+	_, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test append rows on the worksheet with namespace prefix
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<x:worksheet xmlns:x="`+NameSpaceSpreadSheet.Value+`"><x:sheetData><x:row r="1"><x:c t="inlineStr"><x:is><x:t>A</x:t></x:is></x:c></x:row></x:sheetData></x:worksheet>`))
+	sa, err = f.NewStreamAppender("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.NoError(t, sa.SetRow("A2", []interface{}{"B"}))
+	assert.NoError(t, sa.Flush())
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `</x:row><row r="2">`)
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `</x:sheetData></x:worksheet>`)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A"}, {"B"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test append rows after stream writing
+	f = NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{3}))
+	assert.NoError(t, sw.Flush())
+	sa, err = f.NewStreamAppender("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.NoError(t, sa.SetRow("A2", []interface{}{2}))
+	assert.NoError(t, sa.Flush())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"2"}, {"3"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test append rows on the worksheet with rows without row number
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="`+NameSpaceSpreadSheet.Value+`"><dimension ref="A1:A2"/><sheetData><row><c t="inlineStr"><is><t>A</t></is></c></row><row><c t="inlineStr"><is><t>B</t></is></c></row></sheetData><pageMargins left="0.7" right="0.7" top="0.75" bottom="0.75" header="0.3" footer="0.3"/></worksheet>`))
+	sa, err = f.NewStreamAppender("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.NoError(t, sa.SetRow("A2", []interface{}{"C"}))
+	assert.NoError(t, sa.Flush())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A"}, {"C"}}, rows)
+	assert.NotContains(t, string(f.readXML("xl/worksheets/sheet1.xml")), "<dimension")
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), "<pageMargins")
+	assert.NoError(t, f.Close())
+
+	// Test create stream appender with invalid sheet name
+	f = NewFile()
+	_, err = f.NewStreamAppender("Sheet:1", 0)
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test create stream appender on not exists worksheet
+	_, err = f.NewStreamAppender("SheetN", 0)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test create stream appender with invalid row number
+	_, err = f.NewStreamAppender("Sheet1", -1)
+	assert.Equal(t, newInvalidRowNumberError(-1), err)
+	// Test create stream appender on the worksheet without sheet data
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet></worksheet>`))
+	_, err = f.NewStreamAppender("Sheet1", 0)
+	assert.Equal(t, ErrStreamSheetData, err)
+	// Test create stream appender on the worksheet with invalid row number
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="A"></row></sheetData></worksheet>`))
+	_, err = f.NewStreamAppender("Sheet1", 1)
+	assert.Equal(t, `strconv.Atoi: parsing "A": invalid syntax`, err.Error())
+	// Test create stream appender on the worksheet with unsupported charset
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.NewStreamAppender("Sheet1", 0)
+	assert.Error(t, err)
+	// Test set row with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData/></worksheet>`))
+	sa, err = f.NewStreamAppender("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sa.SetRow("A", []interface{}{}))
+	assert.NoError(t, sa.Flush())
+	assert.Equal(t, `<worksheet><sheetData></sheetData></worksheet>`, string(f.readXML("xl/worksheets/sheet1.xml")))
+	assert.NoError(t, f.Close())
+}
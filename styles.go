@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -36,6 +37,17 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 	return f.Styles, nil
 }
 
+// featurePropertyBagsReader provides a function to get the pointer to the
+// structure after deserialization of xl/featurePropertyBag/featurePropertyBag.xml.
+func (f *File) featurePropertyBagsReader() (*xlsxFeaturePropertyBags, error) {
+	var bags xlsxFeaturePropertyBags
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLFeaturePropertyBag)))).
+		Decode(&bags); err != nil && err != io.EOF {
+		return &bags, err
+	}
+	return &bags, nil
+}
+
 // styleSheetWriter provides a function to save xl/styles.xml after serialize
 // structure.
 func (f *File) styleSheetWriter() {
@@ -1647,7 +1659,19 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 	if style.CustomNumFmt != nil {
 		numFmtID = getCustomNumFmtID(ss, style)
 	}
+	var bags *xlsxFeaturePropertyBags
 	for xfID, xf := range ss.CellXfs.Xf {
+		// Skip the cell formats with in-cell checkbox feature
+		if idx := getXfComplementIndex(xf); idx != -1 {
+			if bags == nil {
+				if bags, err = f.featurePropertyBagsReader(); err != nil {
+					return styleID, err
+				}
+			}
+			if bags.isCheckboxComplement(idx) {
+				continue
+			}
+		}
 		if getXfIDFuncs["numFmt"](numFmtID, xf, style) &&
 			getXfIDFuncs["font"](fontID, xf, style) &&
 			getXfIDFuncs["fill"](fillID, xf, style) &&
@@ -1665,16 +1689,55 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 // format by given style format. The parameters are the same with the NewStyle
 // function.
 func (f *File) NewConditionalStyle(style *Style) (int, error) {
+	s, dxf, err := f.newDxf(style)
+	if err != nil {
+		return 0, err
+	}
+	return s.appendDxf(dxf), err
+}
+
+// appendDxf appends the differential formatting to the style sheet and
+// returns the index of it.
+func (s *xlsxStyleSheet) appendDxf(dxf *xlsxDxf) int {
+	if s.Dxfs == nil {
+		s.Dxfs = &xlsxDxfs{}
+	}
+	s.Dxfs.Count++
+	s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, dxf)
+	return s.Dxfs.Count - 1
+}
+
+// getDxfID provides a function to get the differential formatting ID by given
+// style format, the existing differential formatting with the same format will
+// be reused, or a new one will be created if not exist.
+func (f *File) getDxfID(style *Style) (int, error) {
+	s, dxf, err := f.newDxf(style)
+	if err != nil {
+		return 0, err
+	}
+	if s.Dxfs != nil {
+		for idx, d := range s.Dxfs.Dxfs {
+			if reflect.DeepEqual(d, dxf) {
+				return idx, err
+			}
+		}
+	}
+	return s.appendDxf(dxf), err
+}
+
+// newDxf provides a function to create the differential formatting by given
+// style format.
+func (f *File) newDxf(style *Style) (*xlsxStyleSheet, *xlsxDxf, error) {
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
 		f.mu.Unlock()
-		return 0, err
+		return s, nil, err
 	}
 	f.mu.Unlock()
 	fs, err := parseFormatStyleSet(style)
 	if err != nil {
-		return 0, err
+		return s, nil, err
 	}
 	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
 		fs.DecimalPlaces = intPtr(2)
@@ -1695,12 +1758,7 @@ func (f *File) NewConditionalStyle(style *Style) (int, error) {
 		dxf.Protection = newProtection(fs)
 	}
 	dxf.NumFmt = newDxfNumFmt(s, style, &dxf)
-	if s.Dxfs == nil {
-		s.Dxfs = &xlsxDxfs{}
-	}
-	s.Dxfs.Count++
-	s.Dxfs.Dxfs = append(s.Dxfs.Dxfs, &dxf)
-	return s.Dxfs.Count - 1, nil
+	return s, &dxf, err
 }
 
 // GetConditionalStyle returns conditional format style definition by specified
@@ -1729,10 +1787,226 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	f.extractProtection(xf.Protection, s, style)
 	if xf.NumFmt != nil {
 		f.extractNumFmt(&xf.NumFmt.NumFmtID, s, style)
+		// The custom number format of conditional format style stored in the
+		// differential formatting record
+		if style.NumFmt == 0 && style.CustomNumFmt == nil && xf.NumFmt.FormatCode != "" {
+			style.CustomNumFmt = &xf.NumFmt.FormatCode
+		}
 	}
 	return style, nil
 }
 
+// tableStyleElementTypes defined the area types of the elements in the table
+// and pivot table styles.
+var tableStyleElementTypes = map[bool][]string{
+	false: {
+		"wholeTable", "headerRow", "totalRow", "firstColumn", "lastColumn",
+		"firstRowStripe", "secondRowStripe", "firstColumnStripe",
+		"secondColumnStripe", "firstHeaderCell", "lastHeaderCell",
+		"firstTotalCell", "lastTotalCell",
+	},
+	true: {
+		"wholeTable", "headerRow", "totalRow", "firstColumn", "firstRowStripe",
+		"secondRowStripe", "firstColumnStripe", "secondColumnStripe",
+		"firstHeaderCell", "firstSubtotalColumn", "secondSubtotalColumn",
+		"thirdSubtotalColumn", "firstSubtotalRow", "secondSubtotalRow",
+		"thirdSubtotalRow", "blankRow", "firstColumnSubheading",
+		"secondColumnSubheading", "thirdColumnSubheading",
+		"firstRowSubheading", "secondRowSubheading", "thirdRowSubheading",
+		"pageFieldLabels", "pageFieldValues",
+	},
+}
+
+// AddTableStyle provides a function to create a custom table or pivot table
+// style by given style settings, the custom style can be applied to the
+// tables or pivot tables by setting the StyleName of the table or the
+// PivotTableStyleName of the pivot table to the name of the custom style. For
+// example, create a custom table style named "BrandTable":
+//
+//	err := f.AddTableStyle(&excelize.TableStyle{
+//	    Name: "BrandTable",
+//	    Elements: []excelize.TableStyleElement{
+//	        {
+//	            Type: "wholeTable",
+//	            Style: &excelize.Style{
+//	                Border: []excelize.Border{
+//	                    {Type: "left", Color: "1F4E78", Style: 1},
+//	                    {Type: "top", Color: "1F4E78", Style: 1},
+//	                    {Type: "bottom", Color: "1F4E78", Style: 1},
+//	                    {Type: "right", Color: "1F4E78", Style: 1},
+//	                },
+//	            },
+//	        },
+//	        {
+//	            Type: "headerRow",
+//	            Style: &excelize.Style{
+//	                Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+//	                Fill: excelize.Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+//	            },
+//	        },
+//	        {
+//	            Type:  "firstRowStripe",
+//	            Size:  2,
+//	            Style: &excelize.Style{Fill: excelize.Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}},
+//	        },
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddTable("Sheet1", &excelize.Table{Range: "A1:D5", StyleName: "BrandTable"})
+//
+// The optional element types of the table style are:
+//
+//	wholeTable
+//	headerRow
+//	totalRow
+//	firstColumn
+//	lastColumn
+//	firstRowStripe
+//	secondRowStripe
+//	firstColumnStripe
+//	secondColumnStripe
+//	firstHeaderCell
+//	lastHeaderCell
+//	firstTotalCell
+//	lastTotalCell
+//
+// The optional element types of the pivot table style are:
+//
+//	wholeTable
+//	headerRow
+//	totalRow
+//	firstColumn
+//	firstRowStripe
+//	secondRowStripe
+//	firstColumnStripe
+//	secondColumnStripe
+//	firstHeaderCell
+//	firstSubtotalColumn
+//	secondSubtotalColumn
+//	thirdSubtotalColumn
+//	firstSubtotalRow
+//	secondSubtotalRow
+//	thirdSubtotalRow
+//	blankRow
+//	firstColumnSubheading
+//	secondColumnSubheading
+//	thirdColumnSubheading
+//	firstRowSubheading
+//	secondRowSubheading
+//	thirdRowSubheading
+//	pageFieldLabels
+//	pageFieldValues
+//
+// The Size of the element specifies the number of rows or columns in a single
+// band of the stripe, which only be used for the firstRowStripe,
+// secondRowStripe, firstColumnStripe and secondColumnStripe elements, the
+// value must be between 1 and 9.
+func (f *File) AddTableStyle(style *TableStyle) error {
+	if style == nil || style.Name == "" || utf8.RuneCountInString(style.Name) > MaxFieldLength {
+		return ErrParameterInvalid
+	}
+	styles, err := f.GetTableStyles()
+	if err != nil {
+		return err
+	}
+	for _, s := range styles {
+		if strings.EqualFold(s.Name, style.Name) {
+			return ErrExistsTableStyleName
+		}
+	}
+	tableStyle := &xlsxTableStyle{Name: style.Name, Pivot: boolPtr(false)}
+	if style.Pivot {
+		tableStyle.Pivot, tableStyle.Table = nil, boolPtr(false)
+	}
+	for _, element := range style.Elements {
+		if inStrSlice(tableStyleElementTypes[style.Pivot], element.Type, true) == -1 ||
+			element.Size < 0 || element.Size > 9 {
+			return ErrParameterInvalid
+		}
+		if element.Size > 0 && !strings.HasSuffix(element.Type, "Stripe") {
+			return ErrParameterInvalid
+		}
+		elem := &xlsxTableStyleElement{Type: element.Type, Size: element.Size}
+		if element.Style != nil {
+			dxfID, err := f.NewConditionalStyle(element.Style)
+			if err != nil {
+				return err
+			}
+			elem.DxfID = intPtr(dxfID)
+		}
+		tableStyle.TableStyleElement = append(tableStyle.TableStyleElement, elem)
+	}
+	tableStyle.Count = len(tableStyle.TableStyleElement)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	if s.TableStyles == nil {
+		s.TableStyles = &xlsxTableStyles{
+			DefaultTableStyle: "TableStyleMedium2",
+			DefaultPivotStyle: "PivotStyleLight16",
+		}
+	}
+	s.TableStyles.TableStyles = append(s.TableStyles.TableStyles, tableStyle)
+	s.TableStyles.Count = len(s.TableStyles.TableStyles)
+	return err
+}
+
+// GetTableStyles provides a function to get all custom table and pivot table
+// styles in the workbook.
+func (f *File) GetTableStyles() ([]TableStyle, error) {
+	var styles []TableStyle
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil || s.TableStyles == nil {
+		return styles, err
+	}
+	for _, tableStyle := range s.TableStyles.TableStyles {
+		style := TableStyle{
+			Name:  tableStyle.Name,
+			Pivot: tableStyle.Table != nil && !*tableStyle.Table,
+		}
+		for _, elem := range tableStyle.TableStyleElement {
+			element := TableStyleElement{Type: elem.Type, Size: elem.Size}
+			if elem.DxfID != nil {
+				if element.Style, err = f.GetConditionalStyle(*elem.DxfID); err != nil {
+					return styles, err
+				}
+			}
+			style.Elements = append(style.Elements, element)
+		}
+		styles = append(styles, style)
+	}
+	return styles, err
+}
+
+// DeleteTableStyle provides a function to delete the custom table or pivot
+// table style by given style name.
+func (f *File) DeleteTableStyle(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	if s.TableStyles != nil {
+		for i, tableStyle := range s.TableStyles.TableStyles {
+			if strings.EqualFold(tableStyle.Name, name) {
+				s.TableStyles.TableStyles = append(s.TableStyles.TableStyles[:i], s.TableStyles.TableStyles[i+1:]...)
+				s.TableStyles.Count = len(s.TableStyles.TableStyles)
+				return err
+			}
+		}
+	}
+	return newNoExistTableStyleError(name)
+}
+
 // newDxfNumFmt provides a function to create number format for conditional
 // format styles.
 func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxNumFmt {
@@ -568,6 +568,25 @@ func TestGetStyleID(t *testing.T) {
 		},
 	}, &Style{NumFmt: 0, Font: &Font{}})
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+
+	// Test get style ID with the cell formats which have extension list, only
+	// the cell formats with in-cell checkbox feature will not be reused
+	f = NewFile()
+	ss, err := f.stylesReader()
+	assert.NoError(t, err)
+	ss.CellXfs.Xf[0].ExtLst = &xlsxExtLst{Ext: `<ext uri="{00000000-0000-0000-0000-000000000000}"/>`}
+	styleID, err = f.getStyleID(ss, &Style{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A1"))
+	ss.CellXfs.Xf = ss.CellXfs.Xf[1:]
+	styleID, err = f.getStyleID(ss, &Style{})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, styleID)
+	// Test get style ID with unsupported charset feature property bags
+	f.Pkg.Store(defaultXMLFeaturePropertyBag, MacintoshCyrillicCharset)
+	_, err = f.getStyleID(ss, &Style{})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestGetFillID(t *testing.T) {
@@ -759,3 +778,87 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAddTableStyle(t *testing.T) {
+	f := NewFile()
+	headerStyle := &Style{
+		Font: &Font{Bold: true, Color: "FFFFFF"},
+		Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+	}
+	assert.NoError(t, f.AddTableStyle(&TableStyle{
+		Name: "BrandTable",
+		Elements: []TableStyleElement{
+			{Type: "wholeTable", Style: &Style{Border: []Border{{Type: "top", Color: "1F4E78", Style: 1}}}},
+			{Type: "headerRow", Style: headerStyle},
+			{Type: "firstRowStripe", Size: 2, Style: &Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}}},
+			{Type: "totalRow"},
+		},
+	}))
+	assert.NoError(t, f.AddTableStyle(&TableStyle{
+		Name: "BrandPivot", Pivot: true,
+		Elements: []TableStyleElement{{Type: "pageFieldLabels", Style: headerStyle}},
+	}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C3", StyleName: "BrandTable"}))
+	styles, err := f.GetTableStyles()
+	assert.NoError(t, err)
+	assert.Len(t, styles, 2)
+	assert.Equal(t, "BrandTable", styles[0].Name)
+	assert.False(t, styles[0].Pivot)
+	assert.Len(t, styles[0].Elements, 4)
+	assert.Equal(t, "headerRow", styles[0].Elements[1].Type)
+	assert.True(t, styles[0].Elements[1].Style.Font.Bold)
+	assert.Equal(t, []string{"1F4E78"}, styles[0].Elements[1].Style.Fill.Color)
+	assert.Equal(t, 2, styles[0].Elements[2].Size)
+	assert.Nil(t, styles[0].Elements[3].Style)
+	assert.Equal(t, "BrandPivot", styles[1].Name)
+	assert.True(t, styles[1].Pivot)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableStyle.xlsx")))
+	// Test read back the custom table styles
+	f, err = OpenFile(filepath.Join("test", "TestAddTableStyle.xlsx"))
+	assert.NoError(t, err)
+	readStyles, err := f.GetTableStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, styles, readStyles)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "BrandTable", tables[0].StyleName)
+	// Test add table style with invalid settings
+	for _, style := range []*TableStyle{
+		nil,
+		{},
+		{Name: strings.Repeat("s", MaxFieldLength+1)},
+		{Name: "Style", Elements: []TableStyleElement{{Type: "unknown"}}},
+		{Name: "Style", Elements: []TableStyleElement{{Type: "pageFieldLabels"}}},
+		{Name: "Style", Elements: []TableStyleElement{{Type: "firstRowStripe", Size: 10}}},
+		{Name: "Style", Elements: []TableStyleElement{{Type: "headerRow", Size: 1}}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddTableStyle(style))
+	}
+	assert.Equal(t, ErrExistsTableStyleName, f.AddTableStyle(&TableStyle{Name: "brandtable"}))
+	assert.Equal(t, ErrFontSize, f.AddTableStyle(&TableStyle{
+		Name: "Style", Elements: []TableStyleElement{{Type: "headerRow", Style: &Style{Font: &Font{Size: MaxFontSize + 1}}}},
+	}))
+	// Test delete table style
+	assert.NoError(t, f.DeleteTableStyle("BrandPivot"))
+	assert.Equal(t, newNoExistTableStyleError("BrandPivot"), f.DeleteTableStyle("BrandPivot"))
+	styles, err = f.GetTableStyles()
+	assert.NoError(t, err)
+	assert.Len(t, styles, 1)
+	assert.NoError(t, f.Close())
+	// Test get table styles with invalid differential formatting record index
+	f = NewFile()
+	assert.NoError(t, f.AddTableStyle(&TableStyle{Name: "Style", Elements: []TableStyleElement{{Type: "headerRow", Style: headerStyle}}}))
+	f.Styles.Dxfs.Dxfs = nil
+	_, err = f.GetTableStyles()
+	assert.Equal(t, newInvalidStyleID(0), err)
+	// Test add, get and delete table style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTableStyle(&TableStyle{Name: "Style"}), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.GetTableStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	assert.EqualError(t, f.DeleteTableStyle("Style"), "XML syntax error on line 1: invalid UTF-8")
+}
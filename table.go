@@ -16,9 +16,14 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -42,9 +47,29 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	for _, column := range opts.Columns {
+		if _, ok := tableTotalsRowFunctions[column.TotalsRowFunction]; !ok &&
+			column.TotalsRowFunction != "" && column.TotalsRowFunction != "none" &&
+			(column.TotalsRowFunction != "custom" || column.TotalsRowFormula == "") {
+			return opts, ErrParameterInvalid
+		}
+	}
 	return opts, err
 }
 
+// tableTotalsRowFunctions defined the function numbers of the SUBTOTAL
+// function for the totals row functions of the table column.
+var tableTotalsRowFunctions = map[string]int{
+	"average":   101,
+	"count":     103,
+	"countNums": 102,
+	"max":       104,
+	"min":       105,
+	"stdDev":    107,
+	"sum":       109,
+	"var":       110,
+}
+
 // AddTable provides the method to add table in a worksheet by given worksheet
 // name, range reference and format set. For example, create a table of A1:D5
 // on Sheet1:
@@ -64,11 +89,33 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	    ShowColumnStripes: true,
 //	})
 //
+// Create a table of A1:C6 with custom column names, a calculated column, the
+// number format of the column and the totals row:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range:         "A1:C6",
+//	    Name:          "Sales",
+//	    ShowTotalsRow: true,
+//	    Columns: []excelize.TableColumn{
+//	        {Name: "Qty", TotalsRowLabel: "Total"},
+//	        {Name: "Price", NumFmt: 4, TotalsRowFunction: "average"},
+//	        {
+//	            Name:                    "Amount",
+//	            CalculatedColumnFormula: "[@Qty]*[@Price]",
+//	            TotalsRowFunction:       "sum",
+//	        },
+//	    },
+//	})
+//
 // Note that the table must be at least two lines including the header. The
 // header cells must contain strings and must be unique, and must set the
 // header row data of the table before calling the AddTable function. Multiple
 // tables range reference that can't have an intersection.
 //
+// The last row of the range reference will be the totals row if the
+// ShowTotalsRow is true. The formulas of the calculated columns will be filled
+// down into the new rows automatically when inserting rows in the table.
+//
 // Name: The name of the table, in the same worksheet name of the table should
 // be unique, starts with a letter or underscore (_), doesn't include a
 // space or character, and should be no more than 255 characters
@@ -78,6 +125,9 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
+//
+// The name of the custom table style which created by the AddTableStyle
+// function can also be used as the StyleName.
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
@@ -162,12 +212,507 @@ func (f *File) GetTables(sheet string) ([]Table, error) {
 				table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
 				table.ShowRowStripes = &t.TableStyleInfo.ShowRowStripes
 			}
+			if t.HeaderRowCount != nil && *t.HeaderRowCount == 0 {
+				table.ShowHeaderRow = boolPtr(false)
+			}
+			table.ShowTotalsRow = t.TotalsRowCount > 0
+			if table.Columns, err = f.getTableColumns(&t); err != nil {
+				return tables, err
+			}
 			tables = append(tables, table)
 		}
 	}
 	return tables, err
 }
 
+// getTableColumns provides a function to get the settings of the columns in
+// the table.
+func (f *File) getTableColumns(t *xlsxTable) ([]TableColumn, error) {
+	var columns []TableColumn
+	if t.TableColumns == nil {
+		return columns, nil
+	}
+	for _, tc := range t.TableColumns.TableColumn {
+		column := TableColumn{
+			Name:              tc.Name,
+			TotalsRowFunction: tc.TotalsRowFunction,
+			TotalsRowLabel:    tc.TotalsRowLabel,
+		}
+		if tc.CalculatedColumnFormula != nil {
+			column.CalculatedColumnFormula = tc.CalculatedColumnFormula.Content
+		}
+		if tc.TotalsRowFormula != nil {
+			column.TotalsRowFormula = tc.TotalsRowFormula.Content
+		}
+		if tc.DataDxfID != nil {
+			style, err := f.GetConditionalStyle(*tc.DataDxfID)
+			if err != nil {
+				return columns, err
+			}
+			column.NumFmt, column.CustomNumFmt = style.NumFmt, style.CustomNumFmt
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// tableRefInfo directly maps the worksheet name, coordinates and definition of
+// a table in the workbook.
+type tableRefInfo struct {
+	sheet       string
+	tableXML    string
+	coordinates []int
+	table       *xlsxTable
+}
+
+// getWorkbookTables returns all tables in the workbook, the keys of the map are
+// the lowercase names of the tables.
+func (f *File) getWorkbookTables() (map[string]*tableRefInfo, error) {
+	tables := map[string]*tableRefInfo{}
+	for _, sheet := range f.GetSheetList() {
+		sheetTables, err := f.GetTables(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return tables, err
+		}
+		for _, table := range sheetTables {
+			content, _ := f.Pkg.Load(table.tableXML)
+			var t xlsxTable
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return tables, err
+			}
+			coordinates, err := rangeRefToCoordinates(t.Ref)
+			if err != nil {
+				return tables, err
+			}
+			_ = sortCoordinates(coordinates)
+			tables[strings.ToLower(t.Name)] = &tableRefInfo{
+				sheet: sheet, tableXML: table.tableXML, coordinates: coordinates, table: &t,
+			}
+		}
+	}
+	return tables, nil
+}
+
+// SetTable provides the method to update the settings of an existing table by
+// given table name and the new settings. The table will be resized if the
+// Range is not empty, and will be renamed if the Name is not empty and
+// different from the current table name, the structured references to the
+// table in the formulas and defined names of the workbook will be updated. The
+// other settings such as style, header row and totals row will be replaced by
+// the given settings. For example, get the table named "Table1" and append
+// two rows to it, change the table name and style, and show the totals row:
+//
+//	tables, err := f.GetTables("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	tbl := tables[0]
+//	tbl.Range = "A1:D8"
+//	tbl.Name = "Sales"
+//	tbl.StyleName = "TableStyleMedium9"
+//	tbl.ShowTotalsRow = true
+//	tbl.Columns = []excelize.TableColumn{{TotalsRowLabel: "Total"}}
+//	err = f.SetTable("Table1", &tbl)
+//
+// When the Range is empty or not changed, the table will be extended or shrunk
+// by one row for toggling the header row or totals row, the header row will be
+// added above the first data row, and the totals row will be added below the
+// last data row of the table. The settings of the columns will be applied in
+// order if the Columns is not empty, the columns will be renamed if the column
+// name specified. An error will be returned if the new range of the table not
+// contains any data row, overlaps with another table or the auto filter of the
+// worksheet, or the columns removed from the table are referenced by formulas.
+func (f *File) SetTable(name string, table *Table) error {
+	if table == nil {
+		return ErrParameterInvalid
+	}
+	if err := checkDefinedName(name); err != nil {
+		return err
+	}
+	opts := *table
+	if opts.Name == "" {
+		opts.Name = name
+	}
+	if _, err := parseTableOptions(&opts); err != nil {
+		return err
+	}
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	info, ok := tables[strings.ToLower(name)]
+	if !ok {
+		return newNoExistTableError(name)
+	}
+	if other, ok := tables[strings.ToLower(opts.Name)]; ok && other != info {
+		return ErrExistsTableName
+	}
+	t, sheet := info.table, info.sheet
+	coordinates, err := f.getSetTableCoordinates(sheet, t, info.coordinates, &opts)
+	if err != nil {
+		return err
+	}
+	if err = f.checkTableColumnsRefs(sheet, t, info.coordinates, coordinates); err != nil {
+		return err
+	}
+	oldColumns, oldName := map[int]string{}, t.Name
+	for i, columnName := range getTableColumnNames(t) {
+		oldColumns[info.coordinates[0]+i] = columnName
+	}
+	if err = f.setTableHeaderAndTotals(sheet, t, info.coordinates, coordinates, &opts); err != nil {
+		return err
+	}
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	t.Ref, _ = coordinatesToRangeRef(coordinates)
+	t.Name, t.DisplayName = opts.Name, opts.Name
+	t.TableStyleInfo = &xlsxTableStyleInfo{
+		Name:              opts.StyleName,
+		ShowFirstColumn:   opts.ShowFirstColumn,
+		ShowLastColumn:    opts.ShowLastColumn,
+		ShowRowStripes:    *opts.ShowRowStripes,
+		ShowColumnStripes: opts.ShowColumnStripes,
+	}
+	if t.HeaderRowCount == nil {
+		if t.AutoFilter == nil {
+			t.AutoFilter = &xlsxAutoFilter{}
+		}
+		t.AutoFilter.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2 - t.TotalsRowCount})
+		for i, column := range opts.Columns {
+			if column.Name == "" || x1+i > x2 {
+				continue
+			}
+			// Keep the settings of the renamed column
+			if idx := x1 + i - info.coordinates[0]; idx >= 0 && idx < len(getTableColumnList(t)) &&
+				inStrSlice(getTableColumnNames(t), column.Name, false) == -1 {
+				t.TableColumns.TableColumn[idx].Name = column.Name
+			}
+			cell, _ := CoordinatesToCellName(x1+i, y1)
+			if err = f.SetCellStr(sheet, cell, column.Name); err != nil {
+				return err
+			}
+		}
+		_ = f.setTableColumns(sheet, true, x1, y1, x2, t)
+	} else {
+		setTableColumnsWidth(t, x2-x1+1)
+	}
+	if err = f.setTableColumnOptions(sheet, t, coordinates, opts.Columns); err != nil {
+		return err
+	}
+	renamed := map[string]string{}
+	newColumns := getTableColumnNames(t)
+	for col, columnName := range oldColumns {
+		if col < x1 || col > x2 {
+			renamed[strings.ToLower(columnName)] = ""
+			continue
+		}
+		if newColumns[col-x1] != columnName {
+			renamed[strings.ToLower(columnName)] = newColumns[col-x1]
+		}
+	}
+	if err = f.renameStructuredRefs(sheet, t, oldName, coordinates, renamed); err != nil {
+		return err
+	}
+	if err = f.fillTableCalculatedColumns(sheet, t, coordinates); err != nil {
+		return err
+	}
+	content, _ := xml.Marshal(t)
+	f.saveFileList(info.tableXML, content)
+	return nil
+}
+
+// ResizeTable provides the method to change the range reference of an
+// existing table by given table name and the new range reference, the header
+// row of the table must be in the first row of the new range reference. For
+// example, extend the table named "Table1" to A1:D100:
+//
+//	err := f.ResizeTable("Table1", "A1:D100")
+//
+// The new range reference has the same restrictions as the Range of SetTable.
+func (f *File) ResizeTable(name, rangeRef string) error {
+	if err := checkDefinedName(name); err != nil {
+		return err
+	}
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	info, ok := tables[strings.ToLower(name)]
+	if !ok {
+		return newNoExistTableError(name)
+	}
+	tbls, err := f.GetTables(info.sheet)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tbls {
+		if strings.EqualFold(tbl.Name, name) {
+			tbl.Range, tbl.Columns = rangeRef, nil
+			return f.SetTable(tbl.Name, &tbl)
+		}
+	}
+	return newNoExistTableError(name)
+}
+
+// getSetTableCoordinates provides a function to get the new coordinates of
+// the table by given worksheet name, table, current coordinates and the new
+// settings of the table.
+func (f *File) getSetTableCoordinates(sheet string, t *xlsxTable, coordinates []int, opts *Table) ([]int, error) {
+	showHeaderRow := opts.ShowHeaderRow == nil || *opts.ShowHeaderRow
+	hasHeaderRow := t.HeaderRowCount == nil || *t.HeaderRowCount != 0
+	newCoordinates := append([]int{}, coordinates...)
+	if opts.Range != "" && opts.Range != t.Ref {
+		var err error
+		if newCoordinates, err = rangeRefToCoordinates(opts.Range); err != nil {
+			return newCoordinates, err
+		}
+		_ = sortCoordinates(newCoordinates)
+	} else {
+		if hasHeaderRow && !showHeaderRow {
+			newCoordinates[1]++
+		}
+		if !hasHeaderRow && showHeaderRow {
+			if newCoordinates[1]--; newCoordinates[1] < 1 {
+				return newCoordinates, ErrParameterInvalid
+			}
+		}
+		if t.TotalsRowCount == 0 && opts.ShowTotalsRow {
+			newCoordinates[3]++
+		}
+		if t.TotalsRowCount > 0 && !opts.ShowTotalsRow {
+			newCoordinates[3]--
+		}
+	}
+	// The table at least has one data row
+	minRows := 0
+	if showHeaderRow {
+		minRows++
+	}
+	if opts.ShowTotalsRow {
+		minRows++
+	}
+	if newCoordinates[3]-newCoordinates[1] < minRows {
+		return newCoordinates, ErrTableRange
+	}
+	if _, err := coordinatesToRangeRef(newCoordinates); err != nil {
+		return newCoordinates, err
+	}
+	return newCoordinates, f.checkTableRangeOverlap(sheet, t, newCoordinates)
+}
+
+// checkTableRangeOverlap provides a function to check if the given range of
+// the table overlaps with the other tables or the auto filter on the
+// worksheet.
+func (f *File) checkTableRangeOverlap(sheet string, t *xlsxTable, coordinates []int) error {
+	overlap := func(rect []int) bool {
+		return rect[0] <= coordinates[2] && coordinates[0] <= rect[2] &&
+			rect[1] <= coordinates[3] && coordinates[1] <= rect[3]
+	}
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	for _, info := range tables {
+		if info.sheet == sheet && info.table.ID != t.ID && overlap(info.coordinates) {
+			return ErrTableRangeOverlap
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return err
+	}
+	rect, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(rect)
+	if overlap(rect) {
+		return ErrTableRangeOverlap
+	}
+	return err
+}
+
+// checkTableColumnsRefs provides a function to check if the columns of the
+// table which will be removed by resizing are referenced by the formulas of
+// the workbook by given worksheet name, table, the current and new
+// coordinates of the table.
+func (f *File) checkTableColumnsRefs(sheet string, t *xlsxTable, oldCoordinates, coordinates []int) error {
+	removed, columns := map[string]bool{}, getTableColumnNames(t)
+	for i, columnName := range columns {
+		if col := oldCoordinates[0] + i; col < coordinates[0] || col > coordinates[2] {
+			removed[strings.ToLower(columnName)] = true
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	var referenced string
+	check := func(formula string, unqualified bool) error {
+		if !strings.Contains(formula, "[") {
+			return nil
+		}
+		_, err := replaceStructuredRefs(formula, func(ref *structuredRef, text string) (string, error) {
+			if (ref.table != "" || !unqualified) && !strings.EqualFold(ref.table, t.Name) {
+				return text, nil
+			}
+			for _, column := range ref.columns {
+				if removed[strings.ToLower(column)] && referenced == "" {
+					referenced = column
+				}
+			}
+			return text, nil
+		})
+		return err
+	}
+	for _, sheetN := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		for r := range ws.SheetData.Row {
+			for _, cell := range ws.SheetData.Row[r].C {
+				if cell.F == nil || cell.F.Content == "" {
+					continue
+				}
+				col, row, _ := CellNameToCoordinates(cell.R)
+				unqualified := sheetN == sheet && cellInRange([]int{col, row}, oldCoordinates)
+				if unqualified && (col < coordinates[0] || col > coordinates[2]) {
+					continue
+				}
+				if err = check(cell.F.Content, unqualified); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for _, definedName := range wb.DefinedNames.DefinedName {
+			if err = check(definedName.Data, false); err != nil {
+				return err
+			}
+		}
+	}
+	tables, err := f.getWorkbookTables()
+	if err != nil {
+		return err
+	}
+	for _, info := range tables {
+		self := info.table.ID == t.ID
+		for i, column := range getTableColumnList(info.table) {
+			if self && removed[strings.ToLower(columns[i])] {
+				continue
+			}
+			for _, formula := range []*xlsxTableFormula{column.CalculatedColumnFormula, column.TotalsRowFormula} {
+				if formula == nil {
+					continue
+				}
+				if err = check(formula.Content, self); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if referenced != "" {
+		return newTableColumnReferencedError(t.Name, referenced)
+	}
+	return err
+}
+
+// setTableHeaderAndTotals provides a function to toggle the header row and
+// totals row of the table by given worksheet name, table, current and new
+// coordinates and the new settings of the table.
+func (f *File) setTableHeaderAndTotals(sheet string, t *xlsxTable, oldCoordinates, coordinates []int, opts *Table) error {
+	showHeaderRow := opts.ShowHeaderRow == nil || *opts.ShowHeaderRow
+	hasHeaderRow := t.HeaderRowCount == nil || *t.HeaderRowCount != 0
+	if hasHeaderRow && !showHeaderRow {
+		t.HeaderRowCount, t.AutoFilter = intPtr(0), nil
+	}
+	if !hasHeaderRow && showHeaderRow {
+		t.HeaderRowCount = nil
+		for i, columnName := range getTableColumnNames(t) {
+			if coordinates[0]+i > coordinates[2] {
+				break
+			}
+			cell, _ := CoordinatesToCellName(coordinates[0]+i, coordinates[1])
+			if err := f.SetCellStr(sheet, cell, columnName); err != nil {
+				return err
+			}
+		}
+	}
+	columns, err := f.getTableColumns(t)
+	if err != nil {
+		return err
+	}
+	if t.TotalsRowCount > 0 && (!opts.ShowTotalsRow || oldCoordinates[3] != coordinates[3]) {
+		for i := range getTableColumnList(t) {
+			cell, _ := CoordinatesToCellName(oldCoordinates[0]+i, oldCoordinates[3])
+			if err = f.SetCellFormula(sheet, cell, ""); err != nil {
+				return err
+			}
+			if err = f.SetCellValue(sheet, cell, nil); err != nil {
+				return err
+			}
+		}
+		t.TotalsRowCount = 0
+	}
+	if !opts.ShowTotalsRow {
+		for _, column := range getTableColumnList(t) {
+			column.TotalsRowFunction, column.TotalsRowLabel, column.TotalsRowFormula = "", "", nil
+		}
+	}
+	if t.TotalsRowCount == 0 && opts.ShowTotalsRow {
+		t.TotalsRowCount = 1
+		if len(opts.Columns) > 0 {
+			return err
+		}
+		for i, tc := range getTableColumnList(t) {
+			if coordinates[0]+i > coordinates[2] {
+				break
+			}
+			if err = f.setTableTotalsRow(sheet, t, tc, coordinates[0]+i, coordinates[3], columns[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// setTableColumnsWidth provides a function to set the number of columns in
+// the table without header row, the new columns will be named by default.
+func setTableColumnsWidth(t *xlsxTable, width int) {
+	if t.TableColumns == nil {
+		t.TableColumns = &xlsxTableColumns{}
+	}
+	columns := t.TableColumns.TableColumn
+	if len(columns) > width {
+		columns = columns[:width]
+	}
+	t.TableColumns.TableColumn = columns
+	for idx := len(columns) + 1; idx <= width; idx++ {
+		name := "Column" + strconv.Itoa(idx)
+		for i := idx + 1; inStrSlice(getTableColumnNames(t), name, false) != -1; i++ {
+			name = "Column" + strconv.Itoa(i)
+		}
+		t.TableColumns.TableColumn = append(t.TableColumns.TableColumn, &xlsxTableColumn{Name: name})
+	}
+	columns = t.TableColumns.TableColumn
+	for i, column := range columns {
+		column.ID = i + 1
+	}
+	t.TableColumns.Count, t.TableColumns.TableColumn = len(columns), columns
+}
+
 // DeleteTable provides the method to delete table by given table name.
 func (f *File) DeleteTable(name string) error {
 	if err := checkDefinedName(name); err != nil {
@@ -291,7 +836,7 @@ func (f *File) setTableColumns(sheet string, showHeaderRow bool, x1, y1, x2 int,
 		}
 		header = append(header, name)
 		if column := getTableColumn(name); column != nil {
-			column.ID, column.DataDxfID, column.QueryTableFieldID = idx, 0, 0
+			column.ID, column.QueryTableFieldID = idx, 0
 			tableColumns = append(tableColumns, column)
 			continue
 		}
@@ -349,11 +894,32 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 	if hideHeaderRow {
 		y1++
 	}
+	// Correct the minimum number of rows, the table with totals row at least
+	// has one data row.
+	if minRows := 2; opts != nil && opts.ShowTotalsRow {
+		if hideHeaderRow {
+			minRows = 1
+		}
+		if y2-y1 < minRows {
+			y2 = y1 + minRows
+		}
+	}
 	// Correct table range reference, such correct C1:B3 to B1:C3.
 	ref, err := coordinatesToRangeRef([]int{x1, y1, x2, y2})
 	if err != nil {
 		return err
 	}
+	if !hideHeaderRow {
+		for i, column := range opts.Columns {
+			if column.Name == "" || x1+i > x2 {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(x1+i, y1)
+			if err = f.SetCellStr(sheet, cell, column.Name); err != nil {
+				return err
+			}
+		}
+	}
 	name := opts.Name
 	if name == "" {
 		name = "Table" + strconv.Itoa(i)
@@ -380,53 +946,195 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
 	}
+	if opts.ShowTotalsRow {
+		t.TotalsRowCount = 1
+		if t.AutoFilter != nil {
+			t.AutoFilter.Ref, _ = coordinatesToRangeRef([]int{x1, y1, x2, y2 - 1})
+		}
+	}
+	if err = f.setTableColumnOptions(sheet, &t, []int{x1, y1, x2, y2}, opts.Columns); err != nil {
+		return err
+	}
 	table, err := xml.Marshal(t)
 	f.saveFileList(tableXML, table)
 	return err
 }
 
-// AutoFilter provides the method to add auto filter in a worksheet by given
-// worksheet name, range reference and settings. An auto filter in Excel is a
-// way of filtering a 2D range of data based on some simple criteria. For
-// example applying an auto filter to a cell range A1:D4 in the Sheet1:
-//
-//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{})
-//
-// Filter data in an auto filter:
-//
-//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
-//	    {Column: "B", Expression: "x != blanks"},
-//	})
-//
-// Column defines the filter columns in an auto filter range based on simple
-// criteria
-//
-// It isn't sufficient to just specify the filter condition. You must also
-// hide any rows that don't match the filter condition. Rows are hidden using
-// the SetRowVisible function. Excelize can't filter rows automatically since
-// this isn't part of the file format.
-//
-// Setting a filter criteria for a column:
-//
-// Expression defines the conditions, the following operators are available
-// for setting the filter criteria:
-//
-//	==
-//	!=
-//	>
-//	<
-//	>=
-//	<=
-//	and
-//	or
-//
-// An expression can comprise a single statement or two statements separated
-// by the 'and' and 'or' operators. For example:
-//
-//	x <  2000
-//	x >  2000
-//	x == 2000
-//	x >  2000 and x <  5000
+// setTableColumnOptions provides a function to set the custom names,
+// calculated column formulas, number formats and totals row of the columns in
+// the table by given worksheet name, table, coordinates of the table and
+// column settings.
+func (f *File) setTableColumnOptions(sheet string, t *xlsxTable, coordinates []int, columns []TableColumn) error {
+	dataStart, dataEnd := coordinates[1]+1, coordinates[3]-t.TotalsRowCount
+	if t.HeaderRowCount != nil && *t.HeaderRowCount == 0 {
+		dataStart = coordinates[1]
+	}
+	for i, column := range columns {
+		if i >= len(t.TableColumns.TableColumn) {
+			break
+		}
+		tc, col := t.TableColumns.TableColumn[i], coordinates[0]+i
+		if column.Name != "" && inStrSlice(getTableColumnNames(t), column.Name, false) == -1 {
+			tc.Name = column.Name
+		}
+		if formula := strings.TrimPrefix(column.CalculatedColumnFormula, "="); formula != "" {
+			tc.CalculatedColumnFormula = &xlsxTableFormula{Content: formula}
+			for row := dataStart; row <= dataEnd; row++ {
+				cell, _ := CoordinatesToCellName(col, row)
+				if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+					return err
+				}
+			}
+		}
+		if column.NumFmt != 0 || column.CustomNumFmt != nil {
+			dxfID, err := f.NewConditionalStyle(&Style{NumFmt: column.NumFmt, CustomNumFmt: column.CustomNumFmt})
+			if err != nil {
+				return err
+			}
+			tc.DataDxfID = intPtr(dxfID)
+			if err = f.setTableColumnNumFmt(sheet, col, dataStart, dataEnd, column); err != nil {
+				return err
+			}
+		}
+		if t.TotalsRowCount == 0 {
+			continue
+		}
+		if err := f.setTableTotalsRow(sheet, t, tc, col, coordinates[3], column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTableColumnNumFmt provides a function to set the number format of the
+// data cells in the table column.
+func (f *File) setTableColumnNumFmt(sheet string, col, dataStart, dataEnd int, column TableColumn) error {
+	styles := map[int]int{}
+	for row := dataStart; row <= dataEnd; row++ {
+		cell, _ := CoordinatesToCellName(col, row)
+		styleID, err := f.GetCellStyle(sheet, cell)
+		if err != nil {
+			return err
+		}
+		if _, ok := styles[styleID]; !ok {
+			style, err := f.GetStyle(styleID)
+			if err != nil {
+				return err
+			}
+			style.NumFmt, style.CustomNumFmt = column.NumFmt, column.CustomNumFmt
+			if styles[styleID], err = f.NewStyle(style); err != nil {
+				return err
+			}
+		}
+		if err = f.SetCellStyle(sheet, cell, cell, styles[styleID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTableTotalsRow provides a function to set the label or function of the
+// table column in the totals row.
+func (f *File) setTableTotalsRow(sheet string, t *xlsxTable, tc *xlsxTableColumn, col, row int, column TableColumn) error {
+	cell, _ := CoordinatesToCellName(col, row)
+	if column.TotalsRowLabel != "" {
+		tc.TotalsRowLabel = column.TotalsRowLabel
+		if err := f.SetCellStr(sheet, cell, column.TotalsRowLabel); err != nil {
+			return err
+		}
+	}
+	if column.TotalsRowFunction == "custom" {
+		formula := strings.TrimPrefix(column.TotalsRowFormula, "=")
+		tc.TotalsRowFunction, tc.TotalsRowFormula = column.TotalsRowFunction, &xlsxTableFormula{Content: formula}
+		return f.SetCellFormula(sheet, cell, formula)
+	}
+	if num, ok := tableTotalsRowFunctions[column.TotalsRowFunction]; ok {
+		tc.TotalsRowFunction = column.TotalsRowFunction
+		ref := &structuredRef{table: t.Name, columns: []string{tc.Name}}
+		return f.SetCellFormula(sheet, cell, fmt.Sprintf("SUBTOTAL(%d,%s)", num, ref.String()))
+	}
+	return nil
+}
+
+// fillTableCalculatedColumns provides a function to fill the formulas of the
+// calculated columns into the empty data cells of the table.
+func (f *File) fillTableCalculatedColumns(sheet string, t *xlsxTable, coordinates []int) error {
+	if t.TableColumns == nil {
+		return nil
+	}
+	dataStart, dataEnd := coordinates[1]+1, coordinates[3]-t.TotalsRowCount
+	if t.HeaderRowCount != nil && *t.HeaderRowCount == 0 {
+		dataStart = coordinates[1]
+	}
+	for i, tc := range t.TableColumns.TableColumn {
+		if tc.CalculatedColumnFormula == nil || tc.CalculatedColumnFormula.Content == "" {
+			continue
+		}
+		for row := dataStart; row <= dataEnd; row++ {
+			cell, _ := CoordinatesToCellName(coordinates[0]+i, row)
+			formula, err := f.GetCellFormula(sheet, cell)
+			if err != nil {
+				return err
+			}
+			value, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return err
+			}
+			if formula != "" || value != "" {
+				continue
+			}
+			if err = f.SetCellFormula(sheet, cell, tc.CalculatedColumnFormula.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AutoFilter provides the method to add auto filter in a worksheet by given
+// worksheet name, range reference and settings. An auto filter in Excel is a
+// way of filtering a 2D range of data based on some simple criteria. For
+// example applying an auto filter to a cell range A1:D4 in the Sheet1:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{})
+//
+// Filter data in an auto filter:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "B", Expression: "x != blanks"},
+//	})
+//
+// Column defines the filter columns in an auto filter range based on simple
+// criteria
+//
+// The filter criteria will be evaluated against the cell values, and the rows
+// that don't match the filter criteria will be hidden. The rows are evaluated
+// when setting the auto filter, so call this function again to reapply the
+// filter after the cell values have been changed. The rows in the range will
+// be not changed if no filter criteria specified. Use the GetFilteredRows
+// function to get the rows hidden by the auto filter.
+//
+// Setting a filter criteria for a column:
+//
+// Expression defines the conditions, the following operators are available
+// for setting the filter criteria:
+//
+//	==
+//	!=
+//	>
+//	<
+//	>=
+//	<=
+//	and
+//	or
+//
+// An expression can comprise a single statement or two statements separated
+// by the 'and' and 'or' operators. For example:
+//
+//	x <  2000
+//	x >  2000
+//	x == 2000
+//	x >  2000 and x <  5000
 //	x == 2000 or  x == 5000
 //
 // Filtering of blank or non-blank data can be achieved by using a value of
@@ -456,6 +1164,61 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //	x     < 2000
 //	col   < 2000
 //	Price < 2000
+//
+// Besides the expression, one of the following filter criteria can be
+// specified for a column instead:
+//
+// Values: Show the rows that the cell values equal to any of the values,
+// and the Blank specifies whether show the rows with blank cells. The
+// DateGroups specifies the dates or times to show by grouping level, for
+// example, show the rows with the values "East" or "West", and the dates in
+// March 2024:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "A", Values: []string{"East", "West"}},
+//	    {Column: "B", DateGroups: []excelize.AutoFilterDateGroup{
+//	        {Grouping: "month", Year: 2024, Month: 3},
+//	    }},
+//	})
+//
+// CellColor and FontColor: Show the rows that the fill color or font color
+// of the cells equal to the given color:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "C", CellColor: "FFFF00"},
+//	})
+//
+// Top10: Show the rows with the top or bottom N items or percent of the
+// numbers, the value must be 1-500 for the number of items and 1-100 for
+// percent:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "D", Top10: &excelize.AutoFilterTop10{Bottom: true, Value: 10, Percent: true}},
+//	})
+//
+// DynamicFilter: Show the rows by dynamic criteria, which can change with
+// the data itself or the current system date. The optional values are:
+//
+//	aboveAverage
+//	belowAverage
+//	tomorrow
+//	today
+//	yesterday
+//	nextWeek
+//	thisWeek
+//	lastWeek
+//	nextMonth
+//	thisMonth
+//	lastMonth
+//	nextQuarter
+//	thisQuarter
+//	lastQuarter
+//	nextYear
+//	thisYear
+//	lastYear
+//	yearToDate
+//	Q1 - Q4
+//	M1 - M12
 func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) error {
 	coordinates, err := rangeRefToCoordinates(rangeRef)
 	if err != nil {
@@ -510,16 +1273,12 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	if err != nil {
 		return err
 	}
-	if ws.SheetPr != nil {
-		ws.SheetPr.FilterMode = true
-	}
-	ws.SheetPr = &xlsxSheetPr{FilterMode: true}
-	filter := &xlsxAutoFilter{
+	prev, filter := ws.AutoFilter, &xlsxAutoFilter{
 		Ref: ref,
 	}
 	ws.AutoFilter = filter
 	for _, opt := range opts {
-		if opt.Column == "" || opt.Expression == "" {
+		if opt.Column == "" || opt.countCriteria() == 0 {
 			continue
 		}
 		fsCol, err := ColumnNameToNumber(opt.Column)
@@ -531,6 +1290,16 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return newInvalidAutoFilterColumnError(opt.Column)
 		}
 		fc := &xlsxFilterColumn{ColID: offset}
+		if opt.countCriteria() > 1 {
+			return ErrParameterInvalid
+		}
+		if opt.Expression == "" {
+			if err = f.writeFilterCriteria(fc, &opt); err != nil {
+				return err
+			}
+			filter.FilterColumn = append(filter.FilterColumn, fc)
+			continue
+		}
 		token := expressionFormat.FindAllString(opt.Expression, -1)
 		if len(token) != 3 && len(token) != 7 {
 			return newInvalidAutoFilterExpError(opt.Expression)
@@ -543,29 +1312,213 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 		filter.FilterColumn = append(filter.FilterColumn, fc)
 	}
 	ws.AutoFilter = filter
+	return f.applyFilter(sheet, ws, prev, filter)
+}
+
+// countCriteria returns the number of the kinds of filter criteria in the
+// auto filter options.
+func (opt *AutoFilterOptions) countCriteria() int {
+	var count int
+	for _, specified := range []bool{
+		opt.Expression != "",
+		len(opt.Values) > 0 || opt.Blank || len(opt.DateGroups) > 0,
+		opt.CellColor != "",
+		opt.FontColor != "",
+		opt.Top10 != nil,
+		opt.DynamicFilter != "",
+	} {
+		if specified {
+			count++
+		}
+	}
+	return count
+}
+
+// writeFilterCriteria provides a function to write the filter values, date
+// group items, color filter, top 10 filter or dynamic filter of the filter
+// column by given auto filter options.
+func (f *File) writeFilterCriteria(fc *xlsxFilterColumn, opt *AutoFilterOptions) error {
+	switch {
+	case opt.CellColor != "" || opt.FontColor != "":
+		style := &Style{Fill: Fill{Type: "pattern", Color: []string{opt.CellColor}, Pattern: 1}}
+		if opt.FontColor != "" {
+			style = &Style{Font: &Font{Color: opt.FontColor}}
+		}
+		dxfID, err := f.getDxfID(style)
+		if err != nil {
+			return err
+		}
+		fc.ColorFilter = &xlsxColorFilter{CellColor: opt.CellColor != "", DxfID: dxfID}
+	case opt.Top10 != nil:
+		if opt.Top10.Value < 1 || !opt.Top10.Percent && opt.Top10.Value > 500 ||
+			opt.Top10.Percent && opt.Top10.Value > 100 {
+			return ErrParameterInvalid
+		}
+		fc.Top10 = &xlsxTop10{Percent: opt.Top10.Percent, Val: opt.Top10.Value}
+		if opt.Top10.Bottom {
+			fc.Top10.Top = boolPtr(false)
+		}
+	case opt.DynamicFilter != "":
+		if !inDynamicFilterTypes(opt.DynamicFilter) {
+			return ErrParameterInvalid
+		}
+		fc.DynamicFilter = &xlsxDynamicFilter{Type: opt.DynamicFilter}
+	default:
+		fc.Filters = &xlsxFilters{Blank: opt.Blank}
+		for _, val := range opt.Values {
+			fc.Filters.Filter = append(fc.Filters.Filter, &xlsxFilter{Val: val})
+		}
+		for _, group := range opt.DateGroups {
+			if inStrSlice([]string{"year", "month", "day", "hour", "minute", "second"}, group.Grouping, true) == -1 {
+				return ErrParameterInvalid
+			}
+			fc.Filters.DateGroupItem = append(fc.Filters.DateGroupItem, &xlsxDateGroupItem{
+				DateTimeGrouping: group.Grouping,
+				Year:             group.Year,
+				Month:            group.Month,
+				Day:              group.Day,
+				Hour:             group.Hour,
+				Minute:           group.Minute,
+				Second:           group.Second,
+			})
+		}
+	}
 	return nil
 }
 
-// writeAutoFilter provides a function to check for single or double custom
-// filters as default filters and handle them accordingly.
-func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
-	if len(exp) == 1 && exp[0] == 2 {
-		// Single equality.
-		var filters []*xlsxFilter
-		filters = append(filters, &xlsxFilter{Val: tokens[0]})
-		fc.Filters = &xlsxFilters{Filter: filters}
-		return
+// inDynamicFilterTypes returns true if the given type is a supported dynamic
+// filter type.
+func inDynamicFilterTypes(typ string) bool {
+	if inStrSlice([]string{
+		"aboveAverage", "belowAverage", "tomorrow", "today", "yesterday",
+		"nextWeek", "thisWeek", "lastWeek", "nextMonth", "thisMonth",
+		"lastMonth", "nextQuarter", "thisQuarter", "lastQuarter", "nextYear",
+		"thisYear", "lastYear", "yearToDate", "Q1", "Q2", "Q3", "Q4",
+	}, typ, true) != -1 {
+		return true
+	}
+	m, err := strconv.Atoi(strings.TrimPrefix(typ, "M"))
+	return err == nil && strings.HasPrefix(typ, "M") && m >= 1 && m <= 12
+}
+
+// GetAutoFilter provides the method to get the auto filter range reference
+// and settings in a worksheet by given worksheet name. For example, get the
+// auto filter on Sheet1:
+//
+//	ref, opts, err := f.GetAutoFilter("Sheet1")
+//
+// The filter criteria of each column will be returned with the same fields as
+// the AutoFilter function, the custom filters will be returned as expression,
+// and the range reference will be empty if no auto filter in the worksheet.
+func (f *File) GetAutoFilter(sheet string) (string, []AutoFilterOptions, error) {
+	var opts []AutoFilterOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return "", opts, err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return ws.AutoFilter.Ref, opts, err
+	}
+	_ = sortCoordinates(coordinates)
+	for _, fc := range ws.AutoFilter.FilterColumn {
+		if fc == nil {
+			continue
+		}
+		opt := AutoFilterOptions{}
+		if opt.Column, err = ColumnNumberToName(coordinates[0] + fc.ColID); err != nil {
+			return ws.AutoFilter.Ref, opts, err
+		}
+		if err = f.readFilterCriteria(fc, &opt); err != nil {
+			return ws.AutoFilter.Ref, opts, err
+		}
+		opts = append(opts, opt)
+	}
+	return ws.AutoFilter.Ref, opts, err
+}
+
+// readFilterCriteria provides a function to read the filter criteria of the
+// filter column into the auto filter options.
+func (f *File) readFilterCriteria(fc *xlsxFilterColumn, opt *AutoFilterOptions) error {
+	if fc.Filters != nil {
+		opt.Blank = fc.Filters.Blank
+		for _, item := range fc.Filters.Filter {
+			if item != nil {
+				opt.Values = append(opt.Values, item.Val)
+			}
+		}
+		for _, item := range fc.Filters.DateGroupItem {
+			if item != nil {
+				opt.DateGroups = append(opt.DateGroups, AutoFilterDateGroup{
+					Grouping: item.DateTimeGrouping,
+					Year:     item.Year,
+					Month:    item.Month,
+					Day:      item.Day,
+					Hour:     item.Hour,
+					Minute:   item.Minute,
+					Second:   item.Second,
+				})
+			}
+		}
+	}
+	if fc.CustomFilters != nil {
+		operators := map[string]string{
+			"": "==", "equal": "==", "notEqual": "!=", "lessThan": "<",
+			"lessThanOrEqual": "<=", "greaterThan": ">", "greaterThanOrEqual": ">=",
+		}
+		var exp []string
+		for _, cf := range fc.CustomFilters.CustomFilter {
+			if cf == nil {
+				continue
+			}
+			val := cf.Val
+			if strings.TrimSpace(val) == "" {
+				val = "blanks"
+			}
+			exp = append(exp, fmt.Sprintf("x %s %s", operators[cf.Operator], val))
+		}
+		conditional := " or "
+		if fc.CustomFilters.And {
+			conditional = " and "
+		}
+		opt.Expression = strings.Join(exp, conditional)
 	}
-	if len(exp) == 3 && exp[0] == 2 && exp[1] == 1 && exp[2] == 2 {
-		// Double equality with "or" operator.
-		var filters []*xlsxFilter
-		for _, v := range tokens {
-			filters = append(filters, &xlsxFilter{Val: v})
+	if fc.ColorFilter != nil {
+		style, err := f.GetConditionalStyle(fc.ColorFilter.DxfID)
+		if err != nil {
+			return err
+		}
+		if fc.ColorFilter.CellColor && len(style.Fill.Color) > 0 {
+			opt.CellColor = style.Fill.Color[0]
+		}
+		if !fc.ColorFilter.CellColor && style.Font != nil {
+			opt.FontColor = style.Font.Color
 		}
-		fc.Filters = &xlsxFilters{Filter: filters}
+	}
+	if fc.Top10 != nil {
+		opt.Top10 = &AutoFilterTop10{
+			Bottom:  fc.Top10.Top != nil && !*fc.Top10.Top,
+			Percent: fc.Top10.Percent,
+			Value:   fc.Top10.Val,
+		}
+	}
+	if fc.DynamicFilter != nil {
+		opt.DynamicFilter = fc.DynamicFilter.Type
+	}
+	return nil
+}
+
+// writeAutoFilter provides a function to write the single or double custom
+// filters, and the single equality with blanks will be written as the default
+// filters.
+func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
+	if len(exp) == 1 && exp[0] == 2 && tokens[0] == "blanks" {
+		// Single equality with blanks.
+		fc.Filters = &xlsxFilters{Blank: true}
 		return
 	}
-	// Non default custom filter.
+	// Custom filter, the equalities are kept as the custom filter instead of
+	// the filter values to keep the criteria form of the expression.
 	expRel, andRel := map[int]int{0: 0, 1: 2}, map[int]bool{0: true, 1: false}
 	for k, v := range tokens {
 		f.writeCustomFilter(fc, exp[expRel[k]], v)
@@ -688,3 +1641,1015 @@ func (f *File) parseFilterTokens(expression string, tokens []string) ([]int, str
 	}
 	return []int{operator}, token, nil
 }
+
+// filterValue directly maps the value of a cell in the auto filter column.
+type filterValue struct {
+	raw, text string
+	num       float64
+	isNum     bool
+	style     int
+}
+
+// GetFilteredRows provides a function to get the row numbers hidden by the
+// auto filter of the worksheet and the auto filters of the tables in a
+// worksheet by given worksheet name. For example, get the rows which have
+// been filtered out on Sheet1:
+//
+//	rows, err := f.GetFilteredRows("Sheet1")
+func (f *File) GetFilteredRows(sheet string) ([]int, error) {
+	var rows []int
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return rows, err
+	}
+	filters, err := f.getSheetFilters(sheet, ws)
+	if err != nil {
+		return rows, err
+	}
+	filtered := make(map[int]bool)
+	for _, filter := range filters {
+		coordinates, err := rangeRefToCoordinates(filter.Ref)
+		if err != nil {
+			return rows, err
+		}
+		_ = sortCoordinates(coordinates)
+		for r := coordinates[1] + 1; r <= coordinates[3] && r <= len(ws.SheetData.Row); r++ {
+			if ws.SheetData.Row[r-1].Hidden && !filtered[r] {
+				filtered[r] = true
+				rows = append(rows, r)
+			}
+		}
+	}
+	sort.Ints(rows)
+	return rows, err
+}
+
+// getSheetFilters provides a function to get the auto filters which have
+// filter criteria in the worksheet, including the auto filter of the
+// worksheet and the auto filters of the tables in the worksheet.
+func (f *File) getSheetFilters(sheet string, ws *xlsxWorksheet) ([]*xlsxAutoFilter, error) {
+	var filters []*xlsxAutoFilter
+	if ws.AutoFilter != nil && len(ws.AutoFilter.FilterColumn) > 0 {
+		filters = append(filters, ws.AutoFilter)
+	}
+	if ws.TableParts == nil {
+		return filters, nil
+	}
+	for _, tbl := range ws.TableParts.TableParts {
+		if tbl == nil {
+			continue
+		}
+		target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
+		content, ok := f.Pkg.Load(strings.ReplaceAll(target, "..", "xl"))
+		if !ok {
+			continue
+		}
+		var t xlsxTable
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&t); err != nil && err != io.EOF {
+			return filters, err
+		}
+		if t.AutoFilter != nil && len(t.AutoFilter.FilterColumn) > 0 {
+			filters = append(filters, t.AutoFilter)
+		}
+	}
+	return filters, nil
+}
+
+// applyFilter evaluates the filter criteria of the given auto filter of the
+// worksheet or table, and hides the rows in the filter range that don't match
+// the criteria. The rows hidden by the previous filter criteria will be shown
+// if they match the new criteria, and the hidden state of other rows will be
+// kept. The filter mode of the worksheet will be set if any auto filter in
+// the worksheet has filter criteria.
+func (f *File) applyFilter(sheet string, ws *xlsxWorksheet, prev, filter *xlsxAutoFilter) error {
+	prevHidden, err := f.getFilterHiddenRows(ws, prev)
+	if err != nil {
+		return err
+	}
+	hidden, err := f.getFilterHiddenRows(ws, filter)
+	if err != nil {
+		return err
+	}
+	for row := range prevHidden {
+		if !hidden[row] && row <= len(ws.SheetData.Row) {
+			ws.SheetData.Row[row-1].Hidden = false
+		}
+	}
+	for row := range hidden {
+		ws.prepareSheetXML(0, row)
+		ws.SheetData.Row[row-1].Hidden = true
+	}
+	filters, err := f.getSheetFilters(sheet, ws)
+	if err != nil {
+		return err
+	}
+	if len(filters) > 0 {
+		if ws.SheetPr == nil {
+			ws.SheetPr = &xlsxSheetPr{}
+		}
+		ws.SheetPr.FilterMode = true
+	} else if ws.SheetPr != nil {
+		ws.SheetPr.FilterMode = false
+	}
+	return err
+}
+
+// getFilterHiddenRows evaluates the filter criteria of the given auto filter
+// of the worksheet or table, and returns the row numbers in the filter range
+// that don't match the criteria.
+func (f *File) getFilterHiddenRows(ws *xlsxWorksheet, filter *xlsxAutoFilter) (map[int]bool, error) {
+	hidden := make(map[int]bool)
+	if filter == nil || len(filter.FilterColumn) == 0 {
+		return hidden, nil
+	}
+	coordinates, err := rangeRefToCoordinates(filter.Ref)
+	if err != nil {
+		return hidden, err
+	}
+	_ = sortCoordinates(coordinates)
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return hidden, err
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		return hidden, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	for _, fc := range filter.FilterColumn {
+		if fc == nil || coordinates[0]+fc.ColID > coordinates[2] {
+			continue
+		}
+		col := coordinates[0] + fc.ColID
+		values := make([]filterValue, coordinates[3]-coordinates[1])
+		for i := range values {
+			if values[i], err = f.getFilterValue(ws, sst, col, coordinates[1]+i+1); err != nil {
+				return hidden, err
+			}
+		}
+		match := f.newFilterMatcher(fc, values, date1904)
+		for i, value := range values {
+			if !match(value) {
+				hidden[coordinates[1]+i+1] = true
+			}
+		}
+	}
+	return hidden, err
+}
+
+// getFilterValue returns the raw value, formatted value and number of the cell
+// by given coordinates.
+func (f *File) getFilterValue(ws *xlsxWorksheet, sst *xlsxSST, col, row int) (filterValue, error) {
+	var value filterValue
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return value, nil
+	}
+	c := ws.SheetData.Row[row-1].C[col-1]
+	raw, err := c.getValueFrom(f, sst, true)
+	if value.style = c.S; err != nil {
+		return value, err
+	}
+	if value.raw = raw; raw == "" {
+		return value, err
+	}
+	if value.text, err = c.getValueFrom(f, sst, false); err != nil {
+		return value, err
+	}
+	if c.T == "" || c.T == "n" {
+		if num, err := strconv.ParseFloat(raw, 64); err == nil {
+			value.num, value.isNum = num, true
+		}
+	}
+	return value, err
+}
+
+// newFilterMatcher returns a function to check if the cell value matches all
+// criteria of the filter column. The icon filters are not supported to
+// evaluate, and the cells are always treated as matched.
+func (f *File) newFilterMatcher(fc *xlsxFilterColumn, values []filterValue, date1904 bool) func(filterValue) bool {
+	var matchers []func(filterValue) bool
+	if fc.ColorFilter != nil {
+		matchers = append(matchers, f.newColorFilterMatcher(fc.ColorFilter))
+	}
+	if fc.Filters != nil {
+		matchers = append(matchers, func(v filterValue) bool {
+			return v.matchFilters(fc.Filters, date1904)
+		})
+	}
+	if fc.CustomFilters != nil {
+		var customMatchers []func(filterValue) bool
+		for _, cf := range fc.CustomFilters.CustomFilter {
+			customMatchers = append(customMatchers, newCustomFilterMatcher(cf))
+		}
+		matchers = append(matchers, func(v filterValue) bool {
+			for _, match := range customMatchers {
+				if matched := match(v); matched != fc.CustomFilters.And {
+					return matched
+				}
+			}
+			return fc.CustomFilters.And
+		})
+	}
+	if fc.Top10 != nil {
+		matchers = append(matchers, newTop10Matcher(fc.Top10, values))
+	}
+	if fc.DynamicFilter != nil {
+		matchers = append(matchers, newDynamicFilterMatcher(fc.DynamicFilter, values, date1904))
+	}
+	return func(v filterValue) bool {
+		for _, match := range matchers {
+			if !match(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// newColorFilterMatcher returns a function to check if the fill or font color
+// of the cell matches the color of the color filter.
+func (f *File) newColorFilterMatcher(cf *xlsxColorFilter) func(filterValue) bool {
+	var color string
+	sortBy, colors := SortByFontColor, map[int][]string{}
+	if style, err := f.GetConditionalStyle(cf.DxfID); err == nil {
+		if cf.CellColor && len(style.Fill.Color) > 0 {
+			color = style.Fill.Color[0]
+		}
+		if !cf.CellColor && style.Font != nil {
+			color = style.Font.Color
+		}
+	}
+	if cf.CellColor {
+		sortBy = SortByCellColor
+	}
+	return func(v filterValue) bool {
+		return f.getSortColor(v.style, sortBy, colors) == normalizeSortColor(color)
+	}
+}
+
+// matchFilters returns true if the cell value matches any of the filter
+// values or date group items.
+func (v filterValue) matchFilters(filters *xlsxFilters, date1904 bool) bool {
+	if v.raw == "" {
+		return filters.Blank
+	}
+	for _, item := range filters.Filter {
+		if item != nil && (strings.EqualFold(item.Val, v.text) || strings.EqualFold(item.Val, v.raw)) {
+			return true
+		}
+	}
+	if !v.isNum {
+		return false
+	}
+	t := timeFromExcelTime(v.num, date1904)
+	for _, item := range filters.DateGroupItem {
+		if item != nil && matchDateGroupItem(item, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDateGroupItem returns true if the time matches the date group item.
+func matchDateGroupItem(item *xlsxDateGroupItem, t time.Time) bool {
+	fields := []struct {
+		grouping    string
+		expect, val int
+	}{
+		{"year", item.Year, t.Year()},
+		{"month", item.Month, int(t.Month())},
+		{"day", item.Day, t.Day()},
+		{"hour", item.Hour, t.Hour()},
+		{"minute", item.Minute, t.Minute()},
+		{"second", item.Second, t.Second()},
+	}
+	for _, field := range fields {
+		if field.expect != field.val {
+			return false
+		}
+		if field.grouping == item.DateTimeGrouping {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardToRegexp converts the Excel wildcard pattern to the case-insensitive
+// regular expression, the '~' character escapes the wildcard characters.
+func wildcardToRegexp(pattern string) *regexp.Regexp {
+	var (
+		expr    strings.Builder
+		escaped bool
+	)
+	expr.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '~':
+			escaped = true
+		case r == '*':
+			expr.WriteString(".*")
+		case r == '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// newCustomFilterMatcher returns a function to check if the cell value
+// matches the custom filter criteria, the wildcard pattern of the criteria
+// will be compiled once for the filter.
+func newCustomFilterMatcher(cf *xlsxCustomFilter) func(filterValue) bool {
+	if cf == nil {
+		return func(filterValue) bool { return true }
+	}
+	op := cf.Operator
+	if op == "" {
+		op = "equal"
+	}
+	if strings.TrimSpace(cf.Val) == "" {
+		return func(v filterValue) bool {
+			switch op {
+			case "equal":
+				return v.raw == ""
+			case "notEqual":
+				return v.raw != ""
+			}
+			return false
+		}
+	}
+	compare := func(r int) bool {
+		switch op {
+		case "lessThan":
+			return r < 0
+		case "lessThanOrEqual":
+			return r <= 0
+		case "greaterThan":
+			return r > 0
+		case "greaterThanOrEqual":
+			return r >= 0
+		case "notEqual":
+			return r != 0
+		}
+		return r == 0
+	}
+	num, err := strconv.ParseFloat(cf.Val, 64)
+	isNum := err == nil
+	var re *regexp.Regexp
+	if op == "equal" || op == "notEqual" {
+		re = wildcardToRegexp(cf.Val)
+	}
+	return func(v filterValue) bool {
+		if v.raw == "" {
+			return op == "notEqual"
+		}
+		if isNum {
+			if v.isNum {
+				if v.num < num {
+					return compare(-1)
+				}
+				if v.num > num {
+					return compare(1)
+				}
+				return compare(0)
+			}
+			if re == nil {
+				return false
+			}
+		}
+		if re != nil {
+			return (re.MatchString(v.text) || re.MatchString(v.raw)) == (op == "equal")
+		}
+		if v.isNum {
+			return false
+		}
+		return compare(strings.Compare(strings.ToLower(v.text), strings.ToLower(cf.Val)))
+	}
+}
+
+// newTop10Matcher returns a function to check if the cell value is in the top
+// or bottom N items or percent of the numbers in the column.
+func newTop10Matcher(top10 *xlsxTop10, values []filterValue) func(filterValue) bool {
+	var nums []float64
+	for _, v := range values {
+		if v.isNum {
+			nums = append(nums, v.num)
+		}
+	}
+	top := top10.Top == nil || *top10.Top
+	n := int(top10.Val)
+	if top10.Percent {
+		n = int(math.Ceil(float64(len(nums)) * top10.Val / 100))
+	}
+	if n > len(nums) {
+		n = len(nums)
+	}
+	if n <= 0 {
+		return func(filterValue) bool { return false }
+	}
+	sort.Float64s(nums)
+	if top {
+		threshold := nums[len(nums)-n]
+		return func(v filterValue) bool { return v.isNum && v.num >= threshold }
+	}
+	threshold := nums[n-1]
+	return func(v filterValue) bool { return v.isNum && v.num <= threshold }
+}
+
+// newDynamicFilterMatcher returns a function to check if the cell value
+// matches the dynamic filter criteria, the date criteria are relative to the
+// current system date.
+func newDynamicFilterMatcher(df *xlsxDynamicFilter, values []filterValue, date1904 bool) func(filterValue) bool {
+	if df.Type == "aboveAverage" || df.Type == "belowAverage" {
+		var sum, count float64
+		for _, v := range values {
+			if v.isNum {
+				sum, count = sum+v.num, count+1
+			}
+		}
+		avg := sum / math.Max(count, 1)
+		return func(v filterValue) bool {
+			return v.isNum && (df.Type == "aboveAverage" && v.num > avg || df.Type == "belowAverage" && v.num < avg)
+		}
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	week := today.AddDate(0, 0, -int(today.Weekday()))
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	quarter := time.Date(today.Year(), (today.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	year := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	ranges := map[string][2]time.Time{
+		"tomorrow":    {today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)},
+		"today":       {today, today.AddDate(0, 0, 1)},
+		"yesterday":   {today.AddDate(0, 0, -1), today},
+		"nextWeek":    {week.AddDate(0, 0, 7), week.AddDate(0, 0, 14)},
+		"thisWeek":    {week, week.AddDate(0, 0, 7)},
+		"lastWeek":    {week.AddDate(0, 0, -7), week},
+		"nextMonth":   {month.AddDate(0, 1, 0), month.AddDate(0, 2, 0)},
+		"thisMonth":   {month, month.AddDate(0, 1, 0)},
+		"lastMonth":   {month.AddDate(0, -1, 0), month},
+		"nextQuarter": {quarter.AddDate(0, 3, 0), quarter.AddDate(0, 6, 0)},
+		"thisQuarter": {quarter, quarter.AddDate(0, 3, 0)},
+		"lastQuarter": {quarter.AddDate(0, -3, 0), quarter},
+		"nextYear":    {year.AddDate(1, 0, 0), year.AddDate(2, 0, 0)},
+		"thisYear":    {year, year.AddDate(1, 0, 0)},
+		"lastYear":    {year.AddDate(-1, 0, 0), year},
+		"yearToDate":  {year, today.AddDate(0, 0, 1)},
+	}
+	if r, ok := ranges[df.Type]; ok {
+		return func(v filterValue) bool {
+			if !v.isNum {
+				return false
+			}
+			t := timeFromExcelTime(v.num, date1904)
+			return !t.Before(r[0]) && t.Before(r[1])
+		}
+	}
+	var months []time.Month
+	if len(df.Type) == 2 && df.Type[0] == 'Q' && df.Type[1] >= '1' && df.Type[1] <= '4' {
+		q := time.Month(df.Type[1]-'1') * 3
+		months = []time.Month{q + 1, q + 2, q + 3}
+	}
+	if m, err := strconv.Atoi(strings.TrimPrefix(df.Type, "M")); err == nil && df.Type[0] == 'M' && m >= 1 && m <= 12 {
+		months = []time.Month{time.Month(m)}
+	}
+	if months == nil {
+		return func(filterValue) bool { return true }
+	}
+	return func(v filterValue) bool {
+		if !v.isNum {
+			return false
+		}
+		t := timeFromExcelTime(v.num, date1904)
+		for _, m := range months {
+			if t.Month() == m {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// sortValue directly maps the value of a cell in the sort key column.
+type sortValue struct {
+	kind  int
+	num   float64
+	text  string
+	color bool
+}
+
+// This section defines the kind of the sort values in ascending order, the
+// blank cells always be placed at the end.
+const (
+	sortValueNumber = iota
+	sortValueText
+	sortValueBool
+	sortValueError
+	sortValueBlank
+)
+
+// sortRow directly maps the cells, height and sort values of a row in the
+// sort range.
+type sortRow struct {
+	row          int
+	ht           *float64
+	customHeight bool
+	cells        []xlsxC
+	values       []sortValue
+}
+
+// SortRange provides the method to sort a range of cells by given worksheet
+// name, range reference, sort keys and optional settings. The rows in the
+// range will be physically reordered, and the cell styles, row heights,
+// hyperlinks and comments are moved with the rows. The sort state will be
+// recorded in the table if the range is the same as a table range, otherwise
+// in the worksheet. For example, sort the range A1:D10 on Sheet1 by column B
+// in descending order, and then by column C with a custom list:
+//
+//	err := f.SortRange("Sheet1", "A1:D10", []excelize.SortKey{
+//	    {Column: "B", Descending: true},
+//	    {Column: "C", CustomList: []string{"Low", "Medium", "High"}},
+//	})
+//
+// Sort the rows which the cell in column A filled with red color on top:
+//
+//	err := f.SortRange("Sheet1", "A1:D10", []excelize.SortKey{
+//	    {Column: "A", SortBy: excelize.SortByCellColor, Color: "FF0000"},
+//	})
+//
+// Column: The column name of the sort key, which must be in the range.
+//
+// Descending: Sort in descending order, the blank cells are always placed at
+// the end. For the sort by color keys, place the cells with the specified
+// color on bottom.
+//
+// SortBy: Specifies sort by the cell values (SortByValue), the fill color of
+// the cells (SortByCellColor) or the font color of the cells
+// (SortByFontColor).
+//
+// Color: The hex color code of the cell fill or font to sort by.
+//
+// CustomList: Specifies the values in the custom order, the values which not
+// in the list will be placed after them.
+//
+// The optional settings CaseSensitive specifies if the text compare is case
+// sensitive, the lowercase letters before uppercase letters in ascending
+// order. The Header specifies if the first row of the range is a header row
+// which will be not sorted, the header row will be detected automatically if
+// it is not specified: when the first row contains text in all of the sort key
+// columns, while the second row not or formatted differently. The header row
+// and totals row of a table are never sorted. Nothing will be changed if the
+// range contains only one row.
+func (f *File) SortRange(sheet, rangeRef string, keys []SortKey, opts ...SortOptions) error {
+	if len(keys) == 0 {
+		return ErrParameterRequired
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	var options SortOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	columns := make([]int, len(keys))
+	for i, key := range keys {
+		if key.SortBy > SortByFontColor {
+			return ErrParameterInvalid
+		}
+		if key.SortBy != SortByValue && key.Color == "" {
+			return ErrParameterRequired
+		}
+		if columns[i], err = ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if columns[i] < coordinates[0] || columns[i] > coordinates[2] {
+			return newInvalidSortKeyColumnError(key.Column)
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if coordinates[1] == coordinates[3] {
+		return err
+	}
+	tbl, tableXML, err := f.getSortTable(sheet, coordinates)
+	if err != nil {
+		return err
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		ws.prepareSheetXML(coordinates[2], row)
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	data := append([]int{}, coordinates...)
+	if tbl != nil {
+		if tbl.HeaderRowCount == nil || *tbl.HeaderRowCount > 0 {
+			data[1]++
+		}
+		data[3] -= tbl.TotalsRowCount
+	} else if options.Header != nil && *options.Header ||
+		options.Header == nil && f.detectSortHeader(ws, sst, coordinates, columns) {
+		data[1]++
+	}
+	if data[1] >= data[3] {
+		return err
+	}
+	if err = ws.checkSortRange(data); err != nil {
+		return err
+	}
+	rows, err := f.getSortRows(ws, sst, data, keys, columns)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			if r := compareSortValues(rows[i].values[k], rows[j].values[k], key, options.CaseSensitive); r != 0 {
+				return r < 0
+			}
+		}
+		return false
+	})
+	rowMap, err := f.setSortRows(sheet, ws, data, rows)
+	if err != nil {
+		return err
+	}
+	if err = f.moveSortRowObjects(sheet, ws, data, rowMap); err != nil {
+		return err
+	}
+	return f.setSortState(ws, tbl, tableXML, data, keys, columns, options)
+}
+
+// getSortTable returns the table and its part path which has the same range as
+// the given coordinates in the worksheet.
+func (f *File) getSortTable(sheet string, coordinates []int) (*xlsxTable, string, error) {
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, table := range tables {
+		tableCoordinates, err := rangeRefToCoordinates(table.Range)
+		if err != nil {
+			return nil, "", err
+		}
+		_ = sortCoordinates(tableCoordinates)
+		if !reflect.DeepEqual(tableCoordinates, coordinates) {
+			continue
+		}
+		content, _ := f.Pkg.Load(table.tableXML)
+		var t xlsxTable
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&t); err != nil && err != io.EOF {
+			return nil, "", err
+		}
+		return &t, table.tableXML, nil
+	}
+	return nil, "", err
+}
+
+// getSortValue returns the sort value of the cell by given coordinates.
+func (f *File) getSortValue(ws *xlsxWorksheet, sst *xlsxSST, col, row int) (sortValue, error) {
+	c := &ws.SheetData.Row[row-1].C[col-1]
+	val, err := c.getValueFrom(f, sst, true)
+	if err != nil || val == "" {
+		return sortValue{kind: sortValueBlank}, err
+	}
+	value := sortValue{kind: sortValueText, text: val}
+	switch c.T {
+	case "b":
+		value.kind = sortValueBool
+	case "e":
+		value.kind = sortValueError
+	case "", "n":
+		if num, err := strconv.ParseFloat(val, 64); err == nil {
+			value.kind, value.num = sortValueNumber, num
+		}
+	}
+	return value, err
+}
+
+// detectSortHeader returns true if the first row of the range contains text in
+// all of the sort key columns, while the second row not or formatted
+// differently.
+func (f *File) detectSortHeader(ws *xlsxWorksheet, sst *xlsxSST, coordinates, columns []int) bool {
+	var differ bool
+	for _, col := range columns {
+		first, err := f.getSortValue(ws, sst, col, coordinates[1])
+		if err != nil || first.kind != sortValueText {
+			return false
+		}
+		second, err := f.getSortValue(ws, sst, col, coordinates[1]+1)
+		if err != nil {
+			return false
+		}
+		differ = differ || second.kind != sortValueText ||
+			ws.SheetData.Row[coordinates[1]-1].C[col-1].S != ws.SheetData.Row[coordinates[1]].C[col-1].S
+	}
+	return differ
+}
+
+// checkSortRange checks if any merged cells or array formulas across multiple
+// rows or partially overlap the range to be sorted.
+func (ws *xlsxWorksheet) checkSortRange(rect []int) error {
+	if err := ws.checkPartialRange(rect, nil); err != nil {
+		return err
+	}
+	multiRows := func(ref string) bool {
+		rects, err := sqrefToRects(ref)
+		return err == nil && len(rects) > 0 && inRect(rects[0], rect) && rects[0][1] != rects[0][3]
+	}
+	if ws.MergeCells != nil {
+		for _, mc := range ws.MergeCells.Cells {
+			if mc != nil && multiRows(mc.Ref) {
+				return ErrSortRange
+			}
+		}
+	}
+	for r := rect[1]; r <= rect[3]; r++ {
+		for c := rect[0]; c <= rect[2]; c++ {
+			if cell := ws.SheetData.Row[r-1].C[c-1]; cell.F != nil && cell.F.T == STCellFormulaTypeArray && multiRows(cell.F.Ref) {
+				return ErrSortRange
+			}
+		}
+	}
+	return nil
+}
+
+// getSortColor returns the normalized fill or font color of the cell style.
+func (f *File) getSortColor(styleID int, sortBy SortBy, cache map[int][]string) string {
+	colors, ok := cache[styleID]
+	if !ok {
+		colors = make([]string, 2)
+		if style, err := f.GetStyle(styleID); err == nil {
+			if style.Fill.Type == "pattern" && style.Fill.Pattern > 0 && len(style.Fill.Color) > 0 {
+				colors[0] = normalizeSortColor(style.Fill.Color[0])
+			}
+			if style.Font != nil {
+				colors[1] = normalizeSortColor(style.Font.Color)
+			}
+		}
+		cache[styleID] = colors
+	}
+	if sortBy == SortByCellColor {
+		return colors[0]
+	}
+	return colors[1]
+}
+
+// normalizeSortColor returns the uppercase RGB hex color code without the
+// prefix number sign and alpha channel.
+func normalizeSortColor(color string) string {
+	color = strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if len(color) == 8 {
+		color = color[2:]
+	}
+	return color
+}
+
+// getSortRows returns the cells, row heights and sort values of the rows in
+// the range to be sorted.
+func (f *File) getSortRows(ws *xlsxWorksheet, sst *xlsxSST, rect []int, keys []SortKey, columns []int) ([]sortRow, error) {
+	var (
+		rows   []sortRow
+		colors = map[int][]string{}
+	)
+	ws.unshareFormulas(rect)
+	for r := rect[1]; r <= rect[3]; r++ {
+		row := &ws.SheetData.Row[r-1]
+		sr := sortRow{row: r, ht: row.Ht, customHeight: row.CustomHeight}
+		sr.cells = append(sr.cells, row.C[rect[0]-1:rect[2]]...)
+		for i, key := range keys {
+			value, err := f.getSortValue(ws, sst, columns[i], r)
+			if err != nil {
+				return rows, err
+			}
+			if key.SortBy != SortByValue {
+				value.color = f.getSortColor(row.C[columns[i]-1].S, key.SortBy, colors) == normalizeSortColor(key.Color)
+			}
+			sr.values = append(sr.values, value)
+		}
+		rows = append(rows, sr)
+	}
+	return rows, nil
+}
+
+// compareText compares two strings, returns an integer less than, equal to,
+// or greater than zero. The lowercase letters will be placed before uppercase
+// letters if the compare is case sensitive.
+func compareText(a, b string, caseSensitive bool) int {
+	if r := strings.Compare(strings.ToLower(a), strings.ToLower(b)); r != 0 || !caseSensitive {
+		return r
+	}
+	ra, rb := []rune(a), []rune(b)
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		if ra[i] != rb[i] {
+			if unicode.IsLower(ra[i]) {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// compareSortValues compares two sort values by given sort key, returns an
+// integer less than, equal to, or greater than zero.
+func compareSortValues(a, b sortValue, key SortKey, caseSensitive bool) int {
+	order := func(r int) int {
+		if key.Descending {
+			return -r
+		}
+		return r
+	}
+	if key.SortBy != SortByValue {
+		if a.color == b.color {
+			return 0
+		}
+		if a.color {
+			return order(-1)
+		}
+		return order(1)
+	}
+	if a.kind == sortValueBlank || b.kind == sortValueBlank {
+		return compareInt(boolToInt(a.kind == sortValueBlank), boolToInt(b.kind == sortValueBlank))
+	}
+	if len(key.CustomList) > 0 {
+		indexOf := func(v sortValue) int {
+			for i, item := range key.CustomList {
+				if strings.EqualFold(item, v.text) {
+					return i
+				}
+			}
+			return -1
+		}
+		ia, ib := indexOf(a), indexOf(b)
+		if ia != -1 && ib != -1 {
+			return order(compareInt(ia, ib))
+		}
+		if ia != ib {
+			return compareInt(boolToInt(ia == -1), boolToInt(ib == -1))
+		}
+	}
+	if a.kind != b.kind {
+		return order(compareInt(a.kind, b.kind))
+	}
+	switch a.kind {
+	case sortValueNumber:
+		if a.num < b.num {
+			return order(-1)
+		}
+		if a.num > b.num {
+			return order(1)
+		}
+		return 0
+	case sortValueText, sortValueBool:
+		return order(compareText(a.text, b.text, caseSensitive))
+	}
+	return 0
+}
+
+// compareInt compares two integers, returns an integer less than, equal to,
+// or greater than zero.
+func compareInt(a, b int) int {
+	return a - b
+}
+
+// boolToInt returns 1 if the given boolean value is true, otherwise 0.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// setSortRows writes the sorted rows into the worksheet, and returns the map
+// of the original row numbers to the new row numbers.
+func (f *File) setSortRows(sheet string, ws *xlsxWorksheet, rect []int, rows []sortRow) (map[int]int, error) {
+	rowMap, sheetID := map[int]int{}, f.getSheetID(sheet)
+	for i, sr := range rows {
+		row := rect[1] + i
+		rowMap[sr.row] = row
+		ws.SheetData.Row[row-1].Ht, ws.SheetData.Row[row-1].CustomHeight = sr.ht, sr.customHeight
+		for j, cell := range sr.cells {
+			if cell.F != nil {
+				if err := f.deleteCalcChain(sheetID, cell.R); err != nil {
+					return rowMap, err
+				}
+			}
+			var err error
+			if cell.R, err = CoordinatesToCellName(rect[0]+j, row); err != nil {
+				return rowMap, err
+			}
+			if cell.F != nil && row != sr.row {
+				formula := *cell.F
+				if formula.Content, err = f.shiftFormula(sheet, formula.Content, 0, row-sr.row); err != nil {
+					return rowMap, err
+				}
+				if formula.T == STCellFormulaTypeArray && formula.Ref != "" {
+					formula.Ref = shiftOperand(formula.Ref, 0, row-sr.row)
+				}
+				cell.F = &formula
+			}
+			ws.SheetData.Row[row-1].C[rect[0]+j-1] = cell
+		}
+	}
+	return rowMap, nil
+}
+
+// moveSortRowObjects moves the merged cells, hyperlinks and comments in the
+// sorted range with the rows.
+func (f *File) moveSortRowObjects(sheet string, ws *xlsxWorksheet, rect []int, rowMap map[int]int) error {
+	moveRef := func(ref string) (string, bool) {
+		rects, err := sqrefToRects(ref)
+		if err != nil || len(rects) == 0 || !inRect(rects[0], rect) || rects[0][1] != rects[0][3] {
+			return ref, false
+		}
+		row := rowMap[rects[0][1]]
+		newRef, err := rectsToSQRef([][]int{{rects[0][0], row, rects[0][2], row}})
+		return newRef, err == nil
+	}
+	if ws.MergeCells != nil {
+		for _, mc := range ws.MergeCells.Cells {
+			if mc == nil {
+				continue
+			}
+			if ref, ok := moveRef(mc.Ref); ok {
+				mc.Ref, mc.rect = ref, nil
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			ws.Hyperlinks.Hyperlink[i].Ref, _ = moveRef(ws.Hyperlinks.Hyperlink[i].Ref)
+		}
+	}
+	comments, err := f.getVMLComments(sheet, rect)
+	if err != nil {
+		return err
+	}
+	var moved []vmlComment
+	for _, comment := range comments {
+		if ref, _ := moveRef(comment.cell); ref == comment.cell {
+			continue
+		}
+		if err = f.DeleteComment(sheet, comment.cell); err != nil {
+			return err
+		}
+		moved = append(moved, comment)
+	}
+	for _, comment := range moved {
+		ref, _ := moveRef(comment.cell)
+		if err = f.addVMLComment(sheet, ref, comment); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// setSortState records the sort state in the table or worksheet.
+func (f *File) setSortState(ws *xlsxWorksheet, tbl *xlsxTable, tableXML string, rect []int, keys []SortKey, columns []int, opts SortOptions) error {
+	ref, err := coordinatesToRangeRef(rect)
+	if err != nil {
+		return err
+	}
+	state := &xlsxSortState{CaseSensitive: opts.CaseSensitive, Ref: ref}
+	for i, key := range keys {
+		cond := &xlsxSortCondition{Descending: key.Descending, CustomList: strings.Join(key.CustomList, ",")}
+		if cond.Ref, err = coordinatesToRangeRef([]int{columns[i], rect[1], columns[i], rect[3]}); err != nil {
+			return err
+		}
+		if key.SortBy != SortByValue {
+			style := &Style{Fill: Fill{Type: "pattern", Color: []string{key.Color}, Pattern: 1}}
+			if cond.SortBy = "cellColor"; key.SortBy == SortByFontColor {
+				style, cond.SortBy = &Style{Font: &Font{Color: key.Color}}, "fontColor"
+			}
+			dxfID, err := f.getDxfID(style)
+			if err != nil {
+				return err
+			}
+			cond.DxfID = intPtr(dxfID)
+		}
+		state.SortCondition = append(state.SortCondition, cond)
+	}
+	if tbl == nil {
+		ws.SortState = state
+		return err
+	}
+	tbl.SortState = state
+	table, err := xml.Marshal(tbl)
+	f.saveFileList(tableXML, table)
+	return err
+}
@@ -1,10 +1,12 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -178,6 +180,283 @@ func TestAutoFilter(t *testing.T) {
 	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B1", nil))
 }
 
+func TestApplyAutoFilter(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Name", "Score"},
+		{"apple", 10},
+		{"banana", 25},
+		{"Avocado", nil},
+		{"cherry", 40},
+		{"b*c", 5},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	for _, c := range []struct {
+		opts     []AutoFilterOptions
+		expected []int
+	}{
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == apple"}}, []int{3, 4, 5, 6}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == a*"}}, []int{3, 5, 6}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x != *an*"}}, []int{3}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == b~*?"}}, []int{2, 3, 4, 5}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x > b"}}, []int{2, 4}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == blanks"}}, []int{2, 3, 5, 6}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == nonblanks"}}, []int{4}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x >= 10 and x < 40"}}, []int{4, 5, 6}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == 5 or x == 40"}}, []int{2, 3, 4}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x > 5 or x == *a*"}}, []int{4, 6}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == *a*"}, {Column: "B", Expression: "x <= 10"}}, []int{3, 4, 5, 6}},
+		{[]AutoFilterOptions{}, nil},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:B6", c.opts))
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows, c.opts)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.False(t, ws.(*xlsxWorksheet).SheetPr.FilterMode)
+
+	// Test apply auto filter keeps the manually hidden rows
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 8, false))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B6", []AutoFilterOptions{{Column: "A", Expression: "x == cherry"}}))
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.FilterMode)
+	rows, err := f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 6}, rows)
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B6", []AutoFilterOptions{{Column: "A", Expression: "x == apple"}}))
+	rows, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5, 6}, rows)
+	visible, err := f.GetRowVisible("Sheet1", 8)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, true))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 8, true))
+
+	// Test get filtered rows of the table auto filter
+	f2 := NewFile()
+	for r, row := range [][]interface{}{{"Name"}, {"apple"}, {"banana"}} {
+		assert.NoError(t, f2.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	assert.NoError(t, f2.AddTable("Sheet1", &Table{Range: "A1:A3", Name: "Table1"}))
+	f2.Pkg.Store("xl/tables/table1.xml", []byte(`<table xmlns="`+NameSpaceSpreadSheet.Value+`" id="1" name="Table1" displayName="Table1" ref="A1:A3"><autoFilter ref="A1:A3"><filterColumn colId="0"><filters><filter val="banana"/></filters></filterColumn></autoFilter><tableColumns count="1"><tableColumn id="1" name="Name"/></tableColumns></table>`))
+	assert.NoError(t, f2.SetRowVisible("Sheet1", 2, false))
+	rows, err = f2.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, rows)
+	// Test get filtered rows with unsupported charset table
+	f2.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f2.GetFilteredRows("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f2.Close())
+
+	// Test apply top 10, dynamic filter and date group items
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B8", []AutoFilterOptions{{Column: "B", Expression: "x != blanks"}}))
+	filterColumn := ws.(*xlsxWorksheet).AutoFilter.FilterColumn[0]
+	for _, c := range []struct {
+		fc       xlsxFilterColumn
+		expected []int
+	}{
+		{xlsxFilterColumn{Top10: &xlsxTop10{Val: 2}}, []int{2, 4, 6, 7, 8}},
+		{xlsxFilterColumn{Top10: &xlsxTop10{Top: boolPtr(false), Val: 50, Percent: true}}, []int{3, 4, 5, 7, 8}},
+		{xlsxFilterColumn{Top10: &xlsxTop10{Val: 0}}, []int{2, 3, 4, 5, 6, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "aboveAverage"}}, []int{2, 4, 6, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "belowAverage"}}, []int{3, 4, 5, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "null"}}, nil},
+	} {
+		c.fc.ColID = 1
+		prev := *filterColumn
+		*filterColumn = c.fc
+		assert.NoError(t, f.applyFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1:B8", FilterColumn: []*xlsxFilterColumn{&prev}}, ws.(*xlsxWorksheet).AutoFilter))
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows)
+	}
+	today := time.Now()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", today))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", today.AddDate(-1, 0, 0)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", time.Date(today.Year(), 2, 14, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", "x"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B6", "y"))
+	for _, c := range []struct {
+		fc       xlsxFilterColumn
+		expected []int
+	}{
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "today"}}, []int{3, 4, 5, 6, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "lastYear"}}, []int{2, 4, 5, 6, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "M2"}}, []int{2, 3, 5, 6, 7, 8}},
+		{xlsxFilterColumn{DynamicFilter: &xlsxDynamicFilter{Type: "Q1"}}, []int{2, 3, 5, 6, 7, 8}},
+		{xlsxFilterColumn{Filters: &xlsxFilters{DateGroupItem: []*xlsxDateGroupItem{
+			{DateTimeGrouping: "month", Year: today.Year(), Month: 2},
+		}}}, []int{2, 3, 5, 6, 7, 8}},
+	} {
+		if today.Month() <= 3 && c.fc.DynamicFilter != nil && c.fc.DynamicFilter.Type == "Q1" {
+			c.expected = []int{3, 5, 6, 7, 8}
+		}
+		if today.Month() == 2 && (c.fc.Filters != nil || c.fc.DynamicFilter.Type == "M2") {
+			c.expected = []int{3, 5, 6, 7, 8}
+		}
+		c.fc.ColID = 1
+		prev := *filterColumn
+		*filterColumn = c.fc
+		assert.NoError(t, f.applyFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1:B8", FilterColumn: []*xlsxFilterColumn{&prev}}, ws.(*xlsxWorksheet).AutoFilter))
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows, c.fc)
+	}
+
+	// Test apply auto filter with invalid range reference
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A:B1"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.applyFilter("Sheet1", ws.(*xlsxWorksheet), nil, ws.(*xlsxWorksheet).AutoFilter))
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get filtered rows with not exist worksheet
+	_, err = f.GetFilteredRows("SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	// Test apply auto filter with unsupported charset shared strings table
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A1:B8"
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.applyFilter("Sheet1", ws.(*xlsxWorksheet), nil, ws.(*xlsxWorksheet).AutoFilter), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAutoFilterCriteria(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Region", "Date", "Sales"},
+		{"East", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), 10},
+		{"West", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 20},
+		{"North", time.Date(2023, 3, 8, 0, 0, 0, 0, time.UTC), 30},
+		{nil, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), 40},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	yellow, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C4", yellow))
+	blue, err := f.NewStyle(&Style{Font: &Font{Color: "0000FF"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", blue))
+	for _, c := range []struct {
+		opts     AutoFilterOptions
+		expected []int
+	}{
+		{AutoFilterOptions{Column: "A", Values: []string{"east", "West"}}, []int{4, 5}},
+		{AutoFilterOptions{Column: "A", Values: []string{"North"}, Blank: true}, []int{2, 3}},
+		{AutoFilterOptions{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "month", Year: 2024, Month: 3}}}, []int{3, 4}},
+		{AutoFilterOptions{Column: "C", CellColor: "#FFFF00"}, []int{2, 5}},
+		{AutoFilterOptions{Column: "A", FontColor: "0000FF"}, []int{3, 4, 5}},
+		{AutoFilterOptions{Column: "C", Top10: &AutoFilterTop10{Value: 1}}, []int{2, 3, 4}},
+		{AutoFilterOptions{Column: "C", Top10: &AutoFilterTop10{Bottom: true, Percent: true, Value: 50}}, []int{4, 5}},
+		{AutoFilterOptions{Column: "C", DynamicFilter: "aboveAverage"}, []int{2, 3}},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{c.opts}))
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows, c.opts)
+		ref, opts, err := f.GetAutoFilter("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, "$A$1:$C$5", ref)
+		if c.opts.CellColor != "" {
+			c.opts.CellColor = "FFFF00"
+		}
+		assert.Equal(t, []AutoFilterOptions{c.opts}, opts)
+	}
+	// Test filter by the same color again, the differential format will be reused
+	dxfs := len(f.Styles.Dxfs.Dxfs)
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "C", CellColor: "FFFF00"}}))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "A", FontColor: "0000FF"}}))
+	assert.Len(t, f.Styles.Dxfs.Dxfs, dxfs)
+	// Test read back the custom filters as expression
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "C", Expression: "x >= 20 and x != 30"}, {Column: "A", Expression: "x != blanks"}}))
+	_, opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterOptions{{Column: "C", Expression: "x >= 20 and x != 30"}, {Column: "A", Expression: "x != blanks"}}, opts)
+	for _, exp := range []string{"x == East", "x == East or x == West"} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "A", Expression: exp}}))
+		_, opts, err = f.GetAutoFilter("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []AutoFilterOptions{{Column: "A", Expression: exp}}, opts)
+	}
+	// Test read back the filter values, blank and dynamic filter
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "A", Expression: "x == blanks"}, {Column: "B", DynamicFilter: "M3"}}))
+	_, opts, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterOptions{{Column: "A", Blank: true}, {Column: "B", DynamicFilter: "M3"}}, opts)
+	rows, err := f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4}, rows)
+
+	// Test auto filter with invalid criteria
+	for _, opts := range []AutoFilterOptions{
+		{Column: "A", Values: []string{"East"}, CellColor: "FFFF00"},
+		{Column: "A", Expression: "x == 1", DynamicFilter: "today"},
+		{Column: "A", Top10: &AutoFilterTop10{Value: 0}},
+		{Column: "A", Top10: &AutoFilterTop10{Value: 501}},
+		{Column: "A", Top10: &AutoFilterTop10{Value: 101, Percent: true}},
+		{Column: "A", DynamicFilter: "M13"},
+		{Column: "A", DynamicFilter: "unknown"},
+		{Column: "A", DateGroups: []AutoFilterDateGroup{{Grouping: "week"}}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{opts}), opts)
+	}
+	// Test auto filter with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:C5", []AutoFilterOptions{{Column: "A", FontColor: "0000FF"}}), "XML syntax error on line 1: invalid UTF-8")
+	// Test get auto filter without auto filter
+	f = NewFile()
+	ref, opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.Nil(t, opts)
+	// Test get auto filter with not exist worksheet
+	_, _, err = f.GetAutoFilter("SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	// Test get auto filter with invalid range reference and column
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "A:B1"}
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "XFD1:XFD2", FilterColumn: []*xlsxFilterColumn{nil, {ColID: 1}}}
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, ErrColumnNumber, err)
+	// Test get auto filter with invalid color filter style ID
+	ws.(*xlsxWorksheet).AutoFilter = &xlsxAutoFilter{Ref: "A1:B2", FilterColumn: []*xlsxFilterColumn{
+		{ColorFilter: &xlsxColorFilter{DxfID: 100}},
+	}}
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, newInvalidStyleID(100), err)
+}
+
+func TestMatchFilterCriteria(t *testing.T) {
+	v := filterValue{raw: "1", text: "1", num: 1, isNum: true}
+	assert.True(t, newCustomFilterMatcher(nil)(v))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Val: "1"})(v))
+	assert.False(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "greaterThan", Val: " "})(v))
+	assert.False(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "greaterThan", Val: "a"})(v))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "lessThanOrEqual", Val: "2"})(v))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "notEqual", Val: "2"})(v))
+	v = filterValue{raw: "a", text: "a"}
+	assert.False(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "lessThan", Val: "1"})(v))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "lessThan", Val: "b"})(v))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "greaterThanOrEqual", Val: "a"})(v))
+	assert.True(t, v.matchFilters(&xlsxFilters{Filter: []*xlsxFilter{nil, {Val: "A"}}}, false))
+	assert.False(t, v.matchFilters(&xlsxFilters{DateGroupItem: []*xlsxDateGroupItem{{DateTimeGrouping: "year"}}}, false))
+	assert.True(t, newCustomFilterMatcher(&xlsxCustomFilter{Operator: "notEqual", Val: "a"})(filterValue{}))
+	date := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	assert.True(t, matchDateGroupItem(&xlsxDateGroupItem{DateTimeGrouping: "second", Year: 2024, Month: 5, Day: 6, Hour: 7, Minute: 8, Second: 9}, date))
+	assert.False(t, matchDateGroupItem(&xlsxDateGroupItem{DateTimeGrouping: "day", Year: 2024, Month: 5, Day: 7}, date))
+	assert.False(t, matchDateGroupItem(&xlsxDateGroupItem{DateTimeGrouping: "unknown", Year: 2024, Month: 5, Day: 6, Hour: 7, Minute: 8, Second: 9}, date))
+	assert.True(t, wildcardToRegexp("~~a~?*").MatchString("~a?bc"))
+	assert.False(t, newDynamicFilterMatcher(&xlsxDynamicFilter{Type: "today"}, nil, false)(filterValue{raw: "a"}))
+	assert.False(t, newDynamicFilterMatcher(&xlsxDynamicFilter{Type: "M1"}, nil, false)(filterValue{raw: "a"}))
+}
+
 func TestAutoFilterError(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilterError%d.xlsx")
 	f, err := prepareTestBook1()
@@ -224,3 +503,419 @@ func TestParseFilterTokens(t *testing.T) {
 	_, _, err = f.parseFilterTokens("", []string{"", "<", "x != blanks"})
 	assert.Equal(t, newInvalidAutoFilterOperatorError("<", ""), err)
 }
+
+func TestSortRange(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Name", "Score", "Level"},
+		{"bob", 80, "Low"},
+		{"Alice", 95, "High"},
+		{"carol", nil, "Medium"},
+		{"Dave", 80, "High"},
+		{"alice", "N/A", "Low"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "B2*2"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A3", Author: "Excelize", Text: "Alice"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A4", Author: "Excelize", Text: "Carol", Visible: true, Fill: Fill{Color: []string{"DDEBF7"}}}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A5", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C6", "D6"))
+	red, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, Font: &Font{Color: "0000FF"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A4", "A5", red))
+
+	getColumn := func(col string) []string {
+		var values []string
+		for r := 1; r <= 6; r++ {
+			val, err := f.GetCellValue("Sheet1", fmt.Sprintf("%s%d", col, r))
+			assert.NoError(t, err)
+			values = append(values, val)
+		}
+		return values
+	}
+	// Test sort by multiple keys with header detection
+	assert.NoError(t, f.SortRange("Sheet1", "D6:A1", []SortKey{
+		{Column: "B", Descending: true},
+		{Column: "A"},
+	}))
+	assert.Equal(t, []string{"Name", "alice", "Alice", "bob", "Dave", "carol"}, getColumn("A"))
+	assert.Equal(t, []string{"Score", "N/A", "95", "80", "80", ""}, getColumn("B"))
+	formula, err := f.GetCellFormula("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "B4*2", formula)
+	ht, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, ht)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A3", comments[0].Cell)
+	assert.Equal(t, "A6", comments[1].Cell)
+	assert.Equal(t, "Carol", comments[1].Text)
+	assert.True(t, comments[1].Visible)
+	assert.Equal(t, []string{"DDEBF7"}, comments[1].Fill.Color)
+	link, _, err := f.GetCellHyperLink("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C2", mergeCells[0].GetStartAxis())
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxSortState{Ref: "A2:D6", SortCondition: []*xlsxSortCondition{
+		{Descending: true, Ref: "B2:B6"}, {Ref: "A2:A6"},
+	}}, ws.(*xlsxWorksheet).SortState)
+
+	// Test sort with case sensitive and custom list
+	assert.NoError(t, f.SortRange("Sheet1", "A1:D6", []SortKey{
+		{Column: "C", CustomList: []string{"High", "Medium"}},
+		{Column: "A"},
+	}, SortOptions{CaseSensitive: true, Header: boolPtr(true)}))
+	assert.Equal(t, []string{"Name", "Alice", "Dave", "carol", "alice", "bob"}, getColumn("A"))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "A"}}, SortOptions{CaseSensitive: true, Header: boolPtr(false)}))
+	assert.Equal(t, []string{"Name", "alice", "Alice", "bob", "carol", "Dave"}, getColumn("A"))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "C", Descending: true, CustomList: []string{"High", "Medium"}}}))
+	assert.Equal(t, []string{"Level", "Medium", "High", "High", "Low", "Low"}, getColumn("C"))
+
+	// Test sort by cell color and font color
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "A"}}))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "A", SortBy: SortByCellColor, Color: "#FF0000"}}))
+	assert.Equal(t, []string{"Name", "carol", "Dave", "Alice", "alice", "bob"}, getColumn("A"))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "A", SortBy: SortByFontColor, Color: "0000FF", Descending: true}}))
+	assert.Equal(t, []string{"Name", "Alice", "alice", "bob", "carol", "Dave"}, getColumn("A"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "fontColor", ws.(*xlsxWorksheet).SortState.SortCondition[0].SortBy)
+	assert.NotNil(t, ws.(*xlsxWorksheet).SortState.SortCondition[0].DxfID)
+	// Test sort by the same color again, the differential format will be reused
+	dxfID := *ws.(*xlsxWorksheet).SortState.SortCondition[0].DxfID
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Column: "A", SortBy: SortByFontColor, Color: "0000FF"}}))
+	assert.Equal(t, dxfID, *ws.(*xlsxWorksheet).SortState.SortCondition[0].DxfID)
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 2)
+
+	// Test sort a table, the sort state will be recorded in the table
+	f = NewFile()
+	for r, row := range [][]interface{}{{"Item", "Qty"}, {"B", 2}, {"A", 3}, {"C", 1}, {"Total", 6}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B5", Name: "Table1"}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	tbl, tableXML, err := f.getSortTable("Sheet1", []int{1, 1, 2, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, tables[0].tableXML, tableXML)
+	tbl.TotalsRowCount = 1
+	table, err := xml.Marshal(tbl)
+	assert.NoError(t, err)
+	f.Pkg.Store(tableXML, table)
+	assert.NoError(t, f.SortRange("Sheet1", "A1:B5", []SortKey{{Column: "B"}}))
+	for cell, expected := range map[string]string{"A1": "Item", "A2": "C", "A3": "B", "A4": "A", "A5": "Total"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	tbl, _, err = f.getSortTable("Sheet1", []int{1, 1, 2, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:B4", tbl.SortState.Ref)
+
+	// Test sort range with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SortRange("Sheet1", "A1:B5", nil))
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A1:B5", []SortKey{{Column: "A", SortBy: SortByFontColor + 1}}))
+	assert.Equal(t, ErrParameterRequired, f.SortRange("Sheet1", "A1:B5", []SortKey{{Column: "A", SortBy: SortByCellColor}}))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SortRange("Sheet1", "A1:B5", []SortKey{{Column: "-"}}))
+	assert.Equal(t, newInvalidSortKeyColumnError("C"), f.SortRange("Sheet1", "A1:B5", []SortKey{{Column: "C"}}))
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A1", []SortKey{{Column: "A"}}))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SortRange("SheetN", "A1:B5", []SortKey{{Column: "A"}}))
+	// Test sort range with merged cells across multiple rows
+	assert.NoError(t, f.MergeCell("Sheet1", "D2", "D3"))
+	assert.Equal(t, ErrSortRange, f.SortRange("Sheet1", "C1:D5", []SortKey{{Column: "C"}}))
+	assert.Equal(t, ErrPartialMergedCells, f.SortRange("Sheet1", "C3:D5", []SortKey{{Column: "C"}}))
+	formulaType, ref := STCellFormulaTypeArray, "E2:E3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E2", "A2:A3", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.Equal(t, ErrSortRange, f.SortRange("Sheet1", "E1:E5", []SortKey{{Column: "E"}}))
+	// Test sort range with a single row
+	assert.NoError(t, f.SortRange("Sheet1", "A1:B1", []SortKey{{Column: "A"}}))
+	assert.NoError(t, f.SortRange("Sheet1", "A100:B100", []SortKey{{Column: "A"}}))
+	// Test sort range with a single data row
+	assert.NoError(t, f.SortRange("Sheet1", "F1:F2", []SortKey{{Column: "F"}}, SortOptions{Header: boolPtr(true)}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSortRange.xlsx")))
+}
+
+func TestCompareSortValues(t *testing.T) {
+	key := SortKey{}
+	assert.Zero(t, compareSortValues(sortValue{kind: sortValueError}, sortValue{kind: sortValueError}, key, false))
+	assert.Equal(t, 1, compareSortValues(sortValue{kind: sortValueNumber, num: 2}, sortValue{kind: sortValueNumber, num: 1}, key, false))
+	assert.Equal(t, -1, compareSortValues(sortValue{kind: sortValueBool, text: "0"}, sortValue{kind: sortValueBool, text: "1"}, key, false))
+	assert.Zero(t, compareText("ab", "ab", true))
+	assert.Equal(t, 1, compareText("aB", "ab", true))
+}
+
+func TestAddTableColumns(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Item", "Qty", "Price", "Amount"},
+		{"A", 2, 1.5},
+		{"B", 3, 2.5},
+		{"C", 4, 3.5},
+	} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	customNumFmt := "0.000"
+	columns := []TableColumn{
+		{Name: "Product", TotalsRowLabel: "Total"},
+		{TotalsRowFunction: "count"},
+		{NumFmt: 2, TotalsRowFunction: "average"},
+		{
+			Name:                    "Line Total",
+			CalculatedColumnFormula: "=[@Qty]*[@Price]",
+			CustomNumFmt:            &customNumFmt,
+			TotalsRowFunction:       "custom",
+			TotalsRowFormula:        "SUM(Sales[Line Total])",
+		},
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:D5", Name: "Sales", ShowTotalsRow: true, Columns: columns,
+	}))
+	for cell, expected := range map[string]string{
+		"A1": "Product", "D1": "Line Total", "A5": "Total", "C2": "1.50",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	for cell, expected := range map[string]string{
+		"B5": "SUBTOTAL(103,Sales[Qty])",
+		"C5": "SUBTOTAL(101,Sales[Price])",
+		"D5": "SUM(Sales[Line Total])",
+		"D3": "[@Qty]*[@Price]",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	for cell, expected := range map[string]string{"B5": "3", "C5": "2.5", "D4": "14", "D5": "24.5"} {
+		result, err := f.CalcCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test get the columns settings of the table
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.True(t, tables[0].ShowTotalsRow)
+	columns[0].CalculatedColumnFormula = ""
+	columns[1].Name, columns[2].Name = "Qty", "Price"
+	columns[3].CalculatedColumnFormula = "[@Qty]*[@Price]"
+	assert.Equal(t, columns, tables[0].Columns)
+	// Test fill down the calculated column formula after inserting rows
+	assert.NoError(t, f.InsertRows("Sheet1", 4, 1))
+	formula, err := f.GetCellFormula("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "[@Qty]*[@Price]", formula)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 10))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C4", 1))
+	result, err := f.CalcCellValue("Sheet1", "D6", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "34.5", result)
+	// Test remove the totals row after deleting the totals row
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, tables[0].ShowTotalsRow)
+	assert.Empty(t, tables[0].Columns[3].TotalsRowFunction)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableColumns.xlsx")))
+
+	// Test add table with totals row and hidden header row
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:A1", ShowHeaderRow: boolPtr(false), ShowTotalsRow: true,
+		Columns: []TableColumn{{Name: "Value", TotalsRowFunction: "sum"}},
+	}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:A3", tables[0].Range)
+	assert.Equal(t, "Value", tables[0].Columns[0].Name)
+	assert.False(t, *tables[0].ShowHeaderRow)
+	// Test add table with invalid totals row function
+	for _, column := range []TableColumn{{TotalsRowFunction: "unknown"}, {TotalsRowFunction: "custom"}} {
+		assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{
+			Range: "C1:C3", Columns: []TableColumn{column},
+		}))
+	}
+	// Test get tables with invalid number format style ID
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Columns: []TableColumn{{NumFmt: 2}}}))
+	f.Styles.Dxfs = nil
+	_, err = f.GetTables("Sheet1")
+	assert.Equal(t, newInvalidStyleID(0), err)
+}
+
+func TestSetTable(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Item", "Qty", "Price"},
+		{"A", 2, 1.5},
+		{"B", 3, 2.5},
+	} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:D3", Name: "Table1",
+		Columns: []TableColumn{{}, {}, {}, {Name: "Amount", CalculatedColumnFormula: "[@Qty]*[@Price]"}},
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "SUM(Table1[Amount])"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F2", "SUM(Table1[Price])"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Qty", RefersTo: "Table1[Qty]"}))
+	// Test append rows to the table
+	for r, row := range [][]interface{}{{"C", 4, 3.5}, {"D", 5, 4.5}} {
+		cell, err := CoordinatesToCellName(1, r+4)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.ResizeTable("Table1", "A1:D5"))
+	formula, err := f.GetCellFormula("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.Equal(t, "[@Qty]*[@Price]", formula)
+	result, err := f.CalcCellValue("Sheet1", "F1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "47", result)
+	// Test rename the table and columns, change style and show totals row
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	tbl := tables[0]
+	tbl.Name, tbl.StyleName, tbl.ShowTotalsRow = "Sales", "TableStyleMedium9", true
+	tbl.Columns = []TableColumn{{TotalsRowLabel: "Total"}, {Name: "Quantity", TotalsRowFunction: "sum"}}
+	assert.NoError(t, f.SetTable("Table1", &tbl))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D6", tables[0].Range)
+	assert.Equal(t, "Sales", tables[0].Name)
+	assert.Equal(t, "TableStyleMedium9", tables[0].StyleName)
+	assert.True(t, tables[0].ShowTotalsRow)
+	assert.Equal(t, "Quantity", tables[0].Columns[1].Name)
+	assert.Equal(t, "[@Quantity]*[@Price]", tables[0].Columns[3].CalculatedColumnFormula)
+	for cell, expected := range map[string]string{
+		"F1": "SUM(Sales[Amount])",
+		"D2": "[@Quantity]*[@Price]",
+		"B6": "SUBTOTAL(109,Sales[Quantity])",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	value, err := f.GetCellValue("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", value)
+	assert.Equal(t, "Sales[Quantity]", f.GetDefinedName()[0].RefersTo)
+	// Test move the totals row when resizing the table
+	assert.NoError(t, f.ResizeTable("sales", "A1:D7"))
+	for cell, expected := range map[string]string{"B6": "", "B7": "SUBTOTAL(109,Sales[Quantity])"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	formula, err = f.GetCellFormula("Sheet1", "D6")
+	assert.NoError(t, err)
+	assert.Equal(t, "[@Quantity]*[@Price]", formula)
+	// Test shrink the table columns which referenced by formulas
+	assert.Equal(t, newTableColumnReferencedError("Sales", "Amount"), f.ResizeTable("Sales", "A1:C7"))
+	formula, err = f.GetCellFormula("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Sales[Amount])", formula)
+	// Test shrink the table columns
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", ""))
+	assert.NoError(t, f.ResizeTable("Sales", "A1:C7"))
+	// Test toggle the header row and totals row
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	tbl = tables[0]
+	tbl.Range, tbl.ShowHeaderRow, tbl.ShowTotalsRow = "", boolPtr(false), false
+	assert.NoError(t, f.SetTable("Sales", &tbl))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:C6", tables[0].Range)
+	assert.False(t, *tables[0].ShowHeaderRow)
+	assert.False(t, tables[0].ShowTotalsRow)
+	formula, err = f.GetCellFormula("Sheet1", "B7")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test resize the table without header row
+	assert.NoError(t, f.ResizeTable("Sales", "A2:E6"))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Item", "Quantity", "Price", "Column4", "Column5"}, []string{
+		tables[0].Columns[0].Name, tables[0].Columns[1].Name, tables[0].Columns[2].Name,
+		tables[0].Columns[3].Name, tables[0].Columns[4].Name,
+	})
+	tbl = tables[0]
+	tbl.Range, tbl.ShowHeaderRow, tbl.ShowTotalsRow = "", nil, true
+	assert.NoError(t, f.SetTable("Sales", &tbl))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:E7", tables[0].Range)
+	assert.Nil(t, tables[0].ShowHeaderRow)
+	value, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Column4", value)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetTable.xlsx")))
+
+	// Test set table with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetTable("Sales", nil))
+	assert.Equal(t, newInvalidNameError("Table 1"), f.SetTable("Table 1", &Table{}))
+	assert.Equal(t, newInvalidNameError("Table 1"), f.SetTable("Sales", &Table{Name: "Table 1"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetTable("Sales", &Table{Columns: []TableColumn{{TotalsRowFunction: "unknown"}}}))
+	assert.Equal(t, newNoExistTableError("Table1"), f.SetTable("Table1", &Table{}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetTable("Sales", &Table{Range: "A:B1"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "H1:I2", Name: "Table2"}))
+	assert.Equal(t, ErrExistsTableName, f.SetTable("Sales", &Table{Name: "table2"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "K1:K3", Name: "Table3", ShowHeaderRow: boolPtr(false)}))
+	assert.NoError(t, f.ResizeTable("Table3", "K1:K3"))
+	assert.Equal(t, ErrParameterInvalid, f.SetTable("Table3", &Table{}))
+	// Test resize table without data row or overlaps with another table
+	assert.Equal(t, ErrTableRange, f.ResizeTable("Table2", "H1:I1"))
+	assert.Equal(t, ErrTableRange, f.SetTable("Table2", &Table{Range: "H5:I6", ShowTotalsRow: true}))
+	assert.Equal(t, ErrTableRangeOverlap, f.ResizeTable("Table2", "B1:I3"))
+	assert.Equal(t, ErrTableRangeOverlap, f.ResizeTable("Table2", "H1:K3"))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1:E7", "H1:I2", "K1:K3"}, []string{tables[0].Range, tables[1].Range, tables[2].Range})
+	assert.NoError(t, f.AutoFilter("Sheet1", "M1:N3", nil))
+	assert.Equal(t, ErrTableRangeOverlap, f.ResizeTable("Table2", "H1:M2"))
+	assert.NoError(t, f.ResizeTable("Table2", "H1:J2"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "M"
+	assert.Equal(t, ErrParameterInvalid, f.ResizeTable("Table2", "H1:I2"))
+	ws.(*xlsxWorksheet).AutoFilter = nil
+	// Test resize table with invalid parameters
+	assert.Equal(t, newInvalidNameError("Table 1"), f.ResizeTable("Table 1", "A1:B2"))
+	assert.Equal(t, newNoExistTableError("Table1"), f.ResizeTable("Table1", "A1:B2"))
+	// Test remove the table columns which referenced by defined name or the
+	// formulas of the removed columns
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:C3", Name: "Table1",
+		Columns: []TableColumn{{}, {}, {CalculatedColumnFormula: "[@Column2]*2"}},
+	}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Col2", RefersTo: "Table1[Column2]"}))
+	assert.Equal(t, newTableColumnReferencedError("Table1", "Column2"), f.ResizeTable("Table1", "A1:A3"))
+	assert.NoError(t, f.ResizeTable("Table1", "A1:B3"))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Col2"}))
+	assert.NoError(t, f.ResizeTable("Table1", "A1:A3"))
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.checkTableRangeOverlap("Sheet1", &xlsxTable{}, []int{1, 1, 1, 1}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.checkTableColumnsRefs("Sheet1", &xlsxTable{TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "A"}}}}, []int{1, 1, 1, 1}, []int{2, 1, 2, 1}), "XML syntax error on line 1: invalid UTF-8")
+
+	f, err = OpenFile(filepath.Join("test", "TestSetTable.xlsx"))
+	assert.NoError(t, err)
+	// Test set table and resize table with unsupported charset
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTable("Sales", &Table{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.ResizeTable("Sales", "A1:B2"), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -0,0 +1,698 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// threadedCommentTimeLayout defined the layout of the date time of the
+// threaded comments.
+const threadedCommentTimeLayout = "2006-01-02T15:04:05.00"
+
+// AddThreadedComment provides the method to add a threaded comment in a sheet
+// by given worksheet name and threaded comment options. The Cell and Text
+// fields are required, the Author default to "Author", and the Time default to
+// the current time. The person mentioned in the text should be specified by
+// display name in the Mentions field, and the text must include the display
+// name with an "@" prefix. Replies of the threaded comment can be specified by
+// the Replies field. Set the Done field to true to mark the thread as
+// resolved. A legacy comment with the same text was also created in the cell
+// as the fallback for the spreadsheet applications which don't support
+// threaded comments, so an error will be returned if the cell already has a
+// legacy comment.
+// For example, add a threaded comment with a reply in Sheet1!A1:
+//
+//	err := f.AddThreadedComment("Sheet1", excelize.ThreadedComment{
+//	    Cell:     "A1",
+//	    Author:   "Excelize",
+//	    Text:     "@Jane please check this value.",
+//	    Mentions: []string{"Jane"},
+//	    Replies: []excelize.ThreadedComment{
+//	        {Author: "Jane", Text: "Checked, it's correct."},
+//	    },
+//	})
+func (f *File) AddThreadedComment(sheet string, opts ThreadedComment) error {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	cell, err := f.prepareThreadedCommentCell(opts.Cell)
+	if err != nil {
+		return err
+	}
+	threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	if getThreadedCommentIndex(tc, cell) != -1 {
+		return ErrExistsThreadedComment
+	}
+	cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
+	if err != nil {
+		return err
+	}
+	if cmts != nil {
+		for _, cmt := range cmts.CommentList.Comment {
+			if cmt.Ref == cell {
+				return ErrExistsComment
+			}
+		}
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return err
+	}
+	root, err := newThreadedComment(persons, cell, "", opts)
+	if err != nil {
+		return err
+	}
+	if opts.Done {
+		root.Done = boolPtr(opts.Done)
+	}
+	tc.ThreadedComment = append(tc.ThreadedComment, *root)
+	for _, reply := range opts.Replies {
+		comment, err := newThreadedComment(persons, cell, root.ID, reply)
+		if err != nil {
+			return err
+		}
+		tc.ThreadedComment = append(tc.ThreadedComment, *comment)
+	}
+	if _, err = f.relsReader(f.getWorkbookRelsPath()); err != nil {
+		return err
+	}
+	if err = f.AddComment(sheet, Comment{
+		Cell:   cell,
+		Author: "tc=" + root.ID,
+		Text:   getThreadedCommentFallback(tc, root.ID),
+	}); err != nil {
+		return err
+	}
+	if threadedCommentsXML == "" {
+		if threadedCommentsXML, err = f.addSheetThreadedComments(sheet, sheetXMLPath); err != nil {
+			return err
+		}
+	}
+	if err = f.addPersonsPart(persons); err != nil {
+		return err
+	}
+	f.threadedCommentsWriter(threadedCommentsXML, tc)
+	return err
+}
+
+// ReplyThreadedComment provides the method to add a reply to the threaded
+// comment by given worksheet name, cell reference and reply options. The Text
+// field of the options is required, and the Cell and Replies fields will be
+// ignored. For example, reply to the threaded comment in Sheet1!A1:
+//
+//	err := f.ReplyThreadedComment("Sheet1", "A1", excelize.ThreadedComment{
+//	    Author: "Excelize",
+//	    Text:   "Thanks.",
+//	})
+func (f *File) ReplyThreadedComment(sheet, cell string, opts ThreadedComment) error {
+	threadedCommentsXML, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return err
+	}
+	root := tc.ThreadedComment[idx]
+	comment, err := newThreadedComment(persons, root.Ref, root.ID, opts)
+	if err != nil {
+		return err
+	}
+	pos := idx + 1
+	for i := pos; i < len(tc.ThreadedComment); i++ {
+		if tc.ThreadedComment[i].ParentID == root.ID {
+			pos = i + 1
+		}
+	}
+	tc.ThreadedComment = append(tc.ThreadedComment[:pos],
+		append([]xlsxThreadedComment{*comment}, tc.ThreadedComment[pos:]...)...)
+	if err = f.setThreadedCommentFallback(sheet, root.ID, getThreadedCommentFallback(tc, root.ID)); err != nil {
+		return err
+	}
+	if err = f.addPersonsPart(persons); err != nil {
+		return err
+	}
+	f.threadedCommentsWriter(threadedCommentsXML, tc)
+	return err
+}
+
+// ResolveThreadedComment provides the method to mark the threaded comment as
+// resolved or reopen it by given worksheet name, cell reference and resolved
+// state. For example, resolve the threaded comment in Sheet1!A1:
+//
+//	err := f.ResolveThreadedComment("Sheet1", "A1", true)
+func (f *File) ResolveThreadedComment(sheet, cell string, resolved bool) error {
+	threadedCommentsXML, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	tc.ThreadedComment[idx].Done = boolPtr(resolved)
+	f.threadedCommentsWriter(threadedCommentsXML, tc)
+	return err
+}
+
+// GetThreadedComments retrieves all threaded comments and their replies in a
+// worksheet by given worksheet name.
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var comments []ThreadedComment
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return comments, ErrSheetNotExist{sheet}
+	}
+	threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		return comments, nil
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return comments, err
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return comments, err
+	}
+	names := make(map[string]string, len(persons.Person))
+	for _, person := range persons.Person {
+		names[person.ID] = person.DisplayName
+	}
+	roots := map[string]int{}
+	for _, c := range tc.ThreadedComment {
+		comment := ThreadedComment{
+			ID: c.ID, Cell: c.Ref, Author: names[c.PersonID], Text: c.Text,
+		}
+		if c.Done != nil {
+			comment.Done = *c.Done
+		}
+		if c.DT != "" {
+			if comment.Time, err = time.Parse("2006-01-02T15:04:05", c.DT); err != nil {
+				return comments, err
+			}
+		}
+		if c.Mentions != nil {
+			for _, mention := range c.Mentions.Mention {
+				comment.Mentions = append(comment.Mentions, names[mention.MentionPersonID])
+			}
+		}
+		if idx, ok := roots[c.ParentID]; ok && c.ParentID != "" {
+			comments[idx].Replies = append(comments[idx].Replies, comment)
+			continue
+		}
+		roots[c.ID] = len(comments)
+		comments = append(comments, comment)
+	}
+	return comments, err
+}
+
+// DeleteThreadedComment provides the method to delete the threaded comment
+// with all of its replies and the legacy comment fallback by given worksheet
+// name and cell reference. The threaded comments part of the worksheet will
+// be removed after the last thread was deleted. For example, delete the
+// threaded comment in Sheet1!A1:
+//
+//	err := f.DeleteThreadedComment("Sheet1", "A1")
+func (f *File) DeleteThreadedComment(sheet, cell string) error {
+	_, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	rootID, ref := tc.ThreadedComment[idx].ID, tc.ThreadedComment[idx].Ref
+	if err = f.deleteThread(sheet, rootID); err != nil {
+		return err
+	}
+	return f.DeleteComment(sheet, ref)
+}
+
+// deleteThread provides a function to delete the threaded comment with all of
+// its replies by given worksheet name and the ID of the first comment of the
+// thread. The threaded comments part of the worksheet will be removed after
+// the last thread was deleted.
+func (f *File) deleteThread(sheet, rootID string) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		return nil
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	count, comments := len(tc.ThreadedComment), tc.ThreadedComment[:0]
+	for _, c := range tc.ThreadedComment {
+		if c.ID != rootID && c.ParentID != rootID {
+			comments = append(comments, c)
+		}
+	}
+	if len(comments) == count {
+		return err
+	}
+	if tc.ThreadedComment = comments; len(comments) > 0 {
+		f.threadedCommentsWriter(threadedCommentsXML, tc)
+		return err
+	}
+	return f.deleteSheetThreadedComments(sheet, threadedCommentsXML)
+}
+
+// addThreadedCommentEntries provides a function to add the threaded comment
+// with its replies which got by the getVMLComments function to the cell by
+// given worksheet name and cell reference. The persons referenced by the
+// comments will be added to the persons part if not exist.
+func (f *File) addThreadedCommentEntries(sheet, cell string, comments []xlsxThreadedComment, persons []xlsxPerson) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	if threadedCommentsXML == "" {
+		if threadedCommentsXML, err = f.addSheetThreadedComments(sheet, sheetXMLPath); err != nil {
+			return err
+		}
+	}
+	for _, c := range comments {
+		c.Ref = cell
+		tc.ThreadedComment = append(tc.ThreadedComment, c)
+	}
+	f.threadedCommentsWriter(threadedCommentsXML, tc)
+	personList, err := f.personsReader()
+	if err != nil {
+		return err
+	}
+	count, IDs := len(personList.Person), map[string]struct{}{}
+	for _, person := range personList.Person {
+		IDs[person.ID] = struct{}{}
+	}
+	for _, person := range persons {
+		if _, ok := IDs[person.ID]; !ok {
+			personList.Person = append(personList.Person, person)
+		}
+	}
+	if len(personList.Person) == count {
+		return err
+	}
+	return f.addPersonsPart(personList)
+}
+
+// getThreadedCommentPersons provides a function to get the persons referenced
+// by the authors and mentions of the given threaded comments.
+func getThreadedCommentPersons(comments []xlsxThreadedComment, persons map[string]xlsxPerson) []xlsxPerson {
+	var list []xlsxPerson
+	seen := map[string]struct{}{}
+	add := func(ID string) {
+		if _, ok := seen[ID]; ok {
+			return
+		}
+		seen[ID] = struct{}{}
+		if person, ok := persons[ID]; ok {
+			list = append(list, person)
+		}
+	}
+	for _, c := range comments {
+		add(c.PersonID)
+		if c.Mentions != nil {
+			for _, mention := range c.Mentions.Mention {
+				add(mention.MentionPersonID)
+			}
+		}
+	}
+	return list
+}
+
+// renewThreadedCommentIDs provides a function to generate the new IDs of the
+// threaded comments and mentions of the copied comment, the author of the
+// comment will be updated with the new ID of the first comment of the thread.
+func renewThreadedCommentIDs(comment *vmlComment) error {
+	if len(comment.threads) == 0 {
+		return nil
+	}
+	IDs := map[string]string{}
+	threads := make([]xlsxThreadedComment, len(comment.threads))
+	for i, c := range comment.threads {
+		ID, err := newThreadedCommentID()
+		if err != nil {
+			return err
+		}
+		IDs[c.ID], c.ID = ID, ID
+		if c.Mentions != nil {
+			mentions := &xlsxMentions{Mention: append([]xlsxMention{}, c.Mentions.Mention...)}
+			for j := range mentions.Mention {
+				if mentions.Mention[j].MentionID, err = newThreadedCommentID(); err != nil {
+					return err
+				}
+			}
+			c.Mentions = mentions
+		}
+		threads[i] = c
+	}
+	for i := range threads {
+		if threads[i].ParentID != "" {
+			threads[i].ParentID = IDs[threads[i].ParentID]
+		}
+	}
+	comment.threads = threads
+	comment.author = "tc=" + IDs[strings.TrimPrefix(comment.author, "tc=")]
+	return nil
+}
+
+// prepareThreadedCommentCell provides a function to check and normalize the
+// given cell reference of the threaded comment.
+func (f *File) prepareThreadedCommentCell(cell string) (string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return cell, err
+	}
+	return CoordinatesToCellName(col, row)
+}
+
+// getThreadedComment provides a function to get the threaded comments part
+// path, the threaded comments and the index of the first comment of the thread
+// by given worksheet name and cell reference.
+func (f *File) getThreadedComment(sheet, cell string) (string, *xlsxThreadedComments, int, error) {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return "", nil, -1, ErrSheetNotExist{sheet}
+	}
+	ref, err := f.prepareThreadedCommentCell(cell)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	if threadedCommentsXML == "" {
+		return "", nil, -1, newNoExistThreadedCommentError(cell)
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	idx := getThreadedCommentIndex(tc, ref)
+	if idx == -1 {
+		return "", nil, -1, newNoExistThreadedCommentError(cell)
+	}
+	return threadedCommentsXML, tc, idx, err
+}
+
+// getThreadedCommentIndex provides a function to get the index of the first
+// comment of the thread by given cell reference, returns -1 if not found.
+func getThreadedCommentIndex(tc *xlsxThreadedComments, cell string) int {
+	for i, c := range tc.ThreadedComment {
+		if c.ParentID == "" && c.Ref == cell {
+			return i
+		}
+	}
+	return -1
+}
+
+// newThreadedComment provides a function to create a threaded comment by given
+// persons, cell reference, parent ID of the thread and the threaded comment
+// options.
+func newThreadedComment(persons *xlsxPersonList, cell, parentID string, opts ThreadedComment) (*xlsxThreadedComment, error) {
+	if opts.Text == "" {
+		return nil, ErrParameterRequired
+	}
+	if utf8.RuneCountInString(opts.Text) > TotalCellChars {
+		return nil, ErrCellCharsLength
+	}
+	if opts.Author == "" {
+		opts.Author = "Author"
+	}
+	if utf8.RuneCountInString(opts.Author) > MaxFieldLength {
+		opts.Author = string([]rune(opts.Author)[:MaxFieldLength])
+	}
+	if opts.Time.IsZero() {
+		opts.Time = time.Now()
+	}
+	ID, err := newThreadedCommentID()
+	if err != nil {
+		return nil, err
+	}
+	comment := xlsxThreadedComment{
+		Ref:      cell,
+		DT:       opts.Time.UTC().Format(threadedCommentTimeLayout),
+		PersonID: getPersonID(persons, opts.Author),
+		ID:       ID,
+		ParentID: parentID,
+		Text:     opts.Text,
+	}
+	var offset int
+	for _, name := range opts.Mentions {
+		pos := strings.Index(opts.Text[offset:], "@"+name)
+		if name == "" || pos == -1 {
+			return nil, ErrParameterInvalid
+		}
+		if comment.Mentions == nil {
+			comment.Mentions = &xlsxMentions{}
+		}
+		if ID, err = newThreadedCommentID(); err != nil {
+			return nil, err
+		}
+		comment.Mentions.Mention = append(comment.Mentions.Mention, xlsxMention{
+			MentionPersonID: getPersonID(persons, name),
+			MentionID:       ID,
+			StartIndex:      len(utf16.Encode([]rune(opts.Text[:offset+pos]))),
+			Length:          len(utf16.Encode([]rune("@" + name))),
+		})
+		offset += pos + len(name) + 1
+	}
+	return &comment, nil
+}
+
+// newThreadedCommentID provides a function to generate a random version 4
+// GUID for the threaded comment or mention.
+func newThreadedCommentID() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[:4], b[4:6], b[6:8], b[8:10], b[10:]), err
+}
+
+// getPersonID provides a function to get the ID of the person by given display
+// name, a new person will be created if not found.
+func getPersonID(persons *xlsxPersonList, name string) string {
+	used := map[string]struct{}{}
+	for _, person := range persons.Person {
+		if person.DisplayName == name {
+			return person.ID
+		}
+		used[person.ID] = struct{}{}
+	}
+	for n := len(persons.Person) + 1; ; n++ {
+		ID := fmt.Sprintf("{00000000-0000-0000-0000-%012X}", n)
+		if _, ok := used[ID]; !ok {
+			persons.Person = append(persons.Person, xlsxPerson{
+				DisplayName: name, ID: ID, UserID: name, ProviderID: "None",
+			})
+			return ID
+		}
+	}
+}
+
+// getThreadedCommentFallback provides a function to get the text of the
+// legacy comment for the threaded comment by given threaded comments and the
+// ID of the first comment of the thread, the text will be truncated to the
+// maximum characters limit of the cell.
+func getThreadedCommentFallback(tc *xlsxThreadedComments, rootID string) string {
+	var text strings.Builder
+	text.WriteString("[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n")
+	for _, c := range tc.ThreadedComment {
+		if c.ID == rootID {
+			text.WriteString("\nComment:\n    " + c.Text)
+		}
+		if c.ParentID == rootID {
+			text.WriteString("\nReply:\n    " + c.Text)
+		}
+	}
+	if utf8.RuneCountInString(text.String()) > TotalCellChars {
+		return string([]rune(text.String())[:TotalCellChars])
+	}
+	return text.String()
+}
+
+// setThreadedCommentFallback provides a function to update the text of the
+// legacy comment for the threaded comment by given worksheet name, the ID of
+// the first comment of the thread and text.
+func (f *File) setThreadedCommentFallback(sheet, rootID, text string) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil || cmts == nil {
+		return err
+	}
+	authorID := inStrSlice(cmts.Authors.Author, "tc="+rootID, true)
+	for i := range cmts.CommentList.Comment {
+		if authorID != -1 && cmts.CommentList.Comment[i].AuthorID == authorID {
+			cmts.CommentList.Comment[i].Text = xlsxText{T: stringPtr(text)}
+		}
+	}
+	return err
+}
+
+// getSheetThreadedCommentsPath provides a function to get the threaded
+// comments part path in the package by given worksheet XML path, returns empty
+// string if not found.
+func (f *File) getSheetThreadedCommentsPath(sheetXMLPath string) string {
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels == nil {
+		return ""
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, v := range rels.Relationships {
+		if v.Type == SourceRelationshipThreadedComment {
+			if strings.HasPrefix(v.Target, "/") {
+				return strings.TrimPrefix(v.Target, "/")
+			}
+			return "xl" + strings.TrimPrefix(v.Target, "..")
+		}
+	}
+	return ""
+}
+
+// addSheetThreadedComments provides a function to add the relationships and
+// content types of a new threaded comments part for the worksheet by given
+// worksheet name and worksheet XML path, returns the path of the part.
+func (f *File) addSheetThreadedComments(sheet, sheetXMLPath string) (string, error) {
+	var count int
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/threadedComments/threadedComment") {
+			count++
+		}
+		return true
+	})
+	ID := count + 1
+	for {
+		if _, ok := f.Pkg.Load("xl/threadedComments/threadedComment" + strconv.Itoa(ID) + ".xml"); !ok {
+			break
+		}
+		ID++
+	}
+	threadedCommentsXML := "xl/threadedComments/threadedComment" + strconv.Itoa(ID) + ".xml"
+	sheetRels := "xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels"
+	f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(ID)+".xml", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return threadedCommentsXML, f.addContentTypePart(ID, "threadedComment")
+}
+
+// deleteSheetThreadedComments provides a function to delete the threaded
+// comments part and its relationships and content types by given worksheet
+// name and path of the part. The persons part will be deleted if there is no
+// threaded comments part in the workbook.
+func (f *File) deleteSheetThreadedComments(sheet, threadedCommentsXML string) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels != nil {
+		var rID string
+		rels.mu.Lock()
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				rID = v.ID
+			}
+		}
+		rels.mu.Unlock()
+		f.deleteSheetRelationships(sheet, rID)
+	}
+	f.Pkg.Delete(threadedCommentsXML)
+	if err := f.removeContentTypesPart(ContentTypeSpreadSheetMLThreadedComments, "/"+threadedCommentsXML); err != nil {
+		return err
+	}
+	var exist bool
+	f.Pkg.Range(func(k, v interface{}) bool {
+		exist = strings.HasPrefix(k.(string), "xl/threadedComments/threadedComment")
+		return !exist
+	})
+	if exist {
+		return nil
+	}
+	f.Pkg.Delete(defaultXMLPersonPart)
+	if _, err := f.deleteWorkbookRels(SourceRelationshipPerson, strings.TrimPrefix(defaultXMLPersonPart, "xl/")); err != nil {
+		return err
+	}
+	return f.removeContentTypesPart(ContentTypePerson, "/"+defaultXMLPersonPart)
+}
+
+// addPersonsPart provides a function to save the persons part, and add the
+// workbook relationships and content types of it if not exist.
+func (f *File) addPersonsPart(persons *xlsxPersonList) error {
+	persons.XMLNS = NameSpaceSpreadSheetThreadedComments
+	persons.XMLNSX = NameSpaceSpreadSheet.Value
+	output, err := xml.Marshal(persons)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(defaultXMLPersonPart, output)
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return err
+	}
+	var exist bool
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipPerson {
+				exist = true
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if !exist {
+		f.addRels(relPath, SourceRelationshipPerson, strings.TrimPrefix(defaultXMLPersonPart, "xl/"), "")
+	}
+	return f.addContentTypePart(0, "person")
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	var tc xlsxThreadedComments
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&tc); err != nil && err != io.EOF {
+		return &tc, err
+	}
+	return &tc, nil
+}
+
+// threadedCommentsWriter provides a function to save
+// xl/threadedComments/threadedComment%d.xml after serialize structure.
+func (f *File) threadedCommentsWriter(path string, tc *xlsxThreadedComments) {
+	tc.XMLNS = NameSpaceSpreadSheetThreadedComments
+	tc.XMLNSX = NameSpaceSpreadSheet.Value
+	output, _ := xml.Marshal(tc)
+	f.saveFileList(path, output)
+}
+
+// personsReader provides a function to get the pointer to the structure after
+// deserialization of xl/persons/person.xml.
+func (f *File) personsReader() (*xlsxPersonList, error) {
+	var persons xlsxPersonList
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPersonPart)))).
+		Decode(&persons); err != nil && err != io.EOF {
+		return &persons, err
+	}
+	return &persons, nil
+}
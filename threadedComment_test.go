@@ -0,0 +1,407 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddThreadedComment(t *testing.T) {
+	f := NewFile()
+	dt := time.Date(2024, 5, 16, 8, 55, 20, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell:     "a1",
+		Author:   "Excelize",
+		Text:     "@Jane please check this value, @李雷",
+		Mentions: []string{"Jane", "李雷"},
+		Time:     dt,
+		Replies: []ThreadedComment{
+			{Author: "Jane", Text: "Checked.", Time: dt.Add(time.Minute)},
+		},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Text: "Resolved", Done: true, Time: dt}))
+	// Test add threaded comment in the cell which already has a thread
+	assert.Equal(t, ErrExistsThreadedComment, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+
+	tc, err := f.threadedCommentsReader("xl/threadedComments/threadedComment1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, tc.ThreadedComment, 3)
+	guid := regexp.MustCompile(`^{[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}}$`)
+	assert.Regexp(t, guid, tc.ThreadedComment[0].ID)
+	assert.Equal(t, "2024-05-16T08:55:20.00", tc.ThreadedComment[0].DT)
+	mentions := tc.ThreadedComment[0].Mentions.Mention
+	for _, mention := range mentions {
+		assert.Regexp(t, guid, mention.MentionID)
+		assert.NotEqual(t, tc.ThreadedComment[0].ID, mention.MentionID)
+	}
+	assert.NotEqual(t, mentions[0].MentionID, mentions[1].MentionID)
+	mentions[0].MentionID, mentions[1].MentionID = "", ""
+	assert.Equal(t, []xlsxMention{
+		{MentionPersonID: "{00000000-0000-0000-0000-000000000002}", StartIndex: 0, Length: 5},
+		{MentionPersonID: "{00000000-0000-0000-0000-000000000003}", StartIndex: 31, Length: 3},
+	}, mentions)
+	assert.Equal(t, tc.ThreadedComment[0].ID, tc.ThreadedComment[1].ParentID)
+
+	// Test the legacy comment fallback
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "tc="+tc.ThreadedComment[0].ID, comments[0].Author)
+	assert.True(t, strings.HasSuffix(comments[0].Text, "Comment:\n    @Jane please check this value, @李雷\nReply:\n    Checked."))
+
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ThreadedComment{
+		{
+			ID: tc.ThreadedComment[0].ID, Cell: "A1", Author: "Excelize",
+			Text:     "@Jane please check this value, @李雷",
+			Mentions: []string{"Jane", "李雷"}, Time: dt,
+			Replies: []ThreadedComment{
+				{ID: tc.ThreadedComment[1].ID, Cell: "A1", Author: "Jane", Text: "Checked.", Time: dt.Add(time.Minute)},
+			},
+		},
+		{ID: tc.ThreadedComment[2].ID, Cell: "B2", Author: "Author", Text: "Resolved", Time: dt, Done: true},
+	}, threads)
+
+	// Test add threaded comments in another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "C3", Author: "Jane", Text: "Comment"}))
+	threads, err = f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 1)
+	assert.Regexp(t, guid, threads[0].ID)
+	assert.NotEqual(t, tc.ThreadedComment[0].ID, threads[0].ID)
+
+	path := filepath.Join("test", "TestAddThreadedComment.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 2)
+	assert.Len(t, threads[0].Replies, 1)
+	persons, err := f.personsReader()
+	assert.NoError(t, err)
+	assert.Len(t, persons.Person, 4)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add threaded comment with not exist worksheet
+	assert.EqualError(t, f.AddThreadedComment("SheetN", ThreadedComment{Cell: "A1", Text: "Comment"}), "sheet SheetN does not exist")
+	// Test add threaded comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A", Text: "Comment"}))
+	// Test add threaded comment without text
+	assert.Equal(t, ErrParameterRequired, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}))
+	// Test add threaded comment with exceeds text length
+	assert.Equal(t, ErrCellCharsLength, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: strings.Repeat("c", TotalCellChars+1)}))
+	// Test add threaded comment with mention not in the text
+	assert.Equal(t, ErrParameterInvalid, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment", Mentions: []string{"Jane"}}))
+	// Test add threaded comment with invalid reply
+	assert.Equal(t, ErrParameterRequired, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment", Replies: []ThreadedComment{{}}}))
+	_, ok := f.Pkg.Load(defaultXMLPersonPart)
+	assert.False(t, ok)
+	// Test add threaded comment in the cell which already has a legacy comment
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Note"}))
+	assert.Equal(t, ErrExistsComment, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	_, ok = f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.True(t, strings.HasPrefix(comments[0].Author, "tc="))
+	// Test add threaded comment with multi-byte text and author, the length
+	// limits are counted by characters
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "D4", Author: strings.Repeat("作", MaxFieldLength+1), Text: "Comment"}))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "D4", ThreadedComment{Text: strings.Repeat("文", TotalCellChars)}))
+	assert.Equal(t, ErrCellCharsLength, f.ReplyThreadedComment("Sheet1", "D4", ThreadedComment{Text: strings.Repeat("文", TotalCellChars+1)}))
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("作", MaxFieldLength), threads[1].Author)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, utf8.ValidString(comments[1].Text))
+	assert.Equal(t, TotalCellChars, utf8.RuneCountInString(comments[1].Text))
+
+	// Test add threaded comment with unsupported charset threaded comments
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A2", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset comments
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Note"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A2", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset persons
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPersonPart, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test the threaded comments and persons parts not be created when add the
+	// legacy comment fallback failed
+	for _, part := range []string{"xl/threadedComments/threadedComment1.xml", defaultXMLPersonPart} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	assert.Empty(t, f.getSheetThreadedCommentsPath("xl/worksheets/sheet1.xml"))
+}
+
+func TestReplyThreadedComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Excelize", Text: "Comment A1"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B1", Author: "Excelize", Text: "Comment B1"}))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Jane", Text: "Reply 1 @Excelize", Mentions: []string{"Excelize"}}))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Jane", Text: "Reply 2"}))
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "B1", ThreadedComment{Text: "Reply 3"}))
+
+	tc, err := f.threadedCommentsReader("xl/threadedComments/threadedComment1.xml")
+	assert.NoError(t, err)
+	var text []string
+	for _, c := range tc.ThreadedComment {
+		text = append(text, c.Text)
+	}
+	assert.Equal(t, []string{"Comment A1", "Reply 1 @Excelize", "Reply 2", "Comment B1", "Reply 3"}, text)
+
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 2)
+	assert.Len(t, threads[0].Replies, 2)
+	assert.Equal(t, []string{"Excelize"}, threads[0].Replies[0].Mentions)
+	assert.Equal(t, "Author", threads[1].Replies[0].Author)
+
+	// Test update the legacy comment fallback
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(comments[0].Text, "Comment:\n    Comment A1\nReply:\n    Reply 1 @Excelize\nReply:\n    Reply 2"))
+
+	// Test reply threaded comment only update the legacy comment fallback of
+	// the thread
+	cmts := f.Comments["xl/comments1.xml"]
+	cmts.Authors.Author = append(cmts.Authors.Author, "Excelize")
+	cmts.CommentList.Comment = append(cmts.CommentList.Comment, xlsxComment{
+		Ref: "A1", AuthorID: len(cmts.Authors.Author) - 1, Text: xlsxText{T: stringPtr("Note")},
+	})
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Text: "Reply 4"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.True(t, strings.HasSuffix(comments[0].Text, "Reply 4"))
+	assert.Equal(t, "Note", comments[2].Text)
+
+	// Test reply threaded comment with not exist worksheet
+	assert.EqualError(t, f.ReplyThreadedComment("SheetN", "A1", ThreadedComment{Text: "Reply"}), "sheet SheetN does not exist")
+	// Test reply threaded comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ReplyThreadedComment("Sheet1", "A", ThreadedComment{Text: "Reply"}))
+	// Test reply not exist threaded comment
+	assert.Equal(t, newNoExistThreadedCommentError("C1"), f.ReplyThreadedComment("Sheet1", "C1", ThreadedComment{Text: "Reply"}))
+	assert.Equal(t, newNoExistThreadedCommentError("A1"), NewFile().ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Text: "Reply"}))
+	// Test reply threaded comment without text
+	assert.Equal(t, ErrParameterRequired, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{}))
+	// Test reply threaded comment with unsupported charset persons
+	f.Pkg.Store(defaultXMLPersonPart, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Text: "Reply"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test reply threaded comment with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ReplyThreadedComment("Sheet1", "A1", ThreadedComment{Text: "Reply"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestResolveThreadedComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	assert.NoError(t, f.ResolveThreadedComment("Sheet1", "A1", true))
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, threads[0].Done)
+	assert.NoError(t, f.ResolveThreadedComment("Sheet1", "A1", false))
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, threads[0].Done)
+	// Test resolve not exist threaded comment
+	assert.Equal(t, newNoExistThreadedCommentError("B1"), f.ResolveThreadedComment("Sheet1", "B1", true))
+}
+
+func TestGetThreadedComments(t *testing.T) {
+	f := NewFile()
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 0)
+	// Test get threaded comments with not exist worksheet
+	_, err = f.GetThreadedComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get threaded comments with invalid date time
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="A1" dT="x" personId="{00000000-0000-0000-0000-000000000001}" id="{00000000-0000-0000-0001-000000000001}"><text>Comment</text></threadedComment></ThreadedComments>`))
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.Error(t, err)
+	// Test get threaded comments with unsupported charset persons
+	f.Pkg.Store(defaultXMLPersonPart, MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get threaded comments with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestDeleteThreadedComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment A1", Replies: []ThreadedComment{{Text: "Reply"}}}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B1", Text: "Comment B1"}))
+	assert.NoError(t, f.DeleteThreadedComment("Sheet1", "A1"))
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 1)
+	assert.Equal(t, "B1", threads[0].Cell)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B1", comments[0].Cell)
+	// Test add threaded comment after deleted
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment A1"}))
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 2)
+	assert.NotEqual(t, threads[0].ID, threads[1].ID)
+	// Test delete not exist threaded comment
+	assert.Equal(t, newNoExistThreadedCommentError("C1"), f.DeleteThreadedComment("Sheet1", "C1"))
+	// Test delete the legacy comment fallback will delete the thread as well
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "C1", Text: "Comment C1", Replies: []ThreadedComment{{Text: "Reply"}}}))
+	assert.NoError(t, f.DeleteComment("Sheet1", "C1"))
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 2)
+	assert.Equal(t, []string{"B1", "A1"}, []string{threads[0].Cell, threads[1].Cell})
+	// Test delete the last threaded comments, the parts and relationships will be removed
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	for _, cell := range []string{"A1", "B1"} {
+		assert.NoError(t, f.DeleteThreadedComment("Sheet1", cell))
+	}
+	_, ok := f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.False(t, ok)
+	assert.Empty(t, f.getSheetThreadedCommentsPath("xl/worksheets/sheet1.xml"))
+	_, ok = f.Pkg.Load(defaultXMLPersonPart)
+	assert.True(t, ok)
+	assert.NoError(t, f.DeleteThreadedComment("Sheet2", "A1"))
+	_, ok = f.Pkg.Load(defaultXMLPersonPart)
+	assert.False(t, ok)
+	for _, override := range f.ContentTypes.Overrides {
+		assert.NotContains(t, []string{ContentTypeSpreadSheetMLThreadedComments, ContentTypePerson}, override.ContentType)
+	}
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipPerson, rel.Type)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteThreadedComment.xlsx")))
+	threads, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, threads)
+	// Test delete the last threaded comment with unsupported charset content types
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteThreadedComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete the last threaded comment with unsupported charset workbook relationships
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteThreadedComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete threaded comment with not exist worksheet
+	assert.EqualError(t, f.DeleteThreadedComment("SheetN", "A1"), "sheet SheetN does not exist")
+}
+
+func TestMoveThreadedComment(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "A1", Author: "Excelize", Text: "Comment",
+		Replies: []ThreadedComment{{Author: "Jane", Text: "@Excelize Reply", Mentions: []string{"Excelize"}}},
+	}))
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	rootID := threads[0].ID
+	checkThreads := func(sheet string, cells ...string) []ThreadedComment {
+		threads, err := f.GetThreadedComments(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, threads, len(cells))
+		comments, err := f.GetComments(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, comments, len(cells))
+		for i, cell := range cells {
+			assert.Equal(t, cell, threads[i].Cell)
+			for _, reply := range threads[i].Replies {
+				assert.Equal(t, cell, reply.Cell)
+			}
+			assert.Equal(t, cell, comments[i].Cell)
+			assert.Equal(t, "tc="+threads[i].ID, comments[i].Author)
+		}
+		return threads
+	}
+	// Test move the threaded comment with the cells in the worksheet
+	assert.NoError(t, f.MoveRange("Sheet1", "A1", "Sheet1", "C3"))
+	threads = checkThreads("Sheet1", "C3")
+	assert.Equal(t, rootID, threads[0].ID)
+	assert.NoError(t, f.ReplyThreadedComment("Sheet1", "C3", ThreadedComment{Text: "Reply"}))
+	// Test move the threaded comment with the cells to another worksheet
+	assert.NoError(t, f.MoveRange("Sheet1", "C3", "Sheet2", "B2"))
+	checkThreads("Sheet1")
+	threads = checkThreads("Sheet2", "B2")
+	assert.Equal(t, rootID, threads[0].ID)
+	assert.Len(t, threads[0].Replies, 2)
+	assert.Equal(t, "Excelize", threads[0].Author)
+	assert.Equal(t, "Jane", threads[0].Replies[0].Author)
+	assert.Equal(t, []string{"Excelize"}, threads[0].Replies[0].Mentions)
+	// Test copy the threaded comment with the cells
+	assert.NoError(t, f.CopyRange("Sheet2", "B2", "Sheet2", "D4", nil))
+	threads = checkThreads("Sheet2", "B2", "D4")
+	assert.NotEqual(t, threads[0].ID, threads[1].ID)
+	assert.Len(t, threads[1].Replies, 2)
+	assert.Equal(t, []string{"Excelize"}, threads[1].Replies[0].Mentions)
+	assert.NotEqual(t, threads[0].Replies[0].ID, threads[1].Replies[0].ID)
+	// Test sort the range with threaded comments
+	assert.NoError(t, f.SetSheetCol("Sheet2", "D2", &[]interface{}{2, 3, 1}))
+	assert.NoError(t, f.SortRange("Sheet2", "D2:D4", []SortKey{{Column: "D"}}))
+	checkThreads("Sheet2", "B2", "D2")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveThreadedComment.xlsx")))
+	assert.NoError(t, f.Close())
+}
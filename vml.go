@@ -34,20 +34,20 @@ const (
 	FormControlGroupBox
 	FormControlLabel
 	FormControlScrollBar
+	FormControlComboBox
+	FormControlListBox
 )
 
 // GetComments retrieves all comments in a worksheet by given worksheet name.
+// The visibility, fill, border, size and offset of the comment boxes will also
+// be returned.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
 	if !ok {
 		return comments, ErrSheetNotExist{sheet}
 	}
-	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
-	if !strings.HasPrefix(commentsXML, "/") {
-		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
-	}
-	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
 	cmts, err := f.commentsReader(commentsXML)
 	if err != nil {
 		return comments, err
@@ -75,7 +75,142 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 			comments = append(comments, comment)
 		}
 	}
-	return comments, nil
+	return comments, f.extractCommentShapes(sheet, comments)
+}
+
+// extractCommentShapes provides a function to extract the visibility, fill,
+// border, size and position of the comment boxes by given worksheet name and
+// comments.
+func (f *File) extractCommentShapes(sheet string, comments []Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return err
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	shapes, err := f.getVMLShapes(drawingVML)
+	if err != nil {
+		return err
+	}
+	for _, sp := range shapes {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil {
+			return err
+		}
+		if shapeVal.ClientData.ObjectType != "Note" || shapeVal.ClientData.Column == nil || shapeVal.ClientData.Row == nil {
+			continue
+		}
+		col, row := *shapeVal.ClientData.Column+1, *shapeVal.ClientData.Row+1
+		cell, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		for i := range comments {
+			if comments[i].Cell != cell {
+				continue
+			}
+			extractCommentShape(sp, shapeVal, &comments[i])
+			f.extractCommentAnchor(sheet, col, row, shapeVal.ClientData.Anchor, &comments[i])
+		}
+	}
+	return err
+}
+
+// extractCommentShape provides a function to extract the visibility, fill and
+// border of the comment box by given VML shape.
+func extractCommentShape(sp xlsxShape, shapeVal decodeShapeVal, comment *Comment) {
+	comment.Visible = shapeVal.ClientData.Visible != nil
+	if sp.FillColor != "" {
+		comment.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{strings.TrimPrefix(sp.FillColor, "#")}}
+		if shapeVal.Fill != nil && shapeVal.Fill.Type == "gradient" {
+			comment.Fill = Fill{Type: "gradient", Color: []string{
+				strings.TrimPrefix(sp.FillColor, "#"), strings.TrimPrefix(shapeVal.Fill.Color2, "#"),
+			}}
+		}
+	}
+	comment.Line.Color = strings.TrimPrefix(sp.StrokeColor, "#")
+	if width, err := strconv.ParseFloat(strings.TrimSuffix(sp.StrokeWeight, "pt"), 64); err == nil {
+		comment.Line.Width = float64Ptr(width)
+	}
+}
+
+// extractCommentAnchor provides a function to extract the size and the offset
+// from the cell of the comment box by given worksheet name, cell coordinates
+// and VML anchor comma-separated list values. The anchor which can't be parsed
+// will be skipped.
+func (f *File) extractCommentAnchor(sheet string, col, row int, anchor string, comment *Comment) {
+	pos := strings.Split(anchor, ",")
+	if len(pos) != 8 {
+		return
+	}
+	var values [8]int
+	for i, val := range pos {
+		var err error
+		if values[i], err = strconv.Atoi(strings.TrimSpace(val)); err != nil {
+			return
+		}
+	}
+	colStart, x1, rowStart, y1, colEnd, x2, rowEnd, y2 := values[0], values[1], values[2], values[3], values[4], values[5], values[6], values[7]
+	width, height, offsetX, offsetY := x2-x1, y2-y1, x1, y1
+	for c := colStart; c < colEnd; c++ {
+		width += f.getColWidth(sheet, c+1)
+	}
+	for r := rowStart; r < rowEnd; r++ {
+		height += f.getRowHeight(sheet, r+1)
+	}
+	for c := col - 1; c < colStart; c++ {
+		offsetX += f.getColWidth(sheet, c+1)
+	}
+	for r := row - 1; r < rowStart; r++ {
+		offsetY += f.getRowHeight(sheet, r+1)
+	}
+	if width > 0 && height > 0 {
+		comment.Width, comment.Height = uint(width), uint(height)
+	}
+	comment.Format.OffsetX, comment.Format.OffsetY = offsetX, offsetY
+}
+
+// SetCommentText provides the method to update the text of an existing comment
+// in place by given worksheet name, cell reference and rich-text runs, the
+// author and the comment box of the comment will be kept. For example, update
+// the text of the comment in Sheet1!A5:
+//
+//	err := f.SetCommentText("Sheet1", "A5", []excelize.RichTextRun{
+//	    {Text: "Excelize: ", Font: &excelize.Font{Bold: true}},
+//	    {Text: "This is an updated comment."},
+//	})
+func (f *File) SetCommentText(sheet, cell string, paragraph []RichTextRun) error {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ref, _ := CoordinatesToCellName(col, row)
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil {
+		return err
+	}
+	if cmts != nil {
+		for i := range cmts.CommentList.Comment {
+			if cmts.CommentList.Comment[i].Ref != ref {
+				continue
+			}
+			text, err := f.newCommentText(Comment{Paragraph: paragraph})
+			if err != nil {
+				return err
+			}
+			cmts.CommentList.Comment[i].Text = text
+			f.Comments[commentsXML] = cmts
+			return err
+		}
+	}
+	return newNoExistCommentError(cell)
 }
 
 // getSheetComments provides the method to get the target comment reference by
@@ -94,6 +229,16 @@ func (f *File) getSheetComments(sheetFile string) string {
 	return ""
 }
 
+// getSheetCommentsPath provides a function to get the comments part path in
+// the package by given worksheet XML path.
+func (f *File) getSheetCommentsPath(sheetXMLPath string) string {
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	return strings.TrimPrefix(commentsXML, "/")
+}
+
 // AddComment provides the method to add comments in a sheet by giving the
 // worksheet name, cell reference, and format set (such as author and text).
 // Note that the maximum author name length is 255 and the max text length is
@@ -110,6 +255,28 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	    Height: 40,
 //	    Width:  180,
 //	})
+//
+// The comment box is hidden until the mouse hovers over the cell by default,
+// set the Visible field to true to always show it. The Font specifies the
+// default font of the text and the rich-text runs without font setting. The
+// Fill specifies the fill color of the comment box, use one color for solid
+// fill, or two colors with the "gradient" type for gradient fill. The Line
+// specifies the border color and width in points of the comment box. The
+// OffsetX and OffsetY in Format specify the offset in pixels of the comment
+// box from the top-left corner of the cell. For example, add an always visible
+// comment with light blue fill and dark blue border in Sheet1!B2:
+//
+//	lineWidth := 1.5
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:    "B2",
+//	    Author:  "Excelize",
+//	    Text:    "This is a comment.",
+//	    Visible: true,
+//	    Font:    &excelize.Font{Family: "Arial", Size: 10, Color: "1F4E79"},
+//	    Fill:    excelize.Fill{Color: []string{"DDEBF7"}},
+//	    Line:    excelize.ShapeLine{Color: "1F4E79", Width: &lineWidth},
+//	    Format:  excelize.GraphicOptions{OffsetX: 80, OffsetY: 10},
+//	})
 func (f *File) AddComment(sheet string, opts Comment) error {
 	return f.addVMLObject(vmlOptions{
 		sheet: sheet, Comment: opts,
@@ -120,13 +287,15 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 			Paragraph: opts.Paragraph,
 			Width:     opts.Width,
 			Height:    opts.Height,
+			Format:    opts.Format,
 		},
 	})
 }
 
 // DeleteComment provides the method to delete comment in a worksheet by given
-// worksheet name and cell reference. For example, delete the comment in
-// Sheet1!$A$30:
+// worksheet name and cell reference, the threaded comment which using the
+// comment as fallback will be deleted as well. For example, delete the comment
+// in Sheet1!$A$30:
 //
 //	err := f.DeleteComment("Sheet1", "A30")
 func (f *File) DeleteComment(sheet, cell string) error {
@@ -138,21 +307,22 @@ func (f *File) DeleteComment(sheet, cell string) error {
 		return err
 	}
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
-	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
-	if !strings.HasPrefix(commentsXML, "/") {
-		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
-	}
-	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
 	cmts, err := f.commentsReader(commentsXML)
 	if err != nil {
 		return err
 	}
+	var rootIDs []string
 	if cmts != nil {
 		for i := 0; i < len(cmts.CommentList.Comment); i++ {
 			cmt := cmts.CommentList.Comment[i]
 			if cmt.Ref != cell {
 				continue
 			}
+			if cmt.AuthorID >= 0 && cmt.AuthorID < len(cmts.Authors.Author) &&
+				strings.HasPrefix(cmts.Authors.Author[cmt.AuthorID], "tc=") {
+				rootIDs = append(rootIDs, strings.TrimPrefix(cmts.Authors.Author[cmt.AuthorID], "tc="))
+			}
 			if len(cmts.CommentList.Comment) > 1 {
 				cmts.CommentList.Comment = append(
 					cmts.CommentList.Comment[:i],
@@ -165,6 +335,11 @@ func (f *File) DeleteComment(sheet, cell string) error {
 		}
 		f.Comments[commentsXML] = cmts
 	}
+	for _, rootID := range rootIDs {
+		if err = f.deleteThread(sheet, rootID); err != nil {
+			return err
+		}
+	}
 	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 	return f.deleteFormControl(sheetRelationshipsDrawingVML, cell, true)
 }
@@ -176,6 +351,37 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 	if err != nil {
 		return err
 	}
+	vml, err := f.vmlDrawingReader(sheetRelationshipsDrawingVML)
+	if err != nil {
+		return err
+	}
+	cond := func(objectType string) bool {
+		if isComment {
+			return objectType == "Note"
+		}
+		return objectType != "Note"
+	}
+	for i, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
+			cond(shapeVal.ClientData.ObjectType) && shapeVal.ClientData.Anchor != "" {
+			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
+			if err != nil {
+				return err
+			}
+			if leftCol == col-1 && topRow == row-1 {
+				vml.Shape = append(vml.Shape[:i], vml.Shape[i+1:]...)
+				break
+			}
+		}
+	}
+	return err
+}
+
+// vmlDrawingReader provides a function to get the pointer to the structure
+// of the VML drawing by given relationship target of the VML drawing part,
+// the exist shapes in the part will be loaded.
+func (f *File) vmlDrawingReader(sheetRelationshipsDrawingVML string) (*vmlDrawing, error) {
 	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
 	vml := f.VMLDrawing[drawingVML]
@@ -196,7 +402,7 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
 		if err != nil {
-			return err
+			return vml, err
 		}
 		if d != nil {
 			vml.ShapeType.ID = d.ShapeType.ID
@@ -205,45 +411,227 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 			vml.ShapeType.Path = d.ShapeType.Path
 			for _, v := range d.Shape {
 				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
+					ID:           v.ID,
+					Type:         v.Type,
+					Style:        v.Style,
+					Button:       v.Button,
+					Filled:       v.Filled,
+					FillColor:    v.FillColor,
+					InsetMode:    v.InsetMode,
+					Stroked:      v.Stroked,
+					StrokeColor:  v.StrokeColor,
+					StrokeWeight: v.StrokeWeight,
+					Val:          v.Val,
 				}
 				vml.Shape = append(vml.Shape, s)
 			}
 		}
 	}
-	cond := func(objectType string) bool {
-		if isComment {
-			return objectType == "Note"
+	f.VMLDrawing[drawingVML] = vml
+	return vml, nil
+}
+
+// getVMLComments provides a function to get the comments (notes) with their
+// authors and VML shapes inside the range by given worksheet name and range
+// coordinates.
+func (f *File) getVMLComments(sheet string, rect []int) ([]vmlComment, error) {
+	var comments []vmlComment
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return comments, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
+	if err != nil || cmts == nil {
+		return comments, err
+	}
+	threads, persons := map[string][]xlsxThreadedComment{}, map[string]xlsxPerson{}
+	if threadedCommentsXML := f.getSheetThreadedCommentsPath(sheetXMLPath); threadedCommentsXML != "" {
+		tc, err := f.threadedCommentsReader(threadedCommentsXML)
+		if err != nil {
+			return comments, err
+		}
+		personList, err := f.personsReader()
+		if err != nil {
+			return comments, err
+		}
+		for _, person := range personList.Person {
+			persons[person.ID] = person
+		}
+		for _, c := range tc.ThreadedComment {
+			rootID := c.ParentID
+			if rootID == "" {
+				rootID = c.ID
+			}
+			threads[rootID] = append(threads[rootID], c)
 		}
-		return objectType != "Note"
 	}
-	for i, sp := range vml.Shape {
-		var shapeVal decodeShapeVal
-		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
-			cond(shapeVal.ClientData.ObjectType) && shapeVal.ClientData.Anchor != "" {
-			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
-			if err != nil {
-				return err
+	shapes := map[string]xlsxShape{}
+	if ws.LegacyDrawing != nil {
+		vml, err := f.vmlDrawingReader(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+		if err != nil {
+			return comments, err
+		}
+		for _, sp := range vml.Shape {
+			if cell := getVMLNoteCell(sp.Val); cell != "" {
+				shapes[cell] = sp
 			}
-			if leftCol == col-1 && topRow == row-1 {
-				vml.Shape = append(vml.Shape[:i], vml.Shape[i+1:]...)
-				break
+		}
+	}
+	for _, cmt := range cmts.CommentList.Comment {
+		col, row, err := CellNameToCoordinates(cmt.Ref)
+		if err != nil || !cellInRange([]int{col, row}, rect) {
+			continue
+		}
+		comment := vmlComment{cell: cmt.Ref, comment: cmt}
+		if cmt.AuthorID >= 0 && cmt.AuthorID < len(cmts.Authors.Author) {
+			comment.author = cmts.Authors.Author[cmt.AuthorID]
+		}
+		if strings.HasPrefix(comment.author, "tc=") {
+			comment.threads = threads[strings.TrimPrefix(comment.author, "tc=")]
+			comment.persons = getThreadedCommentPersons(comment.threads, persons)
+		}
+		if sp, ok := shapes[cmt.Ref]; ok {
+			comment.shape = &sp
+		}
+		comments = append(comments, comment)
+	}
+	return comments, err
+}
+
+// addVMLComment provides a function to add the comment (note) which got by
+// the getVMLComments function to the cell by given worksheet name and cell
+// reference. The text, author, style, size and visibility of the comment will
+// be kept, and the anchor of the comment box will be moved with the cell. The
+// threaded comments which using the comment as fallback will be added to the
+// cell as well.
+func (f *File) addVMLComment(sheet, cell string, comment vmlComment) error {
+	if err := f.AddComment(sheet, Comment{Cell: cell, Author: comment.author}); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
+	if err != nil {
+		return err
+	}
+	for i := len(cmts.CommentList.Comment) - 1; i >= 0; i-- {
+		if cmt := &cmts.CommentList.Comment[i]; cmt.Ref == cell {
+			if idx := inStrSlice(cmts.Authors.Author, comment.author, true); idx != -1 {
+				cmt.AuthorID = idx
 			}
+			cmt.Text = comment.comment.Text
+			break
+		}
+	}
+	if len(comment.threads) > 0 {
+		if err = f.addThreadedCommentEntries(sheet, cell, comment.threads, comment.persons); err != nil {
+			return err
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || comment.shape == nil {
+		return err
+	}
+	vml, err := f.vmlDrawingReader(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+	if err != nil {
+		return err
+	}
+	srcCol, srcRow, _ := CellNameToCoordinates(comment.cell)
+	col, row, _ := CellNameToCoordinates(cell)
+	for i := len(vml.Shape) - 1; i >= 0; i-- {
+		if getVMLNoteCell(vml.Shape[i].Val) == cell {
+			sp := *comment.shape
+			sp.ID, sp.Val = vml.Shape[i].ID, moveVMLNote(sp.Val, col, row, col-srcCol, row-srcRow)
+			vml.Shape[i] = sp
+			break
 		}
 	}
-	f.VMLDrawing[drawingVML] = vml
 	return err
 }
 
+// getVMLNoteCell returns the cell reference of the comment (note) by given
+// inner XML of the VML shape, returns empty string if the shape is not a
+// comment.
+func getVMLNoteCell(val string) string {
+	var shapeVal decodeShapeVal
+	if err := xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", val)), &shapeVal); err != nil ||
+		shapeVal.ClientData.ObjectType != "Note" || shapeVal.ClientData.Column == nil || shapeVal.ClientData.Row == nil {
+		return ""
+	}
+	cell, _ := CoordinatesToCellName(*shapeVal.ClientData.Column+1, *shapeVal.ClientData.Row+1)
+	return cell
+}
+
+// moveVMLNote returns the inner XML of the VML shape of the comment (note)
+// with the row and column updated by given cell coordinates, and the anchor
+// shifted by given columns and rows distance.
+func moveVMLNote(val string, col, row, dCol, dRow int) string {
+	var shapeVal decodeShapeVal
+	_ = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", val)), &shapeVal)
+	values := map[string]string{"Column": strconv.Itoa(col - 1), "Row": strconv.Itoa(row - 1)}
+	if pos := strings.Split(shapeVal.ClientData.Anchor, ","); len(pos) == 8 {
+		var anchor [8]int
+		for i, v := range pos {
+			var err error
+			if anchor[i], err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return setVMLClientData(val, values)
+			}
+		}
+		anchor[0], anchor[2], anchor[4], anchor[6] = anchor[0]+dCol, anchor[2]+dRow, anchor[4]+dCol, anchor[6]+dRow
+		if anchor[0] < 0 {
+			anchor[0], anchor[4] = 0, anchor[4]-anchor[0]
+		}
+		if anchor[2] < 0 {
+			anchor[2], anchor[6] = 0, anchor[6]-anchor[2]
+		}
+		values["Anchor"] = fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", anchor[0], anchor[1], anchor[2], anchor[3], anchor[4], anchor[5], anchor[6], anchor[7])
+	}
+	return setVMLClientData(val, values)
+}
+
+// setVMLClientData returns the inner XML of the VML shape with the text of
+// the elements in the client data replaced by given element local names and
+// values, the other parts of the inner XML will be kept as is.
+func setVMLClientData(val string, values map[string]string) string {
+	var (
+		buf                 strings.Builder
+		target              string
+		last, start         int64
+		decoder             = xml.NewDecoder(strings.NewReader(val))
+		clientData, nesting int
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			nesting++
+			if t.Name.Local == "ClientData" && clientData == 0 {
+				clientData = nesting
+			}
+			if _, ok := values[t.Name.Local]; ok && clientData > 0 && nesting == clientData+1 &&
+				!strings.HasSuffix(val[:decoder.InputOffset()], "/>") {
+				target, start = t.Name.Local, decoder.InputOffset()
+			}
+		case xml.EndElement:
+			if t.Name.Local == target && nesting == clientData+1 {
+				buf.WriteString(val[last:start])
+				buf.WriteString(values[target])
+				last, target = offset, ""
+			}
+			if nesting == clientData {
+				clientData = 0
+			}
+			nesting--
+		}
+	}
+	buf.WriteString(val[last:])
+	return buf.String()
+}
+
 // addComment provides a function to create chart as xl/comments%d.xml by
 // given cell and format sets.
 func (f *File) addComment(commentsXML string, opts vmlOptions) error {
@@ -265,23 +653,40 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
-	defaultFont, err := f.GetDefaultFont()
+	text, err := f.newCommentText(opts.Comment)
 	if err != nil {
 		return err
 	}
-	chars, cmt := 0, xlsxComment{
+	cmts.CommentList.Comment = append(cmts.CommentList.Comment, xlsxComment{
 		Ref:      opts.Comment.Cell,
 		AuthorID: authorID,
-		Text:     xlsxText{R: []xlsxR{}},
+		Text:     text,
+	})
+	f.Comments[commentsXML] = cmts
+	return err
+}
+
+// newCommentText provides a function to create the rich text of the comment by
+// given comment options, the default font of the comment will be applied on
+// the text and the rich-text runs without font setting.
+func (f *File) newCommentText(opts Comment) (xlsxText, error) {
+	defaultFont, err := f.GetDefaultFont()
+	if err != nil {
+		return xlsxText{}, err
 	}
-	if opts.Comment.Text != "" {
-		if len(opts.Comment.Text) > TotalCellChars {
-			opts.Comment.Text = opts.Comment.Text[:TotalCellChars]
+	chars, text, paragraph := 0, xlsxText{R: []xlsxR{}}, opts.Paragraph
+	if opts.Text != "" {
+		if len(opts.Text) > TotalCellChars {
+			opts.Text = opts.Text[:TotalCellChars]
+		}
+		if opts.Font == nil {
+			text.T = stringPtr(opts.Text)
+			chars += len(opts.Text)
+		} else {
+			paragraph = append([]RichTextRun{{Text: opts.Text}}, paragraph...)
 		}
-		cmt.Text.T = stringPtr(opts.Comment.Text)
-		chars += len(opts.Comment.Text)
 	}
-	for _, run := range opts.Comment.Paragraph {
+	for _, run := range paragraph {
 		if chars == TotalCellChars {
 			break
 		}
@@ -303,14 +708,15 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 				Value: "preserve",
 			}},
 		}
+		if run.Font == nil {
+			run.Font = opts.Font
+		}
 		if run.Font != nil {
 			r.RPr = newRpr(run.Font)
 		}
-		cmt.Text.R = append(cmt.Text.R, r)
+		text.R = append(text.R, r)
 	}
-	cmts.CommentList.Comment = append(cmts.CommentList.Comment, cmt)
-	f.Comments[commentsXML] = cmts
-	return err
+	return text, err
 }
 
 // countComments provides a function to get comments files count storage in
@@ -360,9 +766,10 @@ func (f *File) commentsWriter() {
 
 // AddFormControl provides the method to add form control button in a worksheet
 // by given worksheet name and form control options. Supported form control
-// type: button, check box, group box, label, option button, scroll bar and
-// spinner. If set macro for the form control, the workbook extension should be
-// XLSM or XLTM. Scroll value must be between 0 and 30000.
+// type: button, check box, combo box, group box, label, list box, option
+// button, scroll bar and spinner. If set macro for the form control, the
+// workbook extension should be XLSM or XLTM. Scroll value must be between 0
+// and 30000.
 //
 // Example 1, add button form control with macro, rich-text, custom button size,
 // print property on Sheet1!A2, and let the button do not move or size with
@@ -436,6 +843,36 @@ func (f *File) commentsWriter() {
 //	    CellLink:     "A1",
 //	    Horizontally: true,
 //	})
+//
+// Example 5, add combo box form control on Sheet1!C1 to select an item from
+// the items in Sheet1!A1:A5, with 5 lines in the drop-down list, and write the
+// index of the selected item into Sheet1!B1:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:       "C1",
+//	    Type:       excelize.FormControlComboBox,
+//	    Width:      140,
+//	    Height:     20,
+//	    InputRange: "$A$1:$A$5",
+//	    CellLink:   "B1",
+//	    DropLines:  5,
+//	})
+//
+// Example 6, add list box form control with multiple selection on Sheet1!C3
+// to select items from the items in Sheet1!A1:A5:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:          "C3",
+//	    Type:          excelize.FormControlListBox,
+//	    InputRange:    "$A$1:$A$5",
+//	    SelectionType: "multi",
+//	})
+//
+// The InputRange specifies the reference of the items in the combo box or list
+// box. The CurrentVal specifies the index of the selected item, starting from
+// 1. The DropLines specifies the number of lines in the drop-down list of the
+// combo box, default 8. The SelectionType specifies the selection type of the
+// list box, the optional values are "single" (default), "multi" and "extend".
 func (f *File) AddFormControl(sheet string, opts FormControl) error {
 	return f.addVMLObject(vmlOptions{
 		formCtrl: true, sheet: sheet, FormControl: opts,
@@ -493,6 +930,35 @@ func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
 	return f.DecodeVMLDrawing[path], nil
 }
 
+// getVMLShapes provides a function to get the shapes in the VML drawing part
+// by given VML drawing path.
+func (f *File) getVMLShapes(drawingVML string) ([]xlsxShape, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml.Shape, nil
+	}
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	shapes := make([]xlsxShape, 0, len(d.Shape))
+	for _, v := range d.Shape {
+		shapes = append(shapes, xlsxShape{
+			ID:           v.ID,
+			Type:         v.Type,
+			Style:        v.Style,
+			Button:       v.Button,
+			Filled:       v.Filled,
+			FillColor:    v.FillColor,
+			InsetMode:    v.InsetMode,
+			Stroked:      v.Stroked,
+			StrokeColor:  v.StrokeColor,
+			StrokeWeight: v.StrokeWeight,
+			Val:          v.Val,
+		})
+	}
+	return shapes, err
+}
+
 // vmlDrawingWriter provides a function to save xl/drawings/vmlDrawing%d.xml
 // after serialize structure.
 func (f *File) vmlDrawingWriter() {
@@ -514,7 +980,7 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 	}
 	vmlID := f.countComments() + 1
 	if opts.formCtrl {
-		if opts.Type > FormControlScrollBar {
+		if opts.Type > FormControlListBox {
 			return ErrParameterInvalid
 		}
 		vmlID = f.countVMLDrawing() + 1
@@ -553,11 +1019,11 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 // prepareFormCtrlOptions provides a function to parse the format settings of
 // the form control with default value.
 func prepareFormCtrlOptions(opts *vmlOptions) *vmlOptions {
-	if opts.Format.ScaleX == 0 {
-		opts.Format.ScaleX = 1
+	if opts.FormControl.Format.ScaleX == 0 {
+		opts.FormControl.Format.ScaleX = 1
 	}
-	if opts.Format.ScaleY == 0 {
-		opts.Format.ScaleY = 1
+	if opts.FormControl.Format.ScaleY == 0 {
+		opts.FormControl.Format.ScaleY = 1
 	}
 	if opts.FormControl.Width == 0 {
 		opts.FormControl.Width = 140
@@ -721,6 +1187,36 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 		firstButton:  nil,
 		shadow:       nil,
 	},
+	FormControlComboBox: {
+		objectType:   "Drop",
+		autoFill:     "False",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
+	FormControlListBox: {
+		objectType:   "List",
+		autoFill:     "False",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
 	FormControlSpinButton: {
 		objectType:   "Spin",
 		autoFill:     "False",
@@ -738,8 +1234,12 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 	},
 }
 
-// addFormCtrl check and add scroll bar or spinner form control by given options.
+// addFormCtrl check and add scroll bar, spinner, combo box or list box form
+// control by given options.
 func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
+	if opts.Type == FormControlComboBox || opts.Type == FormControlListBox {
+		return sp.addListFormCtrl(opts)
+	}
 	if opts.Type != FormControlScrollBar && opts.Type != FormControlSpinButton {
 		return nil
 	}
@@ -770,6 +1270,39 @@ func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
 	return nil
 }
 
+// addListFormCtrl check and add combo box or list box form control by given
+// options.
+func (sp *encodeShape) addListFormCtrl(opts *vmlOptions) error {
+	if opts.CurrentVal > MaxFormControlValue || opts.DropLines > MaxFormControlValue {
+		return ErrFormControlValue
+	}
+	if opts.CellLink != "" {
+		if _, _, err := CellNameToCoordinates(opts.CellLink); err != nil {
+			return err
+		}
+	}
+	sp.ClientData.FmlaLink = opts.CellLink
+	sp.ClientData.FmlaRange = opts.InputRange
+	sp.ClientData.Sel = opts.CurrentVal
+	sp.ClientData.NoThreeD2 = stringPtr("")
+	if opts.Type == FormControlComboBox {
+		sp.ClientData.DropStyle, sp.ClientData.DropLines = "Combo", opts.DropLines
+		if sp.ClientData.DropLines == 0 {
+			sp.ClientData.DropLines = 8
+		}
+		return nil
+	}
+	idx := inStrSlice(supportedFormCtrlSelectionTypes, opts.SelectionType, true)
+	if opts.SelectionType == "" {
+		idx = 0
+	}
+	if idx == -1 {
+		return ErrParameterInvalid
+	}
+	sp.ClientData.SelType = []string{"Single", "Multi", "Extend"}[idx]
+	return nil
+}
+
 // addFormCtrlShape returns a VML shape by given preset and options.
 func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor string, opts *vmlOptions) (*encodeShape, error) {
 	sp := encodeShape{
@@ -792,11 +1325,11 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 			FirstButton: preset.firstButton,
 		},
 	}
-	if opts.Format.PrintObject != nil && !*opts.Format.PrintObject {
+	if opts.FormControl.Format.PrintObject != nil && !*opts.FormControl.Format.PrintObject {
 		sp.ClientData.PrintObject = "False"
 	}
-	if opts.Format.Positioning != "" {
-		idx := inStrSlice(supportedPositioning, opts.Format.Positioning, true)
+	if opts.FormControl.Format.Positioning != "" {
+		idx := inStrSlice(supportedPositioning, opts.FormControl.Format.Positioning, true)
 		if idx == -1 {
 			return &sp, ErrParameterInvalid
 		}
@@ -806,6 +1339,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Comment.Visible {
+			sp.ClientData.Visible = stringPtr("")
+		}
 	}
 	if !opts.formCtrl {
 		return &sp, nil
@@ -818,6 +1354,27 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	return &sp, sp.addFormCtrl(opts)
 }
 
+// prepareCommentPreset provides a function to override the fill and border
+// settings of the comment box preset by given comment options.
+func prepareCommentPreset(preset formCtrlPreset, opts *Comment) formCtrlPreset {
+	if len(opts.Fill.Color) > 0 {
+		color := "#" + strings.ToUpper(strings.TrimPrefix(opts.Fill.Color[0], "#"))
+		preset.fillColor, preset.fill = color, &vFill{Color2: color}
+		if opts.Fill.Type == "gradient" && len(opts.Fill.Color) > 1 {
+			fill := *formCtrlPresets[FormControlNote].fill
+			fill.Color2 = "#" + strings.ToUpper(strings.TrimPrefix(opts.Fill.Color[1], "#"))
+			preset.fill = &fill
+		}
+	}
+	if opts.Line.Color != "" {
+		preset.strokeColor = "#" + strings.ToUpper(strings.TrimPrefix(opts.Line.Color, "#"))
+	}
+	if opts.Line.Width != nil {
+		preset.strokeWeight = strconv.FormatFloat(*opts.Line.Width, 'f', -1, 64) + "pt"
+	}
+	return preset
+}
+
 // addDrawingVML provides a function to create VML drawing XML as
 // xl/drawings/vmlDrawing%d.vml by given data ID, XML path and VML options. The
 // anchor value is a comma-separated list of data written out as: LeftColumn,
@@ -828,14 +1385,24 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	if err != nil {
 		return err
 	}
-	leftOffset, vmlID, vml, preset := 23, 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
+	vmlID, vml, preset := 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
+	offsetX, offsetY := opts.FormControl.Format.OffsetX, opts.FormControl.Format.OffsetY
 	style := "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
 	if opts.formCtrl {
-		leftOffset, vmlID = 0, 201
+		vmlID = 201
 		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
+	} else {
+		if offsetX == 0 && offsetY == 0 {
+			offsetX = 23
+		}
+		if opts.Comment.Visible {
+			style = strings.ReplaceAll(style, "visibility:hidden", "visibility:visible")
+		}
+		preset = prepareCommentPreset(preset, &opts.Comment)
 	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
-	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
+	_, _, _, _, x1, y1 := f.positionObjectPixels(opts.sheet, col, row, offsetX, offsetY, 0, 0)
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, offsetX, offsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
+	anchor := fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, x1, rowStart, y1, colEnd, x2, rowEnd, y2)
 	if vml == nil {
 		vml = &vmlDrawing{
 			XMLNSv:  "urn:schemas-microsoft-com:vml",
@@ -866,16 +1433,17 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 			vml.ShapeType.Path = d.ShapeType.Path
 			for _, v := range d.Shape {
 				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
+					ID:           v.ID,
+					Type:         v.Type,
+					Style:        v.Style,
+					Button:       v.Button,
+					Filled:       v.Filled,
+					FillColor:    v.FillColor,
+					InsetMode:    v.InsetMode,
+					Stroked:      v.Stroked,
+					StrokeColor:  v.StrokeColor,
+					StrokeWeight: v.StrokeWeight,
+					Val:          v.Val,
 				}
 				vml.Shape = append(vml.Shape, s)
 			}
@@ -887,15 +1455,16 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
-		ID:          "_x0000_s1025",
-		Type:        fmt.Sprintf("#_x0000_t%d", vmlID),
-		Style:       style,
-		Button:      preset.strokeButton,
-		Filled:      preset.filled,
-		FillColor:   preset.fillColor,
-		Stroked:     preset.stroked,
-		StrokeColor: preset.strokeColor,
-		Val:         string(s[13 : len(s)-14]),
+		ID:           "_x0000_s1025",
+		Type:         fmt.Sprintf("#_x0000_t%d", vmlID),
+		Style:        style,
+		Button:       preset.strokeButton,
+		Filled:       preset.filled,
+		FillColor:    preset.fillColor,
+		Stroked:      preset.stroked,
+		StrokeColor:  preset.strokeColor,
+		StrokeWeight: preset.strokeWeight,
+		Val:          string(s[13 : len(s)-14]),
 	}
 	vml.Shape = append(vml.Shape, shape)
 	f.VMLDrawing[drawingVML] = vml
@@ -990,6 +1559,14 @@ func extractFormControl(clientData string) (FormControl, error) {
 			formControl.IncChange = shapeVal.ClientData.Inc
 			formControl.PageChange = shapeVal.ClientData.Page
 			formControl.Horizontally = shapeVal.ClientData.Horiz != nil
+			if formCtrlType == FormControlComboBox || formCtrlType == FormControlListBox {
+				formControl.CurrentVal = shapeVal.ClientData.Sel
+				formControl.InputRange = shapeVal.ClientData.FmlaRange
+				formControl.DropLines = shapeVal.ClientData.DropLines
+				if idx := inStrSlice([]string{"Single", "Multi", "Extend"}, shapeVal.ClientData.SelType, false); idx != -1 {
+					formControl.SelectionType = supportedFormCtrlSelectionTypes[idx]
+				}
+			}
 		}
 	}
 	return formControl, err
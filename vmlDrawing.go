@@ -42,17 +42,18 @@ type xlsxIDmap struct {
 
 // xlsxShape directly maps the shape element.
 type xlsxShape struct {
-	XMLName     xml.Name `xml:"v:shape"`
-	ID          string   `xml:"id,attr"`
-	Type        string   `xml:"type,attr"`
-	Style       string   `xml:"style,attr"`
-	Button      string   `xml:"o:button,attr,omitempty"`
-	Filled      string   `xml:"filled,attr,omitempty"`
-	FillColor   string   `xml:"fillcolor,attr,omitempty"`
-	InsetMode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
-	Stroked     string   `xml:"stroked,attr,omitempty"`
-	StrokeColor string   `xml:"strokecolor,attr,omitempty"`
-	Val         string   `xml:",innerxml"`
+	XMLName      xml.Name `xml:"v:shape"`
+	ID           string   `xml:"id,attr"`
+	Type         string   `xml:"type,attr"`
+	Style        string   `xml:"style,attr"`
+	Button       string   `xml:"o:button,attr,omitempty"`
+	Filled       string   `xml:"filled,attr,omitempty"`
+	FillColor    string   `xml:"fillcolor,attr,omitempty"`
+	InsetMode    string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
+	Stroked      string   `xml:"stroked,attr,omitempty"`
+	StrokeColor  string   `xml:"strokecolor,attr,omitempty"`
+	StrokeWeight string   `xml:"strokeweight,attr,omitempty"`
+	Val          string   `xml:",innerxml"`
 }
 
 // xlsxShapeType directly maps the shapetype element.
@@ -139,6 +140,7 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
@@ -150,6 +152,12 @@ type xClientData struct {
 	Page          uint    `xml:"x:Page,omitempty"`
 	Horiz         *string `xml:"x:Horiz"`
 	Dx            uint    `xml:"x:Dx,omitempty"`
+	FmlaRange     string  `xml:"x:FmlaRange,omitempty"`
+	Sel           uint    `xml:"x:Sel,omitempty"`
+	SelType       string  `xml:"x:SelType,omitempty"`
+	DropStyle     string  `xml:"x:DropStyle,omitempty"`
+	DropLines     uint    `xml:"x:DropLines,omitempty"`
+	NoThreeD2     *string `xml:"x:NoThreeD2"`
 }
 
 // decodeVmlDrawing defines the structure used to parse the file
@@ -170,25 +178,34 @@ type decodeShapeType struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	ID          string `xml:"id,attr"`
-	Type        string `xml:"type,attr"`
-	Style       string `xml:"style,attr"`
-	Button      string `xml:"button,attr,omitempty"`
-	Filled      string `xml:"filled,attr,omitempty"`
-	FillColor   string `xml:"fillcolor,attr,omitempty"`
-	InsetMode   string `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
-	Stroked     string `xml:"stroked,attr,omitempty"`
-	StrokeColor string `xml:"strokecolor,attr,omitempty"`
-	Val         string `xml:",innerxml"`
+	ID           string `xml:"id,attr"`
+	Type         string `xml:"type,attr"`
+	Style        string `xml:"style,attr"`
+	Button       string `xml:"button,attr,omitempty"`
+	Filled       string `xml:"filled,attr,omitempty"`
+	FillColor    string `xml:"fillcolor,attr,omitempty"`
+	InsetMode    string `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
+	Stroked      string `xml:"stroked,attr,omitempty"`
+	StrokeColor  string `xml:"strokecolor,attr,omitempty"`
+	StrokeWeight string `xml:"strokeweight,attr,omitempty"`
+	Val          string `xml:",innerxml"`
 }
 
 // decodeShapeVal defines the structure used to parse the sub-element of the
 // shape in the file xl/drawings/vmlDrawing%d.vml.
 type decodeShapeVal struct {
+	Fill       *decodeVMLFill      `xml:"fill"`
 	TextBox    decodeVMLTextBox    `xml:"textbox"`
 	ClientData decodeVMLClientData `xml:"ClientData"`
 }
 
+// decodeVMLFill defines the structure used to parse the v:fill element in the
+// file xl/drawings/vmlDrawing%d.vml.
+type decodeVMLFill struct {
+	Color2 string `xml:"color2,attr"`
+	Type   string `xml:"type,attr"`
+}
+
 // decodeVMLFontU defines the structure used to parse the u element in the VML.
 type decodeVMLFontU struct {
 	Class string `xml:"class,attr"`
@@ -238,6 +255,7 @@ type decodeVMLClientData struct {
 	FmlaMacro  string
 	Column     *int
 	Row        *int
+	Visible    *string
 	Checked    int
 	FmlaLink   string
 	Val        uint
@@ -246,6 +264,10 @@ type decodeVMLClientData struct {
 	Inc        uint
 	Page       uint
 	Horiz      *string
+	FmlaRange  string
+	Sel        uint
+	SelType    string
+	DropLines  uint
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
@@ -269,11 +291,24 @@ type formCtrlPreset struct {
 	shadow       *vShadow
 	strokeButton string
 	strokeColor  string
+	strokeWeight string
 	stroked      string
 	textHAlign   string
 	textVAlign   string
 }
 
+// vmlComment defines the structure used to internal comment (note) with its
+// author, VML shape and the threaded comments which using the comment as
+// fallback with the persons referenced by them.
+type vmlComment struct {
+	cell    string
+	author  string
+	comment xlsxComment
+	shape   *xlsxShape
+	threads []xlsxThreadedComment
+	persons []xlsxPerson
+}
+
 // vmlOptions defines the structure used to internal comments and form controls.
 type vmlOptions struct {
 	formCtrl bool
@@ -284,20 +319,23 @@ type vmlOptions struct {
 
 // FormControl directly maps the form controls information.
 type FormControl struct {
-	Cell         string
-	Macro        string
-	Width        uint
-	Height       uint
-	Checked      bool
-	CurrentVal   uint
-	MinVal       uint
-	MaxVal       uint
-	IncChange    uint
-	PageChange   uint
-	Horizontally bool
-	CellLink     string
-	Text         string
-	Paragraph    []RichTextRun
-	Type         FormControlType
-	Format       GraphicOptions
+	Cell          string
+	Macro         string
+	Width         uint
+	Height        uint
+	Checked       bool
+	CurrentVal    uint
+	MinVal        uint
+	MaxVal        uint
+	IncChange     uint
+	PageChange    uint
+	Horizontally  bool
+	CellLink      string
+	InputRange    string
+	DropLines     uint
+	SelectionType string
+	Text          string
+	Paragraph     []RichTextRun
+	Type          FormControlType
+	Format        GraphicOptions
 }
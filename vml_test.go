@@ -81,6 +81,122 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestAddCommentFormat(t *testing.T) {
+	f := NewFile()
+	lineWidth := 1.5
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell:    "B2",
+		Author:  "Excelize",
+		Text:    "This is a comment.",
+		Visible: true,
+		Font:    &Font{Family: "Arial", Size: 10, Color: "1F4E79"},
+		Fill:    Fill{Color: []string{"#ddebf7"}},
+		Line:    ShapeLine{Color: "1F4E79", Width: &lineWidth},
+		Format:  GraphicOptions{OffsetX: 80, OffsetY: 10},
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell: "D4", Text: "Gradient", Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "DDEBF7"}},
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "F6", Text: "Default", Width: 200, Height: 100}))
+	check := func(f *File) {
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, comments, 3)
+		assert.True(t, comments[0].Visible)
+		assert.Empty(t, comments[0].Text)
+		assert.Equal(t, []RichTextRun{{Text: "This is a comment.", Font: &Font{Underline: "none", Family: "Arial", Size: 10, Color: "1F4E79"}}}, comments[0].Paragraph)
+		assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"DDEBF7"}}, comments[0].Fill)
+		assert.Equal(t, ShapeLine{Color: "1F4E79", Width: &lineWidth}, comments[0].Line)
+		assert.Equal(t, 80, comments[0].Format.OffsetX)
+		assert.Equal(t, 10, comments[0].Format.OffsetY)
+		assert.Equal(t, uint(140), comments[0].Width)
+		assert.Equal(t, uint(60), comments[0].Height)
+
+		assert.False(t, comments[1].Visible)
+		assert.Equal(t, Fill{Type: "gradient", Color: []string{"FFFFFF", "DDEBF7"}}, comments[1].Fill)
+
+		assert.Equal(t, "Default", comments[2].Text)
+		assert.Equal(t, Fill{Type: "gradient", Color: []string{"FBF6D6", "FBFE82"}}, comments[2].Fill)
+		assert.Equal(t, ShapeLine{Color: "EDEAA1"}, comments[2].Line)
+		assert.Equal(t, 23, comments[2].Format.OffsetX)
+		assert.Equal(t, 0, comments[2].Format.OffsetY)
+		assert.Equal(t, uint(200), comments[2].Width)
+		assert.Equal(t, uint(100), comments[2].Height)
+	}
+	check(f)
+	path := filepath.Join("test", "TestAddCommentFormat.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+
+	// Test get comments with invalid VML anchor, the size and offset of the
+	// comment box will not be extracted
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	val := vml.Shape[0].Val
+	for _, anchor := range []string{"0, 23, 0, 0", "0, x, 0, 0, 2, 35, 3, 6"} {
+		vml.Shape[0].Val = strings.Replace(val, "0, 23, 0, 0, 2, 35, 3, 6", anchor, 1)
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, comments, 1)
+		assert.Equal(t, "Comment", comments[0].Text)
+		assert.Zero(t, comments[0].Width)
+		assert.Zero(t, comments[0].Height)
+		assert.Zero(t, comments[0].Format.OffsetX)
+	}
+	// Test get comments with invalid VML shape
+	vml.Shape[0].Val = "<x:ClientData>"
+	_, err = f.GetComments("Sheet1")
+	assert.Error(t, err)
+	// Test get comments with invalid cell coordinates in VML shape
+	vml.Shape[0].Val = "<x:ClientData ObjectType=\"Note\"><x:Row>0</x:Row><x:Column>-1</x:Column></x:ClientData>"
+	_, err = f.GetComments("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 1), err)
+	// Test get comments with unsupported charset VML drawing
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetCommentText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment", Visible: true}))
+	assert.NoError(t, f.SetCommentText("Sheet1", "a1", []RichTextRun{
+		{Text: "Excelize: ", Font: &Font{Bold: true}},
+		{Text: "This is an updated comment."},
+	}))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.True(t, comments[0].Visible)
+	assert.Empty(t, comments[0].Text)
+	assert.Len(t, comments[0].Paragraph, 2)
+	assert.Equal(t, "This is an updated comment.", comments[0].Paragraph[1].Text)
+
+	// Test set comment text on not exists worksheet
+	assert.EqualError(t, f.SetCommentText("SheetN", "A1", nil), "sheet SheetN does not exist")
+	// Test set comment text with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCommentText("Sheet1", "A", nil))
+	// Test set comment text on the cell without comment
+	assert.Equal(t, newNoExistCommentError("B1"), f.SetCommentText("Sheet1", "B1", nil))
+	assert.Equal(t, newNoExistCommentError("A1"), NewFile().SetCommentText("Sheet1", "A1", nil))
+	// Test set comment text with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCommentText("Sheet1", "A1", nil), "XML syntax error on line 1: invalid UTF-8")
+	// Test set comment text with unsupported charset comments
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCommentText("Sheet1", "A1", nil), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -407,8 +523,121 @@ func TestFormControl(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestListFormControl(t *testing.T) {
+	f := NewFile()
+	for r, item := range []string{"Apple", "Banana", "Cherry"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", r+1), item))
+	}
+	formControls := []FormControl{
+		{
+			Cell: "C1", Type: FormControlComboBox, Width: 140, Height: 20,
+			InputRange: "$A$1:$A$3", CellLink: "B1", CurrentVal: 2, DropLines: 5,
+		},
+		{Cell: "C3", Type: FormControlComboBox, InputRange: "$A$1:$A$3"},
+		{Cell: "E1", Type: FormControlListBox, InputRange: "$A$1:$A$3", CellLink: "B2", CurrentVal: 1},
+		{Cell: "E5", Type: FormControlListBox, InputRange: "$A$1:$A$3", SelectionType: "multi"},
+		{Cell: "E9", Type: FormControlListBox, InputRange: "$A$1:$A$3", SelectionType: "extend"},
+	}
+	for _, formCtrl := range formControls {
+		assert.NoError(t, f.AddFormControl("Sheet1", formCtrl))
+	}
+	expected := []FormControl{
+		{Cell: "C1", Type: FormControlComboBox, InputRange: "$A$1:$A$3", CellLink: "B1", CurrentVal: 2, DropLines: 5},
+		{Cell: "C3", Type: FormControlComboBox, InputRange: "$A$1:$A$3", DropLines: 8},
+		{Cell: "E1", Type: FormControlListBox, InputRange: "$A$1:$A$3", CellLink: "B2", CurrentVal: 1, SelectionType: "single"},
+		{Cell: "E5", Type: FormControlListBox, InputRange: "$A$1:$A$3", SelectionType: "multi"},
+		{Cell: "E9", Type: FormControlListBox, InputRange: "$A$1:$A$3", SelectionType: "extend"},
+	}
+	check := func(f *File) {
+		result, err := f.GetFormControls("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+	check(f)
+	path := filepath.Join("test", "TestListFormControl.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	check(f)
+	// Test delete combo box form control
+	assert.NoError(t, f.DeleteFormControl("Sheet1", "C1"))
+	result, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	// Test add list box form control with invalid selection type
+	assert.Equal(t, ErrParameterInvalid, f.AddFormControl("Sheet1", FormControl{
+		Cell: "G1", Type: FormControlListBox, SelectionType: "x",
+	}))
+	// Test add combo box form control with illegal cell link reference
+	assert.Equal(t, newCellNameToCoordinatesError("*", newInvalidCellNameError("*")), f.AddFormControl("Sheet1", FormControl{
+		Cell: "G1", Type: FormControlComboBox, CellLink: "*",
+	}))
+	// Test add combo box form control with invalid value
+	assert.Equal(t, ErrFormControlValue, f.AddFormControl("Sheet1", FormControl{
+		Cell: "G1", Type: FormControlComboBox, DropLines: MaxFormControlValue + 1,
+	}))
+	assert.NoError(t, f.Close())
+}
+
 func TestExtractFormControl(t *testing.T) {
 	// Test extract form control with unsupported charset
 	_, err := extractFormControl(string(MacintoshCyrillicCharset))
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestMoveVMLNote(t *testing.T) {
+	// Test move the comment shape with prefixed namespace, the other elements
+	// and the elements out of the client data should be kept
+	val := `<v:textbox><div><Row>9</Row></div></v:textbox><xx:ClientData ObjectType="Note"><xx:MoveWithCells/><xx:Anchor>1, 23, 0, 2, 3, 15, 4, 16</xx:Anchor><xx:Row>0</xx:Row><xx:Column>0</xx:Column></xx:ClientData>`
+	assert.Equal(t, `<v:textbox><div><Row>9</Row></div></v:textbox><xx:ClientData ObjectType="Note"><xx:MoveWithCells/><xx:Anchor>3, 23, 3, 2, 5, 15, 7, 16</xx:Anchor><xx:Row>3</xx:Row><xx:Column>2</xx:Column></xx:ClientData>`,
+		moveVMLNote(val, 3, 4, 2, 3))
+	assert.Equal(t, "C4", getVMLNoteCell(moveVMLNote(val, 3, 4, 2, 3)))
+	// Test move the comment shape to the anchor out of the worksheet
+	assert.Contains(t, moveVMLNote(val, 1, 1, -2, -1), "<xx:Anchor>0, 23, 0, 2, 2, 15, 4, 16</xx:Anchor>")
+	// Test move the comment shape with invalid anchor
+	val = `<x:ClientData ObjectType="Note"><x:Anchor>A, 0, 0, 0, 0, 0, 0, 0</x:Anchor><x:Row/><x:Column>0</x:Column></x:ClientData>`
+	assert.Equal(t, `<x:ClientData ObjectType="Note"><x:Anchor>A, 0, 0, 0, 0, 0, 0, 0</x:Anchor><x:Row/><x:Column>1</x:Column></x:ClientData>`,
+		moveVMLNote(val, 2, 2, 1, 1))
+	// Test get comment cell of the shape which is not a comment
+	assert.Empty(t, getVMLNoteCell(`<x:ClientData ObjectType="Button"><x:Row>0</x:Row><x:Column>0</x:Column></x:ClientData>`))
+}
+
+func TestGetVMLComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	// Test get comments with unsupported charset VML drawing
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err := f.getVMLComments("Sheet1", []int{1, 1, 1, 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with unsupported charset comments
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	_, err = f.getVMLComments("Sheet1", []int{1, 1, 1, 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with invalid sheet name
+	_, err = f.getVMLComments("Sheet:1", []int{1, 1, 1, 1})
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test add comment with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.addVMLComment("Sheet:1", "A1", vmlComment{}))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Comment"}))
+	comments, err := f.getVMLComments("Sheet1", []int{1, 1, 1, 1})
+	assert.NoError(t, err)
+	// Test add threaded comments with unsupported charset persons
+	f.Pkg.Store(defaultXMLPersonPart, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addVMLComment("Sheet1", "B1", comments[0]), "XML syntax error on line 1: invalid UTF-8")
+	// Test get threaded comments with unsupported charset persons
+	_, err = f.getVMLComments("Sheet1", []int{1, 1, 1, 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get threaded comments with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.getVMLComments("Sheet1", []int{1, 1, 1, 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comments with unsupported charset threaded comments
+	assert.EqualError(t, f.addVMLComment("Sheet1", "C1", comments[0]), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
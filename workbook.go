@@ -310,28 +310,50 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
-		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"chart":                "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":           "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":             "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":             "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"featurePropertyBag":   "/" + defaultXMLFeaturePropertyBag,
+		"metadata":             "/" + defaultXMLMetadata,
+		"person":               "/" + defaultXMLPersonPart,
+		"rdRichValue":          "/" + defaultXMLRdRichValuePart,
+		"rdRichValueStructure": "/" + defaultXMLRdRichValueStructurePart,
+		"rdRichValueTypes":     "/" + defaultXMLRdRichValueTypesPart,
+		"rdRichValueWebImage":  "/" + defaultXMLRdRichValueWebImagePart,
+		"richValueRel":         "/" + defaultXMLRdRichValueRel,
+		"table":                "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":           "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":           "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":        "/xl/sharedStrings.xml",
+		"slicer":               "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":          "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"threadedComment":      "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
+		"timeline":             "/xl/timelines/timeline" + strconv.Itoa(index) + ".xml",
+		"timelineCache":        "/xl/timelineCaches/timelineCache" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":        ContentTypeSlicer,
-		"slicerCache":   ContentTypeSlicerCache,
+		"chart":                ContentTypeDrawingML,
+		"chartsheet":           ContentTypeSpreadSheetMLChartsheet,
+		"comments":             ContentTypeSpreadSheetMLComments,
+		"drawings":             ContentTypeDrawing,
+		"featurePropertyBag":   ContentTypeFeaturePropertyBag,
+		"metadata":             ContentTypeSheetMetadata,
+		"person":               ContentTypePerson,
+		"rdRichValue":          ContentTypeRdRichValue,
+		"rdRichValueStructure": ContentTypeRdRichValueStructure,
+		"rdRichValueTypes":     ContentTypeRdRichValueTypes,
+		"rdRichValueWebImage":  ContentTypeRdRichValueWebImage,
+		"richValueRel":         ContentTypeRichValueRel,
+		"table":                ContentTypeSpreadSheetMLTable,
+		"pivotTable":           ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":           ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"sharedStrings":        ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":               ContentTypeSlicer,
+		"slicerCache":          ContentTypeSlicerCache,
+		"threadedComment":      ContentTypeSpreadSheetMLThreadedComments,
+		"timeline":             ContentTypeTimeline,
+		"timelineCache":        ContentTypeTimelineCache,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -72,6 +75,68 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element from the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root of the threaded comments part of a worksheet, which
+// contains a list of threaded comments and replies.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"ThreadedComments"`
+	XMLNS           string                `xml:"xmlns,attr"`
+	XMLNSX          string                `xml:"xmlns:x,attr,omitempty"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single threaded comment or reply, the reply references to the
+// first comment of the thread by parent ID.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     *bool         `xml:"done,attr"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxMentions `xml:"mentions"`
+	ExtLst   *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxMentions directly maps the mentions element. This element contains a
+// list of persons mentioned in the text of a threaded comment.
+type xlsxMentions struct {
+	Mention []xlsxMention `xml:"mention"`
+}
+
+// xlsxMention directly maps the mention element. This element specifies the
+// person and the position of the mention in the text of a threaded comment.
+type xlsxMention struct {
+	MentionPersonID string `xml:"mentionpersonId,attr"`
+	MentionID       string `xml:"mentionId,attr"`
+	StartIndex      int    `xml:"startIndex,attr"`
+	Length          int    `xml:"length,attr"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root of the persons part of the workbook, which contains a list of persons
+// referenced by threaded comments.
+type xlsxPersonList struct {
+	XMLName xml.Name      `xml:"personList"`
+	XMLNS   string        `xml:"xmlns,attr"`
+	XMLNSX  string        `xml:"xmlns:x,attr,omitempty"`
+	Person  []xlsxPerson  `xml:"person"`
+	ExtLst  *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of threaded comments or a person mentioned in threaded comments.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
 // Comment directly maps the comment information.
 type Comment struct {
 	Author    string
@@ -81,4 +146,23 @@ type Comment struct {
 	Width     uint
 	Height    uint
 	Paragraph []RichTextRun
+	Visible   bool
+	Font      *Font
+	Fill      Fill
+	Line      ShapeLine
+	Format    GraphicOptions
+}
+
+// ThreadedComment directly maps the threaded comment information. The Mentions
+// specifies the display names of the persons mentioned in the text, each of
+// them should be included in the text with an "@" prefix.
+type ThreadedComment struct {
+	ID       string
+	Cell     string
+	Author   string
+	Text     string
+	Mentions []string
+	Time     time.Time
+	Done     bool
+	Replies  []ThreadedComment
 }
@@ -22,12 +22,86 @@ type decodeCellAnchor struct {
 	From             *decodeFrom             `xml:"from"`
 	To               *decodeTo               `xml:"to"`
 	Sp               *decodeSp               `xml:"sp"`
+	GrpSp            *decodeGrpSp            `xml:"grpSp"`
 	Pic              *decodePic              `xml:"pic"`
 	ClientData       *decodeClientData       `xml:"clientData"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
 	Content          string                  `xml:",innerxml"`
 }
 
+// decodeTextShapeAnchor defines the structure used to deserialize the inner
+// content of the cell anchor of the shape on replacing the text body of the
+// shape, the elements except the text body are kept with their attributes and
+// inner XML content.
+type decodeTextShapeAnchor struct {
+	Pos        *xlsxInnerXMLAttrs `xml:"pos"`
+	From       *xlsxInnerXMLAttrs `xml:"from"`
+	To         *xlsxInnerXMLAttrs `xml:"to"`
+	Ext        *xlsxInnerXMLAttrs `xml:"ext"`
+	Sp         *decodeTextSp      `xml:"sp"`
+	ClientData *xlsxInnerXMLAttrs `xml:"clientData"`
+}
+
+// decodeTextSp defines the structure used to deserialize the sp element on
+// replacing the text body of the shape.
+type decodeTextSp struct {
+	Attrs  []xml.Attr         `xml:",any,attr"`
+	NvSpPr *xlsxInnerXMLAttrs `xml:"nvSpPr"`
+	SpPr   *xlsxInnerXMLAttrs `xml:"spPr"`
+	Style  *xlsxInnerXMLAttrs `xml:"style"`
+	TxBody *decodeTextBody    `xml:"txBody"`
+}
+
+// decodeTextBody defines the structure used to deserialize the txBody element
+// of the shape, the body properties, list styles, and the properties of the
+// paragraphs and the text runs are kept.
+type decodeTextBody struct {
+	BodyPr   *xlsxInnerXMLAttrs `xml:"bodyPr"`
+	LstStyle *xlsxInnerXMLAttrs `xml:"lstStyle"`
+	P        []decodeTextP      `xml:"p"`
+}
+
+// decodeTextP defines the structure used to deserialize the paragraph
+// properties and the run properties of the paragraph in the text body.
+type decodeTextP struct {
+	PPr *xlsxInnerXMLAttrs `xml:"pPr"`
+	R   []struct {
+		RPr *xlsxInnerXMLAttrs `xml:"rPr"`
+	} `xml:"r"`
+}
+
+// decodeGrpSp defines the structure used to deserialize the grpSp element,
+// the shapes and the nested group shapes within the group are kept.
+type decodeGrpSp struct {
+	CNvPr *decodeCNvPr   `xml:"nvGrpSpPr>cNvPr"`
+	Sp    []*decodeSp    `xml:"sp"`
+	GrpSp []*decodeGrpSp `xml:"grpSp"`
+}
+
+// decodeSlicerAnchor defines the structure used to deserialize the cell anchor
+// of the slicer or timeline shape.
+type decodeSlicerAnchor struct {
+	From             *decodeFrom                   `xml:"from"`
+	To               *decodeTo                     `xml:"to"`
+	ClientData       *decodeClientData             `xml:"clientData"`
+	AlternateContent *decodeSlicerAlternateContent `xml:"AlternateContent"`
+}
+
+// decodeSlicerAlternateContent defines the structure used to deserialize the
+// alternate content of the slicer or timeline shape, the choice element
+// contains the graphic frame of the slicer, and the fallback element contains
+// the shape for the applications which don't support slicers.
+type decodeSlicerAlternateContent struct {
+	GraphicFrame *decodeSlicerGraphicFrame `xml:"Choice>graphicFrame"`
+	Sp           *decodeSp                 `xml:"Fallback>sp"`
+}
+
+// decodeSlicerGraphicFrame defines the structure used to deserialize the
+// graphic frame of the slicer or timeline shape.
+type decodeSlicerGraphicFrame struct {
+	CNvPr *decodeCNvPr `xml:"nvGraphicFramePr>cNvPr"`
+}
+
 // decodeCellAnchorPos defines the structure used to deserialize the cell anchor
 // for adjust drawing object on inserting/deleting column/rows.
 type decodeCellAnchorPos struct {
@@ -54,6 +128,61 @@ type decodeSp struct {
 	FPublished *bool         `xml:"fPublished,attr"`
 	NvSpPr     *decodeNvSpPr `xml:"nvSpPr"`
 	SpPr       *decodeSpPr   `xml:"spPr"`
+	Style      *decodeStyle  `xml:"style"`
+	TxBody     *decodeTxBody `xml:"txBody"`
+}
+
+// decodeStyle directly maps the style element. This element specifies the
+// style information for a shape, such as the line and fill color references.
+type decodeStyle struct {
+	LnRef   *decodeStyleRef `xml:"lnRef"`
+	FillRef *decodeStyleRef `xml:"fillRef"`
+}
+
+// decodeStyleRef directly maps the lnRef and fillRef element of the shape
+// style.
+type decodeStyleRef struct {
+	Idx     int            `xml:"idx,attr"`
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
+}
+
+// decodeTxBody directly maps the txBody element. This element specifies the
+// existence of text to be contained within the corresponding shape.
+type decodeTxBody struct {
+	P []decodeP `xml:"p"`
+}
+
+// decodeP directly maps the p element. This element specifies the presence
+// of a paragraph of text within the containing text body, the text runs and
+// line breaks of the paragraph are kept in document order.
+type decodeP struct {
+	Content []decodeTextRun `xml:",any"`
+}
+
+// decodeTextRun directly maps the child elements of the paragraph, such as
+// the text run, text field and line break.
+type decodeTextRun struct {
+	XMLName xml.Name
+	RPr     *decodeRPr `xml:"rPr"`
+	T       string     `xml:"t"`
+}
+
+// decodeRPr directly maps the rPr element. This element specifies a set of
+// run properties which shall be applied to the contents of the parent run.
+type decodeRPr struct {
+	B         bool             `xml:"b,attr"`
+	I         bool             `xml:"i,attr"`
+	Strike    string           `xml:"strike,attr"`
+	Sz        float64          `xml:"sz,attr"`
+	U         string           `xml:"u,attr"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	Latin     *decodeTextFont  `xml:"latin"`
+}
+
+// decodeTextFont directly maps the latin element. This element specifies
+// that a Latin font be used for a specific run of text.
+type decodeTextFont struct {
+	Typeface string `xml:"typeface,attr"`
 }
 
 // decodeSp (Non-Visual Properties for a Shape) directly maps the nvSpPr
@@ -123,9 +252,11 @@ type decodePicLocks struct {
 // decodeBlip element specifies the existence of an image (binary large image
 // or picture) and contains a reference to the image data.
 type decodeBlip struct {
-	Embed  string `xml:"embed,attr"`
-	Cstate string `xml:"cstate,attr,omitempty"`
-	R      string `xml:"r,attr"`
+	Embed       string           `xml:"embed,attr"`
+	Cstate      string           `xml:"cstate,attr,omitempty"`
+	R           string           `xml:"r,attr"`
+	AlphaModFix *xlsxAlphaModFix `xml:"alphaModFix"`
+	Lum         *xlsxLum         `xml:"lum"`
 }
 
 // decodeStretch directly maps the stretch element. This element specifies
@@ -161,8 +292,11 @@ type decodePrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type decodeXfrm struct {
-	Off decodeOff  `xml:"off"`
-	Ext decodeAExt `xml:"ext"`
+	Rot   int        `xml:"rot,attr,omitempty"`
+	FlipH bool       `xml:"flipH,attr,omitempty"`
+	FlipV bool       `xml:"flipV,attr,omitempty"`
+	Off   decodeOff  `xml:"off"`
+	Ext   decodeAExt `xml:"ext"`
 }
 
 // decodeCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -191,6 +325,7 @@ type decodeNvPicPr struct {
 // fills specified for a picture object.
 type decodeBlipFill struct {
 	Blip    decodeBlip    `xml:"blip"`
+	SrcRect *xlsxSrcRect  `xml:"srcRect"`
 	Stretch decodeStretch `xml:"stretch"`
 }
 
@@ -200,8 +335,78 @@ type decodeBlipFill struct {
 // properties of a shape but are used here to describe the visual appearance
 // of a picture within a document.
 type decodeSpPr struct {
-	Xfrm     decodeXfrm     `xml:"xfrm"`
-	PrstGeom decodePrstGeom `xml:"prstGeom"`
+	Xfrm      decodeXfrm       `xml:"xfrm"`
+	PrstGeom  decodePrstGeom   `xml:"prstGeom"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	GradFill  *decodeGradFill  `xml:"gradFill"`
+	Ln        *decodeLn        `xml:"ln"`
+	EffectLst *decodeEffectLst `xml:"effectLst"`
+}
+
+// decodeLn directly maps the ln element. This element specifies an outline
+// style that can be applied to a number of different objects like shapes and
+// pictures.
+type decodeLn struct {
+	W         int              `xml:"w,attr,omitempty"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	PrstDash  *attrValString   `xml:"prstDash"`
+}
+
+// decodeGradFill directly maps the gradFill element. This element defines a
+// gradient fill.
+type decodeGradFill struct {
+	GsLst []decodeGs  `xml:"gsLst>gs"`
+	Lin   *decodeLin  `xml:"lin"`
+	Path  *decodePath `xml:"path"`
+}
+
+// decodeGs directly maps the gs element. This element defines a gradient
+// stop.
+type decodeGs struct {
+	Pos     int            `xml:"pos,attr"`
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
+}
+
+// decodeLin directly maps the lin element. This element specifies a linear
+// gradient.
+type decodeLin struct {
+	Ang int `xml:"ang,attr"`
+}
+
+// decodePath directly maps the path element. This element defines that a
+// gradient fill follows a path vs. a linear line.
+type decodePath struct {
+	Path       string       `xml:"path,attr"`
+	FillToRect *xlsxSrcRect `xml:"fillToRect"`
+}
+
+// decodeSolidFill directly maps the solidFill element. This element specifies
+// a solid color fill.
+type decodeSolidFill struct {
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
+}
+
+// decodeEffectLst directly maps the effectLst element. This element specifies
+// a list of effects, such as the outer shadow of the shape or picture.
+type decodeEffectLst struct {
+	OuterShdw *decodeOuterShdw `xml:"outerShdw"`
+}
+
+// decodeOuterShdw directly maps the outerShdw element. This element specifies
+// an outer shadow effect, which is applied outside the edges of the object.
+type decodeOuterShdw struct {
+	BlurRad int            `xml:"blurRad,attr"`
+	Dist    int            `xml:"dist,attr"`
+	Dir     int            `xml:"dir,attr"`
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
+}
+
+// decodeSrgbClr directly maps the srgbClr element. This element specifies a
+// color using the red, green, blue RGB color model, and the alpha element
+// specifies its opacity.
+type decodeSrgbClr struct {
+	Val   string      `xml:"val,attr"`
+	Alpha *attrValInt `xml:"alpha"`
 }
 
 // decodePic elements encompass the definition of pictures within the
@@ -63,10 +63,37 @@ type xlsxPicLocks struct {
 // xlsxBlip element specifies the existence of an image (binary large image or
 // picture) and contains a reference to the image data.
 type xlsxBlip struct {
-	Embed   string                        `xml:"r:embed,attr"`
-	Cstate  string                        `xml:"cstate,attr,omitempty"`
-	R       string                        `xml:"xmlns:r,attr"`
-	ExtList *xlsxEGOfficeArtExtensionList `xml:"a:extLst"`
+	Embed       string                        `xml:"r:embed,attr"`
+	Cstate      string                        `xml:"cstate,attr,omitempty"`
+	R           string                        `xml:"xmlns:r,attr"`
+	AlphaModFix *xlsxAlphaModFix              `xml:"a:alphaModFix"`
+	Lum         *xlsxLum                      `xml:"a:lum"`
+	ExtList     *xlsxEGOfficeArtExtensionList `xml:"a:extLst"`
+}
+
+// xlsxAlphaModFix directly maps the alphaModFix element. This element
+// represents an alpha modulate fixed effect, the amt attribute specifies the
+// percentage of the opacity to be applied to the picture.
+type xlsxAlphaModFix struct {
+	Amt int `xml:"amt,attr"`
+}
+
+// xlsxLum directly maps the lum element. This element represents a luminance
+// effect, that brightens or darkens the picture and changes its contrast.
+type xlsxLum struct {
+	Bright   int `xml:"bright,attr,omitempty"`
+	Contrast int `xml:"contrast,attr,omitempty"`
+}
+
+// xlsxSrcRect directly maps the srcRect element. This element specifies a
+// portion of the picture to be used for the fill, each edge of the source
+// rectangle is defined by a percentage offset from the corresponding edge of
+// the bounding box.
+type xlsxSrcRect struct {
+	L int `xml:"l,attr,omitempty"`
+	T int `xml:"t,attr,omitempty"`
+	R int `xml:"r,attr,omitempty"`
+	B int `xml:"b,attr,omitempty"`
 }
 
 // xlsxStretch directly maps the stretch element. This element specifies that a
@@ -102,8 +129,11 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext aExt    `xml:"a:ext"`
+	Rot   int     `xml:"rot,attr,omitempty"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   aExt    `xml:"a:ext"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -153,16 +183,85 @@ type xlsxEGOfficeArtExtensionList struct {
 // picture has a picture fill already by default, it is possible to have two
 // fills specified for a picture object.
 type xlsxBlipFill struct {
-	Blip    xlsxBlip    `xml:"a:blip"`
-	Stretch xlsxStretch `xml:"a:stretch"`
+	Blip    xlsxBlip     `xml:"a:blip"`
+	SrcRect *xlsxSrcRect `xml:"a:srcRect"`
+	Stretch xlsxStretch  `xml:"a:stretch"`
 }
 
 // xlsxLineProperties specifies the width of a line in EMUs. This simple type
 // has a minimum value of greater than or equal to 0. This simple type has a
 // maximum value of less than or equal to 20116800.
 type xlsxLineProperties struct {
-	W         int           `xml:"w,attr,omitempty"`
-	SolidFill *xlsxInnerXML `xml:"a:solidFill"`
+	W         int            `xml:"w,attr,omitempty"`
+	SolidFill *xlsxInnerXML  `xml:"a:solidFill"`
+	PrstDash  *attrValString `xml:"a:prstDash"`
+	HeadEnd   *xlsxLineEnd   `xml:"a:headEnd"`
+	TailEnd   *xlsxLineEnd   `xml:"a:tailEnd"`
+}
+
+// xlsxLineEnd directly maps the headEnd and tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line,
+// such as the arrowheads of the connector.
+type xlsxLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
+	W    string `xml:"w,attr,omitempty"`
+	Len  string `xml:"len,attr,omitempty"`
+}
+
+// xlsxGradFill directly maps the gradFill element. This element defines a
+// gradient fill.
+type xlsxGradFill struct {
+	RotWithShape bool      `xml:"rotWithShape,attr,omitempty"`
+	GsLst        []xlsxGs  `xml:"a:gsLst>a:gs"`
+	Lin          *xlsxLin  `xml:"a:lin"`
+	Path         *xlsxPath `xml:"a:path"`
+}
+
+// xlsxGs directly maps the gs element. This element defines a gradient stop,
+// which specifies the color and the position of the stop.
+type xlsxGs struct {
+	Pos     int          `xml:"pos,attr"`
+	SrgbClr *xlsxSrgbClr `xml:"a:srgbClr"`
+}
+
+// xlsxLin directly maps the lin element. This element specifies a linear
+// gradient.
+type xlsxLin struct {
+	Ang    int  `xml:"ang,attr"`
+	Scaled bool `xml:"scaled,attr"`
+}
+
+// xlsxPath directly maps the path element. This element defines that a
+// gradient fill follows a path vs. a linear line, and the fillToRect element
+// specifies the focus rectangle for the center shade.
+type xlsxPath struct {
+	Path       string       `xml:"path,attr"`
+	FillToRect *xlsxSrcRect `xml:"a:fillToRect"`
+}
+
+// xlsxEffectList directly maps the effectLst element. This element specifies
+// a list of effects, such as the outer shadow of the shape or picture.
+type xlsxEffectList struct {
+	OuterShdw *xlsxOuterShadow `xml:"a:outerShdw"`
+}
+
+// xlsxOuterShadow directly maps the outerShdw element. This element specifies
+// an outer shadow effect, which is applied outside the edges of the object.
+type xlsxOuterShadow struct {
+	BlurRad      int          `xml:"blurRad,attr,omitempty"`
+	Dist         int          `xml:"dist,attr,omitempty"`
+	Dir          int          `xml:"dir,attr,omitempty"`
+	Algn         string       `xml:"algn,attr,omitempty"`
+	RotWithShape bool         `xml:"rotWithShape,attr"`
+	SrgbClr      *xlsxSrgbClr `xml:"a:srgbClr"`
+}
+
+// xlsxSrgbClr directly maps the srgbClr element. This element specifies a
+// color using the red, green, blue RGB color model, and the alpha element
+// specifies its opacity.
+type xlsxSrgbClr struct {
+	Val   string      `xml:"val,attr"`
+	Alpha *attrValInt `xml:"a:alpha"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -174,7 +273,9 @@ type xlsxSpPr struct {
 	Xfrm      xlsxXfrm           `xml:"a:xfrm"`
 	PrstGeom  xlsxPrstGeom       `xml:"a:prstGeom"`
 	SolidFill *xlsxInnerXML      `xml:"a:solidFill"`
+	GradFill  *xlsxGradFill      `xml:"a:gradFill"`
 	Ln        xlsxLineProperties `xml:"a:ln"`
+	EffectLst *xlsxEffectList    `xml:"a:effectLst"`
 }
 
 // xlsxPic elements encompass the definition of pictures within the DrawingML
@@ -225,6 +326,8 @@ type xdrCellAnchor struct {
 	Ext              *aExt                   `xml:"xdr:ext"`
 	Sp               *xdrSp                  `xml:"xdr:sp"`
 	Pic              *xlsxPic                `xml:"xdr:pic,omitempty"`
+	GrpSp            *xdrGrpSp               `xml:"xdr:grpSp"`
+	CxnSp            *xdrCxnSp               `xml:"xdr:cxnSp"`
 	GraphicFrame     string                  `xml:",innerxml"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
 	ClientData       *xdrClientData          `xml:"xdr:clientData"`
@@ -263,6 +366,60 @@ type xlsxSp struct {
 	Content    string `xml:",innerxml"`
 }
 
+// xlsxInnerXMLAttrs defines the structure used to keep the attributes and the
+// inner XML content of an element.
+type xlsxInnerXMLAttrs struct {
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",innerxml"`
+}
+
+// xlsxTextShapeAnchor defines the structure used to serialize the inner
+// content of the cell anchor of the shape on replacing the text body of the
+// shape.
+type xlsxTextShapeAnchor struct {
+	Pos        *xlsxInnerXMLAttrs `xml:"xdr:pos"`
+	From       *xlsxInnerXMLAttrs `xml:"xdr:from"`
+	To         *xlsxInnerXMLAttrs `xml:"xdr:to"`
+	Ext        *xlsxInnerXMLAttrs `xml:"xdr:ext"`
+	Sp         *xlsxTextSp        `xml:"xdr:sp"`
+	ClientData *xlsxInnerXMLAttrs `xml:"xdr:clientData"`
+}
+
+// xlsxTextSp defines the structure used to serialize the sp element with the
+// replaced text body.
+type xlsxTextSp struct {
+	Attrs  []xml.Attr         `xml:",any,attr"`
+	NvSpPr *xlsxInnerXMLAttrs `xml:"xdr:nvSpPr"`
+	SpPr   *xlsxInnerXMLAttrs `xml:"xdr:spPr"`
+	Style  *xlsxInnerXMLAttrs `xml:"xdr:style"`
+	TxBody *xlsxTextBody      `xml:"xdr:txBody"`
+}
+
+// xlsxTextBody defines the structure used to serialize the txBody element of
+// the shape.
+type xlsxTextBody struct {
+	BodyPr   *xlsxInnerXMLAttrs `xml:"a:bodyPr"`
+	LstStyle *xlsxInnerXMLAttrs `xml:"a:lstStyle"`
+	P        []xlsxTextP        `xml:"a:p"`
+}
+
+// xlsxTextP defines the structure used to serialize the paragraph in the text
+// body, the text runs and line breaks of the paragraph are kept in order.
+type xlsxTextP struct {
+	PPr        *xlsxInnerXMLAttrs `xml:"a:pPr"`
+	Content    []xlsxTextRun
+	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
+}
+
+// xlsxTextRun defines the structure used to serialize the text run or the line
+// break of the paragraph, the name of the element is a:r or a:br, and the run
+// properties is the *aRPr or *xlsxInnerXMLAttrs.
+type xlsxTextRun struct {
+	XMLName xml.Name
+	RPr     interface{} `xml:"a:rPr"`
+	T       *string     `xml:"a:t"`
+}
+
 // xlsxPoint2D describes the position of a drawing element within a spreadsheet.
 type xlsxPoint2D struct {
 	XMLName xml.Name `xml:"xdr:pos"`
@@ -274,6 +431,7 @@ type xlsxPoint2D struct {
 // wsDr.
 type xlsxWsDr struct {
 	mu               sync.Mutex
+	objectIDOffset   int
 	XMLName          xml.Name                `xml:"xdr:wsDr"`
 	NS               string                  `xml:"xmlns,attr,omitempty"`
 	A                string                  `xml:"xmlns:a,attr,omitempty"`
@@ -326,6 +484,7 @@ type xlsxGraphicData struct {
 	URI   string     `xml:"uri,attr"`
 	Chart *xlsxChart `xml:"c:chart,omitempty"`
 	Sle   *xlsxSle   `xml:"sle:slicer"`
+	Tsle  *xlsxTsle  `xml:"tsle:timeslicer"`
 }
 
 type xlsxSle struct {
@@ -333,6 +492,13 @@ type xlsxSle struct {
 	Name  string `xml:"name,attr"`
 }
 
+// xlsxTsle directly maps the tsle:timeslicer element. This element specifies
+// the timeline reference of the graphic frame by the timeline name.
+type xlsxTsle struct {
+	XMLNS string `xml:"xmlns:tsle,attr"`
+	Name  string `xml:"name,attr"`
+}
+
 // xlsxChart (Chart) directly maps the c:chart element.
 type xlsxChart struct {
 	C   string `xml:"xmlns:c,attr"`
@@ -357,6 +523,79 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrGrpSp (Group Shape) directly maps the xdr:grpSp element. This element
+// specifies a group shape that represents many shapes grouped together, the
+// shapes and pictures within the group are positioned in the child coordinate
+// space of the group.
+type xdrGrpSp struct {
+	XMLName   xml.Name      `xml:"xdr:grpSp"`
+	NvGrpSpPr *xdrNvGrpSpPr `xml:"xdr:nvGrpSpPr"`
+	GrpSpPr   *xdrGrpSpPr   `xml:"xdr:grpSpPr"`
+	Sp        []*xdrSp      `xml:"xdr:sp"`
+	Pic       []*xlsxPic    `xml:"xdr:pic"`
+}
+
+// xdrNvGrpSpPr (Non-Visual Properties for a Group Shape) directly maps the
+// xdr:nvGrpSpPr element. This element specifies all non-visual properties for
+// a group shape.
+type xdrNvGrpSpPr struct {
+	CNvPr      *xlsxCNvPr    `xml:"xdr:cNvPr"`
+	CNvGrpSpPr *xlsxInnerXML `xml:"xdr:cNvGrpSpPr"`
+}
+
+// xdrGrpSpPr (Group Shape Properties) directly maps the xdr:grpSpPr element.
+// This element specifies the properties that are to be common across all of
+// the shapes within the corresponding group.
+type xdrGrpSpPr struct {
+	Xfrm xlsxGrpXfrm `xml:"a:xfrm"`
+}
+
+// xlsxGrpXfrm directly maps the a:xfrm element of the group shape. The chOff
+// and chExt element specifies the location and the size of the child
+// coordinate space of the group.
+type xlsxGrpXfrm struct {
+	Rot   int     `xml:"rot,attr,omitempty"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   aExt    `xml:"a:ext"`
+	ChOff xlsxOff `xml:"a:chOff"`
+	ChExt aExt    `xml:"a:chExt"`
+}
+
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes.
+type xdrCxnSp struct {
+	XMLName   xml.Name      `xml:"xdr:cxnSp"`
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element. This element specifies all non-visual properties
+// for a connection shape.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr     `xml:"xdr:cNvPr"`
+	CNvCxnSpPr *xdrCNvCxnSpPr `xml:"xdr:cNvCxnSpPr"`
+}
+
+// xdrCNvCxnSpPr (Non-Visual Connector Shape Drawing Properties) directly maps
+// the xdr:cNvCxnSpPr element. The stCxn and endCxn element specifies the
+// starting and ending connection of the connector shape.
+type xdrCNvCxnSpPr struct {
+	StCxn  *xlsxConnection `xml:"a:stCxn"`
+	EndCxn *xlsxConnection `xml:"a:endCxn"`
+}
+
+// xlsxConnection directly maps the a:stCxn and a:endCxn element. This element
+// specifies the shape ID and the connection site index of the connected shape.
+type xlsxConnection struct {
+	ID  int `xml:"id,attr"`
+	Idx int `xml:"idx,attr"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
@@ -445,11 +684,46 @@ type GraphicOptions struct {
 	Hyperlink           string
 	HyperlinkType       string
 	Positioning         string
+	Crop                *PictureCrop
+	Rotation            int
+	FlipHorizontal      bool
+	FlipVertical        bool
+	Border              *PictureBorder
+	Transparency        int
+	Brightness          int
+	Contrast            int
+	Shadow              *PictureShadow
+}
+
+// PictureCrop directly maps the cropping settings of the picture, each value
+// specifies the percentage of the picture trimmed from the corresponding edge.
+type PictureCrop struct {
+	Left   float64
+	Top    float64
+	Right  float64
+	Bottom float64
+}
+
+// PictureBorder directly maps the border line settings of the picture.
+type PictureBorder struct {
+	Color string
+	Width float64
+	Dash  string
+}
+
+// PictureShadow directly maps the outer shadow settings of the picture.
+type PictureShadow struct {
+	Color        string
+	Transparency int
+	Blur         float64
+	Distance     float64
+	Angle        int
 }
 
 // Shape directly maps the format settings of the shape.
 type Shape struct {
 	Cell      string
+	Name      string
 	Type      string
 	Macro     string
 	Width     uint
@@ -460,6 +734,29 @@ type Shape struct {
 	Paragraph []RichTextRun
 }
 
+// GroupShape directly maps the format settings of the group shape. The
+// OffsetX and OffsetY of the shapes and pictures in the group specifies the
+// position relative to the upper left corner of the group in pixels.
+type GroupShape struct {
+	Cell     string
+	Name     string
+	Format   GraphicOptions
+	Shapes   []Shape
+	Pictures []Picture
+}
+
+// Connector directly maps the format settings of the connector shape.
+type Connector struct {
+	Name       string
+	Type       string
+	From       string
+	To         string
+	Line       ShapeLine
+	BeginArrow string
+	EndArrow   string
+	Format     GraphicOptions
+}
+
 // ShapeLine directly maps the line settings of the shape.
 type ShapeLine struct {
 	Color string
@@ -24,7 +24,8 @@ import "encoding/xml"
 // can be propagated along with the value as it is referenced in formulas.
 type xlsxMetadata struct {
 	XMLName         xml.Name             `xml:"metadata"`
-	MetadataTypes   *xlsxInnerXML        `xml:"metadataTypes"`
+	XMLNS           string               `xml:"xmlns,attr,omitempty"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
 	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
 	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
 	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
@@ -33,9 +34,53 @@ type xlsxMetadata struct {
 	ExtLst          *xlsxInnerXML        `xml:"extLst"`
 }
 
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the set of metadata types used in this workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr,omitempty"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single set of metadata type properties, and the flags which
+// specify how the metadata is propagated when the cell it is associated with
+// is changed.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
 // xlsxFutureMetadata directly maps the futureMetadata element. This element
 // represents future metadata information.
 type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
 	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
 	ExtLst *xlsxInnerXML             `xml:"extLst"`
 }
@@ -72,6 +117,7 @@ type xlsxMetadataRecord struct {
 // data.
 type xlsxRichValueData struct {
 	XMLName xml.Name        `xml:"rvData"`
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
 	Count   int             `xml:"count,attr,omitempty"`
 	Rv      []xlsxRichValue `xml:"rv"`
 	ExtLst  *xlsxInnerXML   `xml:"extLst"`
@@ -85,10 +131,36 @@ type xlsxRichValue struct {
 	Fb *xlsxInnerXML `xml:"fb"`
 }
 
+// xlsxRichValueStructures directly maps the rvStructures element that
+// specifies a list of rich value structures.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"rvStructures"`
+	XMLNS   string                   `xml:"xmlns,attr,omitempty"`
+	Count   int                      `xml:"count,attr,omitempty"`
+	S       []xlsxRichValueStructure `xml:"s"`
+	ExtLst  *xlsxInnerXML            `xml:"extLst"`
+}
+
+// xlsxRichValueStructure directly maps the s element that specifies a rich
+// value structure, which is an ordered collection of rich value keys.
+type xlsxRichValueStructure struct {
+	T string             `xml:"t,attr"`
+	K []xlsxRichValueKey `xml:"k"`
+}
+
+// xlsxRichValueKey directly maps the k element that specifies the name and
+// value type of a key in a rich value structure.
+type xlsxRichValueKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
 // xlsxRichValueRels directly maps the richValueRels element. This element that
 // specifies a list of rich value relationships.
 type xlsxRichValueRels struct {
 	XMLName xml.Name                       `xml:"richValueRels"`
+	XMLNS   string                         `xml:"xmlns,attr,omitempty"`
+	R       string                         `xml:"xmlns:r,attr,omitempty"`
 	Rels    []xlsxRichValueRelRelationship `xml:"rel"`
 	ExtLst  *xlsxInnerXML                  `xml:"extLst"`
 }
@@ -96,7 +168,8 @@ type xlsxRichValueRels struct {
 // xlsxRichValueRelRelationship directly maps the rel element. This element
 // specifies a relationship for a rich value property.
 type xlsxRichValueRelRelationship struct {
-	ID string `xml:"id,attr"`
+	ID  string `xml:"id,attr,omitempty"`
+	RID string `xml:"r:id,attr,omitempty"`
 }
 
 // xlsxWebImagesSupportingRichData directly maps the webImagesSrd element. This
@@ -104,6 +177,7 @@ type xlsxRichValueRelRelationship struct {
 // values.
 type xlsxWebImagesSupportingRichData struct {
 	XMLName     xml.Name                         `xml:"webImagesSrd"`
+	XMLNS       string                           `xml:"xmlns,attr,omitempty"`
 	WebImageSrd []xlsxWebImageSupportingRichData `xml:"webImageSrd"`
 	ExtLst      *xlsxInnerXML                    `xml:"extLst"`
 }
@@ -111,7 +185,7 @@ type xlsxWebImagesSupportingRichData struct {
 // xlsxWebImageSupportingRichData directly maps the webImageSrd element. This
 // element specifies a set of properties for a web image rich value.
 type xlsxWebImageSupportingRichData struct {
-	Address           xlsxExternalReference `xml:"address"`
-	MoreImagesAddress xlsxExternalReference `xml:"moreImagesAddress"`
-	Blip              xlsxExternalReference `xml:"blip"`
+	Address           xlsxExternalReference  `xml:"address"`
+	MoreImagesAddress *xlsxExternalReference `xml:"moreImagesAddress"`
+	Blip              xlsxExternalReference  `xml:"blip"`
 }
@@ -149,9 +149,12 @@ type xlsxX15SlicerCaches struct {
 // decodeTableSlicerCache defines the structure used to parse the
 // x15:tableSlicerCache element of the table slicer cache.
 type decodeTableSlicerCache struct {
-	XMLName xml.Name `xml:"tableSlicerCache"`
-	TableID int      `xml:"tableId,attr"`
-	Column  int      `xml:"column,attr"`
+	XMLName        xml.Name `xml:"tableSlicerCache"`
+	TableID        int      `xml:"tableId,attr"`
+	Column         int      `xml:"column,attr"`
+	SortOrder      string   `xml:"sortOrder,attr"`
+	CustomListSort *bool    `xml:"customListSort,attr"`
+	CrossFilter    string   `xml:"crossFilter,attr"`
 }
 
 // decodeSlicerList defines the structure used to parse the x14:slicerList
@@ -170,8 +173,9 @@ type decodeSlicer struct {
 // decodeSlicerCaches defines the structure used to parse the
 // x14:slicerCaches and x15:slicerCaches element of a slicer cache.
 type decodeSlicerCaches struct {
-	XMLName xml.Name `xml:"slicerCaches"`
-	Content string   `xml:",innerxml"`
+	XMLName     xml.Name        `xml:"slicerCaches"`
+	SlicerCache []*decodeSlicer `xml:"slicerCache"`
+	Content     string          `xml:",innerxml"`
 }
 
 // xlsxTimelines is a mechanism for filtering data in pivot table views, cube
@@ -203,3 +207,81 @@ type xlsxTimeline struct {
 	ScrollPosition          string `xml:"scrollPosition,attr,omitempty"`
 	Style                   string `xml:"style,attr,omitempty"`
 }
+
+// xlsxTimelineCacheDefinition directly maps the timelineCacheDefinition
+// element that specifies a timeline cache.
+type xlsxTimelineCacheDefinition struct {
+	XMLName     xml.Name                    `xml:"http://schemas.microsoft.com/office/spreadsheetml/2010/11/main timelineCacheDefinition"`
+	XMLNSXMC    string                      `xml:"xmlns:mc,attr"`
+	XMLNSX      string                      `xml:"xmlns:x,attr"`
+	XMLNSXR10   string                      `xml:"xmlns:xr10,attr"`
+	Name        string                      `xml:"name,attr"`
+	XR10UID     string                      `xml:"xr10:uid,attr,omitempty"`
+	SourceName  string                      `xml:"sourceName,attr"`
+	PivotTables *xlsxSlicerCachePivotTables `xml:"pivotTables"`
+	State       *xlsxTimelineState          `xml:"state"`
+	ExtLst      *xlsxExtLst                 `xml:"extLst"`
+}
+
+// xlsxTimelineState is a complex type that specifies the filter state, the
+// selection and the bounds of the timeline cache.
+type xlsxTimelineState struct {
+	SingleRangeFilterState bool               `xml:"singleRangeFilterState,attr,omitempty"`
+	MinimalRefreshVersion  int                `xml:"minimalRefreshVersion,attr"`
+	LastRefreshVersion     int                `xml:"lastRefreshVersion,attr"`
+	PivotCacheID           int                `xml:"pivotCacheId,attr"`
+	FilterType             string             `xml:"filterType,attr"`
+	Selection              *xlsxTimelineRange `xml:"selection"`
+	Bounds                 *xlsxTimelineRange `xml:"bounds"`
+	ExtLst                 *xlsxExtLst        `xml:"extLst"`
+}
+
+// xlsxTimelineRange is a complex type that specifies a date range of the
+// timeline cache.
+type xlsxTimelineRange struct {
+	StartDate string `xml:"startDate,attr"`
+	EndDate   string `xml:"endDate,attr"`
+}
+
+// xlsxX15TimelineRefs specifies a list of timeline.
+type xlsxX15TimelineRefs struct {
+	XMLName     xml.Name              `xml:"x15:timelineRefs"`
+	TimelineRef []*xlsxX15TimelineRef `xml:"x15:timelineRef"`
+}
+
+// xlsxX15TimelineRef specifies a timeline part reference of the worksheet.
+type xlsxX15TimelineRef struct {
+	RID string `xml:"r:id,attr"`
+}
+
+// xlsxX15TimelineCacheRefs directly maps the x15:timelineCacheRefs element.
+type xlsxX15TimelineCacheRefs struct {
+	XMLName xml.Name `xml:"x15:timelineCacheRefs"`
+	Content string   `xml:",innerxml"`
+}
+
+// xlsxX15TimelineCacheRef directly maps the x15:timelineCacheRef element.
+type xlsxX15TimelineCacheRef struct {
+	XMLName xml.Name `xml:"x15:timelineCacheRef"`
+	RID     string   `xml:"r:id,attr"`
+}
+
+// decodeTimelineRefs defines the structure used to parse the x15:timelineRefs
+// element of a list of timeline.
+type decodeTimelineRefs struct {
+	XMLName     xml.Name             `xml:"timelineRefs"`
+	TimelineRef []*decodeTimelineRef `xml:"timelineRef"`
+}
+
+// decodeTimelineRef defines the structure used to parse the x15:timelineRef
+// element of a timeline.
+type decodeTimelineRef struct {
+	RID string `xml:"id,attr"`
+}
+
+// decodeTimelineCacheRefs defines the structure used to parse the
+// x15:timelineCacheRefs element of the timeline caches.
+type decodeTimelineCacheRefs struct {
+	XMLName xml.Name `xml:"timelineCacheRefs"`
+	Content string   `xml:",innerxml"`
+}
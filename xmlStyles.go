@@ -223,6 +223,47 @@ type xlsxXf struct {
 	ApplyProtection   *bool           `xml:"applyProtection,attr"`
 	Alignment         *xlsxAlignment  `xml:"alignment"`
 	Protection        *xlsxProtection `xml:"protection"`
+	ExtLst            *xlsxExtLst     `xml:"extLst"`
+}
+
+// xlsxFeaturePropertyBags directly maps the FeaturePropertyBags element in the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag.
+// This element is the root of the feature property bag part, which contains
+// the property bags of the features applied on the cell formats, such as the
+// in-cell checkbox.
+type xlsxFeaturePropertyBags struct {
+	XMLName xml.Name                 `xml:"FeaturePropertyBags"`
+	XMLNS   string                   `xml:"xmlns,attr"`
+	Bag     []xlsxFeaturePropertyBag `xml:"bag"`
+}
+
+// xlsxFeaturePropertyBag directly maps the bag element. This element specifies
+// a feature property bag by type, which can reference other bags by index.
+type xlsxFeaturePropertyBag struct {
+	Type   string         `xml:"type,attr"`
+	ExtRef string         `xml:"extRef,attr,omitempty"`
+	BagID  []xlsxBagID    `xml:"bagId"`
+	A      []xlsxBagArray `xml:"a"`
+}
+
+// xlsxBagID directly maps the bagId element. This element specifies the index
+// of the referenced feature property bag.
+type xlsxBagID struct {
+	K   string `xml:"k,attr,omitempty"`
+	Val int    `xml:",chardata"`
+}
+
+// xlsxBagArray directly maps the a element. This element specifies an array of
+// the feature property bag references.
+type xlsxBagArray struct {
+	K     string      `xml:"k,attr"`
+	BagID []xlsxBagID `xml:"bagId"`
+}
+
+// decodeXfComplement defines the structure used to parse the xfComplement
+// element in the extension list of the cell format.
+type decodeXfComplement struct {
+	I int `xml:"i,attr"`
 }
 
 // xlsxCellXfs directly maps the cellXfs element. This element contains the
@@ -275,11 +316,20 @@ type xlsxTableStyles struct {
 // a single table style definition that indicates how a spreadsheet application
 // should format and display a table.
 type xlsxTableStyle struct {
-	Name              string `xml:"name,attr,omitempty"`
-	Pivot             int    `xml:"pivot,attr"`
-	Count             int    `xml:"count,attr,omitempty"`
-	Table             bool   `xml:"table,attr,omitempty"`
-	TableStyleElement string `xml:",innerxml"`
+	Name              string                   `xml:"name,attr,omitempty"`
+	Pivot             *bool                    `xml:"pivot,attr"`
+	Table             *bool                    `xml:"table,attr"`
+	Count             int                      `xml:"count,attr,omitempty"`
+	TableStyleElement []*xlsxTableStyleElement `xml:"tableStyleElement"`
+}
+
+// xlsxTableStyleElement directly maps the tableStyleElement element. This
+// element specifies the formatting of a particular area of a table or pivot
+// table, the formatting is defined by a differential formatting record.
+type xlsxTableStyleElement struct {
+	Type  string `xml:"type,attr"`
+	Size  int    `xml:"size,attr,omitempty"`
+	DxfID *int   `xml:"dxfId,attr"`
 }
 
 // xlsxNumFmts directly maps the numFmts element. This element defines the
@@ -364,6 +414,25 @@ type Protection struct {
 	Locked bool
 }
 
+// TableStyleElement directly maps the formatting of an area in the custom
+// table or pivot table style. The Type specifies the area of the table, and
+// the Size specifies the number of rows or columns in a single band of
+// stripes, which only be used for the stripe areas.
+type TableStyleElement struct {
+	Type  string
+	Size  int
+	Style *Style
+}
+
+// TableStyle directly maps the settings of the custom table or pivot table
+// style. Set Pivot to true for defining a pivot table style, otherwise a table
+// style will be defined.
+type TableStyle struct {
+	Name     string
+	Pivot    bool
+	Elements []TableStyleElement
+}
+
 // Style directly maps the style settings of the cells.
 type Style struct {
 	Border        []Border
@@ -44,6 +44,7 @@ type xlsxTable struct {
 	TotalsRowCellStyle   string              `xml:"totalsRowCellStyle,attr,omitempty"`
 	ConnectionID         int                 `xml:"connectionId,attr,omitempty"`
 	AutoFilter           *xlsxAutoFilter     `xml:"autoFilter"`
+	SortState            *xlsxSortState      `xml:"sortState"`
 	TableColumns         *xlsxTableColumns   `xml:"tableColumns"`
 	TableStyleInfo       *xlsxTableStyleInfo `xml:"tableStyleInfo"`
 }
@@ -146,7 +147,7 @@ type xlsxIconFilter struct {
 type xlsxTop10 struct {
 	FilterVal float64 `xml:"filterVal,attr,omitempty"`
 	Percent   bool    `xml:"percent,attr,omitempty"`
-	Top       bool    `xml:"top,attr"`
+	Top       *bool   `xml:"top,attr"`
 	Val       float64 `xml:"val,attr,omitempty"`
 }
 
@@ -177,18 +178,29 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	ID                 int    `xml:"id,attr"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
-	Name               string `xml:"name,attr"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	Name                    string            `xml:"name,attr"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID               *int              `xml:"dataDxfId,attr"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula elements. These elements contains the formula that is used
+// to perform the calculation for each cell in the calculated column, or the
+// custom formula that is used in the totals row of the column.
+type xlsxTableFormula struct {
+	Array   bool   `xml:"array,attr,omitempty"`
+	Content string `xml:",chardata"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -244,10 +256,83 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	ShowTotalsRow     bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the settings of the column in the table. The
+// CalculatedColumnFormula specifies the formula which be filled into each
+// data cell of the column. The TotalsRowFunction specifies the function of
+// the column in the totals row, the optional values are: "average", "count",
+// "countNums", "custom", "max", "min", "stdDev", "sum" and "var", and the
+// TotalsRowFormula specifies the formula for the "custom" function. The
+// NumFmt and CustomNumFmt specifies the number format of the data cells in
+// the column.
+type TableColumn struct {
+	Name                    string
+	CalculatedColumnFormula string
+	TotalsRowFunction       string
+	TotalsRowFormula        string
+	TotalsRowLabel          string
+	NumFmt                  int
+	CustomNumFmt            *string
+}
+
+// AutoFilterDateGroup directly maps the date group item of the auto filter,
+// the Grouping specifies the level of date or time to filter by, the optional
+// values are: "year", "month", "day", "hour", "minute" and "second".
+type AutoFilterDateGroup struct {
+	Grouping string
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Minute   int
+	Second   int
+}
+
+// AutoFilterTop10 directly maps the top or bottom N items or percent settings
+// of the auto filter.
+type AutoFilterTop10 struct {
+	Bottom  bool
+	Percent bool
+	Value   float64
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
 type AutoFilterOptions struct {
+	Column        string
+	Expression    string
+	Values        []string
+	Blank         bool
+	DateGroups    []AutoFilterDateGroup
+	CellColor     string
+	FontColor     string
+	Top10         *AutoFilterTop10
+	DynamicFilter string
+}
+
+// SortBy is the type of sort condition.
+type SortBy byte
+
+// This section defines the currently supported sort by types enumeration.
+const (
+	SortByValue SortBy = iota
+	SortByCellColor
+	SortByFontColor
+)
+
+// SortKey directly maps the settings of a sort key.
+type SortKey struct {
 	Column     string
-	Expression string
+	Descending bool
+	SortBy     SortBy
+	Color      string
+	CustomList []string
+}
+
+// SortOptions directly maps the settings of sorting a range.
+type SortOptions struct {
+	CaseSensitive bool
+	Header        *bool
 }
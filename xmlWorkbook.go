@@ -247,6 +247,7 @@ type xlsxChoice struct {
 	XMLName    xml.Name `xml:"mc:Choice"`
 	XMLNSA14   string   `xml:"xmlns:a14,attr,omitempty"`
 	XMLNSSle15 string   `xml:"xmlns:sle15,attr,omitempty"`
+	XMLNSTsle  string   `xml:"xmlns:tsle,attr,omitempty"`
 	Requires   string   `xml:"Requires,attr,omitempty"`
 	Content    string   `xml:",innerxml"`
 }
@@ -325,11 +325,25 @@ type xlsxRow struct {
 // xlsxSortState directly maps the sortState element. This collection
 // preserves the AutoFilter sort state.
 type xlsxSortState struct {
-	ColumnSort    bool   `xml:"columnSort,attr,omitempty"`
-	CaseSensitive bool   `xml:"caseSensitive,attr,omitempty"`
-	SortMethod    string `xml:"sortMethod,attr,omitempty"`
-	Ref           string `xml:"ref,attr"`
-	Content       string `xml:",innerxml"`
+	ColumnSort    bool                 `xml:"columnSort,attr,omitempty"`
+	CaseSensitive bool                 `xml:"caseSensitive,attr,omitempty"`
+	SortMethod    string               `xml:"sortMethod,attr,omitempty"`
+	Ref           string               `xml:"ref,attr"`
+	SortCondition []*xlsxSortCondition `xml:"sortCondition"`
+	ExtLst        *xlsxExtLst          `xml:"extLst"`
+}
+
+// xlsxSortCondition directly maps the sortCondition element. This element
+// specifies a sort condition for the sort state, including the range of the
+// cells to sort by, the sort order and the sort by type.
+type xlsxSortCondition struct {
+	Descending bool   `xml:"descending,attr,omitempty"`
+	SortBy     string `xml:"sortBy,attr,omitempty"`
+	Ref        string `xml:"ref,attr"`
+	CustomList string `xml:"customList,attr,omitempty"`
+	DxfID      *int   `xml:"dxfId,attr"`
+	IconSet    string `xml:"iconSet,attr,omitempty"`
+	IconID     *int   `xml:"iconId,attr"`
 }
 
 // xlsxCustomSheetViews directly maps the customSheetViews element. This is a
@@ -703,9 +717,51 @@ type xlsxLegacyDrawingHF struct {
 
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
-	XMLName xml.Name `xml:"sparklineGroups"`
-	XMLNSXM string   `xml:"xmlns:xm,attr"`
-	Content string   `xml:",innerxml"`
+	XMLName         xml.Name                   `xml:"sparklineGroups"`
+	XMLNSXM         string                     `xml:"xmlns:xm,attr"`
+	SparklineGroups []*decodeX14SparklineGroup `xml:"sparklineGroup"`
+	Content         string                     `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroup directly maps the sparklineGroup element.
+type decodeX14SparklineGroup struct {
+	ManualMax           int                 `xml:"manualMax,attr"`
+	ManualMin           int                 `xml:"manualMin,attr"`
+	LineWeight          float64             `xml:"lineWeight,attr"`
+	Type                string              `xml:"type,attr"`
+	DateAxis            bool                `xml:"dateAxis,attr"`
+	DisplayEmptyCellsAs string              `xml:"displayEmptyCellsAs,attr"`
+	Markers             bool                `xml:"markers,attr"`
+	High                bool                `xml:"high,attr"`
+	Low                 bool                `xml:"low,attr"`
+	First               bool                `xml:"first,attr"`
+	Last                bool                `xml:"last,attr"`
+	Negative            bool                `xml:"negative,attr"`
+	DisplayXAxis        bool                `xml:"displayXAxis,attr"`
+	DisplayHidden       bool                `xml:"displayHidden,attr"`
+	MinAxisType         string              `xml:"minAxisType,attr"`
+	MaxAxisType         string              `xml:"maxAxisType,attr"`
+	RightToLeft         bool                `xml:"rightToLeft,attr"`
+	ColorSeries         *xlsxColor          `xml:"colorSeries"`
+	ColorNegative       *xlsxColor          `xml:"colorNegative"`
+	ColorAxis           *xlsxColor          `xml:"colorAxis"`
+	ColorMarkers        *xlsxColor          `xml:"colorMarkers"`
+	ColorFirst          *xlsxColor          `xml:"colorFirst"`
+	ColorLast           *xlsxColor          `xml:"colorLast"`
+	ColorHigh           *xlsxColor          `xml:"colorHigh"`
+	ColorLow            *xlsxColor          `xml:"colorLow"`
+	Sparklines          decodeX14Sparklines `xml:"sparklines"`
+}
+
+// decodeX14Sparklines directly maps the sparklines element.
+type decodeX14Sparklines struct {
+	Sparkline []*decodeX14Sparkline `xml:"sparkline"`
+}
+
+// decodeX14Sparkline directly maps the sparkline element.
+type decodeX14Sparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
 }
 
 // decodeX14ConditionalFormattingExt directly maps the ext element.